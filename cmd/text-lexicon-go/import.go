@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	authRepository "github.com/shlembo598/text-lexicon-go/internal/auth/repository"
+	collectionsRepository "github.com/shlembo598/text-lexicon-go/internal/collections/repository"
+	collectionsUseCase "github.com/shlembo598/text-lexicon-go/internal/collections/usecase"
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	dictionariesRepository "github.com/shlembo598/text-lexicon-go/internal/dictionaries/repository"
+	dictionariesUseCase "github.com/shlembo598/text-lexicon-go/internal/dictionaries/usecase"
+	documentsRepository "github.com/shlembo598/text-lexicon-go/internal/documents/repository"
+	documentsUseCase "github.com/shlembo598/text-lexicon-go/internal/documents/usecase"
+	jobsRepository "github.com/shlembo598/text-lexicon-go/internal/jobs/repository"
+	jobsUseCase "github.com/shlembo598/text-lexicon-go/internal/jobs/usecase"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	wordsRepository "github.com/shlembo598/text-lexicon-go/internal/words/repository"
+	wordsUseCase "github.com/shlembo598/text-lexicon-go/internal/words/usecase"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils"
+)
+
+const (
+	importDictionaryCommand = "import-dictionary"
+	importDocsCommand       = "import-docs"
+)
+
+var (
+	errMissingPath = errors.New("dictionary path is required")
+	errMissingTree = errors.New("user email and directory or archive path are required")
+)
+
+// Glob patterns of a flag given several times
+type patternsFlag []string
+
+func (p *patternsFlag) String() string {
+	return strings.Join(*p, ",")
+}
+
+func (p *patternsFlag) Set(value string) error {
+	*p = append(*p, value)
+	return nil
+}
+
+// Import dictionary file given in args:
+// import-dictionary [-format stardict|dsl|tei] [-name name] [-force] path
+func importDictionary(cfg *config.Config, db *sqlx.DB, args []string) error {
+	params := &models.DictionaryImport{}
+
+	flags := flag.NewFlagSet(importDictionaryCommand, flag.ContinueOnError)
+	flags.StringVar(&params.Format, "format", "", "dictionary format: stardict, dsl or tei, guessed by file extension")
+	flags.StringVar(&params.Name, "name", "", "unique dictionary name, file name by default")
+	flags.BoolVar(&params.Force, "force", false, "import even if files were not changed")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s [flags] path\n", importDictionaryCommand)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errMissingPath
+	}
+	params.Path = flags.Arg(0)
+
+	dictionariesUC := dictionariesUseCase.NewDictionariesUseCase(
+		cfg, dictionariesRepository.NewDictionariesRepository(cfg, db),
+		jobsUseCase.NewJobsUseCase(cfg, jobsRepository.NewJobsRepository(db)), nil,
+	)
+
+	dictionary, err := dictionariesUC.Import(context.Background(), params)
+	if err != nil {
+		return err
+	}
+
+	slog.Info(
+		"Dictionary",
+		slog.Int("id", dictionary.DictionaryID),
+		slog.String("name", dictionary.Name),
+		slog.String("status", dictionary.Status),
+		slog.Int("headwords", dictionary.HeadwordsCount),
+	)
+
+	return nil
+}
+
+// Import documentation files of a directory or archive given in args into a new collection of the user:
+// import-docs -user email [-name name] [-include glob]... [-exclude glob]... [-code-mode skip|split] path
+func importDocs(cfg *config.Config, db *sqlx.DB, args []string) error {
+	var email, name string
+	var include, exclude patternsFlag
+	params := &models.ImportParams{}
+
+	flags := flag.NewFlagSet(importDocsCommand, flag.ContinueOnError)
+	flags.StringVar(&email, "user", "", "email of the user owning the collection")
+	flags.StringVar(&name, "name", "", "collection name, directory or archive name by default")
+	flags.Var(
+		&include, "include", "glob pattern of imported files, repeatable, docs, Go and API spec files by default",
+	)
+	flags.Var(
+		&exclude, "exclude", "glob pattern of left out files, repeatable, hidden files and dependencies by default",
+	)
+	flags.StringVar(&params.CodeMode, "code-mode", models.CodeModeSkip, "code mode of documents: skip or split")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s [flags] path\n", importDocsCommand)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 || email == "" {
+		flags.Usage()
+		return errMissingTree
+	}
+	params.Include, params.Exclude = include, exclude
+
+	user, err := authRepository.NewAuthRepository(db).FindByEmail(context.Background(), &models.User{Email: email})
+	if err != nil {
+		return err
+	}
+	ctx := context.WithValue(context.Background(), utils.UserCtxKey{}, user)
+
+	// Imported files are neither fetched nor translated
+	wordsUC := wordsUseCase.NewWordsUseCase(cfg, wordsRepository.NewWordsRepository(db))
+	jobsUC := jobsUseCase.NewJobsUseCase(cfg, jobsRepository.NewJobsRepository(db))
+	documentsUC := documentsUseCase.NewDocumentsUseCase(
+		cfg, documentsRepository.NewDocumentsRepository(db), nil, nil, wordsUC, jobsUC,
+	)
+	collectionsUC := collectionsUseCase.NewCollectionsUseCase(
+		cfg, collectionsRepository.NewCollectionsRepository(db), documentsUC, jobsUC, nil,
+	)
+
+	summary, err := collectionsUC.ImportPath(ctx, &models.Collection{Name: name}, params, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range summary.Files {
+		slog.Info(
+			"Imported",
+			slog.String("path", file.Path),
+			slog.Int("words", file.WordCount),
+			slog.Int("new_words", file.NewWordsCount),
+		)
+	}
+	for _, file := range summary.Skipped {
+		slog.Info("Skipped", slog.String("path", file.Path), slog.String("reason", file.Reason))
+	}
+	slog.Info(
+		"Collection",
+		slog.String("id", summary.CollectionID.String()),
+		slog.Int("documents", len(summary.Files)),
+		slog.Int("skipped", len(summary.Skipped)),
+	)
+
+	return nil
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"log/slog"
+	"os"
 
 	"github.com/shlembo598/text-lexicon-go/internal/config"
 	"github.com/shlembo598/text-lexicon-go/internal/server"
@@ -35,6 +36,19 @@ func main() {
 		}
 	}()
 
+	if len(os.Args) > 1 && os.Args[1] == importDictionaryCommand {
+		if err = importDictionary(cfg, db, os.Args[2:]); err != nil {
+			sl.Fatalf("failed to import dictionary", err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == importDocsCommand {
+		if err = importDocs(cfg, db, os.Args[2:]); err != nil {
+			sl.Fatalf("failed to import documents", err)
+		}
+		return
+	}
+
 	s := server.NewServer(cfg, db)
 	if err = s.Run(); err != nil {
 		sl.Fatalf("failed to run server", err)
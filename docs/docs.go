@@ -15,6 +15,111 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/dictionaries": {
+            "get": {
+                "description": "get imported dictionaries with their import status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dictionaries"
+                ],
+                "summary": "Get dictionaries",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ImportedDictionary"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/dictionaries/import": {
+            "post": {
+                "description": "queue import of a StarDict, DSL or TEI dictionary from the server import directory,\nunchanged dictionary is not imported again unless forced, the dictionary status tells\nwhen the import is done",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dictionaries"
+                ],
+                "summary": "Import dictionary",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImportedDictionary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/dictionaries/{id}": {
+            "get": {
+                "description": "get dictionary with its import status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dictionaries"
+                ],
+                "summary": "Get dictionary by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "dictionary_id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImportedDictionary"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/login": {
             "post": {
                 "description": "login user, returns user and set session",
@@ -128,7 +233,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "update existing user",
+                "description": "update existing user, the CEFR level is used by dictionary filters",
                 "consumes": [
                     "application/json"
                 ],
@@ -193,64 +298,1632 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "httpErrors.RestError": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "integer"
+        },
+        "/collections": {
+            "get": {
+                "description": "get collections of current user, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Get collections",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Collection"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create collection of current user from their existing documents",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Create collection",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Collection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
                 }
             }
         },
-        "models.User": {
-            "type": "object",
-            "required": [
-                "first_name",
-                "last_name",
-                "password"
-            ],
-            "properties": {
-                "avatar": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
+        "/collections/crawl": {
+            "post": {
+                "description": "create collection and crawl a documentation site into it starting from a page or sitemap.xml,\nsame origin links under the path prefix are followed, robots.txt is respected",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Crawl site into collection",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.CollectionCrawl"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
                     }
-                },
-                "country": {
-                    "type": "string",
-                    "maxLength": 24
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string",
-                    "maxLength": 60
-                },
-                "first_name": {
-                    "type": "string",
-                    "maxLength": 30
-                },
-                "last_name": {
-                    "type": "string",
-                    "maxLength": 30
-                },
-                "login_date": {
-                    "type": "string"
-                },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
-                },
-                "updated_at": {
-                    "type": "string"
-                },
-                "user_id": {
+                }
+            }
+        },
+        "/collections/upload": {
+            "post": {
+                "description": "create collection and import documentation files of a zip or tar archive into it, files matching\ninclude and not matching exclude glob patterns are imported, a file with the content of an earlier\none is skipped, the job result lists documents of files with the words each file added.\nMarkup, readme, Go and OpenAPI files are included and hidden files, dependencies and Go tests\nexcluded by default, doc comments of Go files and descriptions of API specs are imported",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Import archive into collection",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": ".zip, .tar, .tar.gz or .tgz archive, a directory holding all files is left out",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "name of the collection, the archive name by default",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "glob patterns of imported files",
+                        "name": "include",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "glob patterns of left out files",
+                        "name": "exclude",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "skip leaves code out of the dictionary, split breaks identifiers into words",
+                        "name": "code_mode",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.CollectionImport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}": {
+            "get": {
+                "description": "get collection of current user with its documents",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Get collection by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "collection_id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Collection"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "rename collection of current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Update collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "collection_id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Collection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "delete collection of current user, its documents are kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Delete collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "collection_id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/dictionary": {
+            "get": {
+                "description": "get combined dictionary of collection documents, frequencies are summed across documents\nand every entry lists documents using the word",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Get collection dictionary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "collection_id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "leave out known and ignored words of the caller, true by default",
+                        "name": "hide_known",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "leave out words used less often in all documents together",
+                        "name": "min_frequency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "keep only words with a translation of this part of speech",
+                        "name": "pos",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "A1",
+                            "A2",
+                            "B1",
+                            "B2",
+                            "C1",
+                            "C2"
+                        ],
+                        "type": "string",
+                        "description": "keep only words at or above this CEFR level",
+                        "name": "min_level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "keep only words at or above the level in the profile of the caller",
+                        "name": "at_my_level",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "frequency",
+                            "keyness",
+                            "word"
+                        ],
+                        "type": "string",
+                        "description": "order of entries, most frequent or typical first",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "separate",
+                            "drop"
+                        ],
+                        "type": "string",
+                        "description": "proper nouns and product names in a separate list or dropped",
+                        "name": "names",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CollectionDictionary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/documents": {
+            "post": {
+                "description": "add existing documents of current user to their collection, documents already in it are skipped",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Add documents to collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "collection_id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Collection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/documents/{document_id}": {
+            "delete": {
+                "description": "remove document from collection of current user, the document itself is kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Remove document from collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "collection_id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "document_id",
+                        "name": "document_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Collection"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            }
+        },
+        "/documents": {
+            "post": {
+                "description": "queue document from url or raw text, the dictionary is built in background,\nthe job result holds the created document when it is done",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Documents"
+                ],
+                "summary": "Create document",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            }
+        },
+        "/documents/upload": {
+            "post": {
+                "description": "queue markdown, reStructuredText, AsciiDoc, PDF or EPUB file, headings, code blocks and admonitions\nare parsed as on html pages, examples of PDF and EPUB files keep their page or chapter.\nDoc comments of Go files and descriptions of OpenAPI specs are taken with examples linked to\nthe documented symbol, operation id or schema path. Cues of SRT and WebVTT subtitles are joined\ninto paragraphs, examples keep the timestamp of the cue they start in,\nthe job result holds the created document when it is done",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Documents"
+                ],
+                "summary": "Upload document",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": ".md, .rst, .adoc, .pdf, .epub, .srt, .vtt, .go or OpenAPI .json or .yaml file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "title of the document, the first heading of the file by default",
+                        "name": "title",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "skip leaves code out of the dictionary, split breaks identifiers into words",
+                        "name": "code_mode",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            }
+        },
+        "/documents/{id}": {
+            "get": {
+                "description": "get document with its generated dictionary, documents added by a user are found by the user only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Documents"
+                ],
+                "summary": "Get document by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "document_id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "leave out known and ignored words of the caller, true by default",
+                        "name": "hide_known",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "leave out words used less often",
+                        "name": "min_frequency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "keep only words with a translation of this part of speech",
+                        "name": "pos",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "A1",
+                            "A2",
+                            "B1",
+                            "B2",
+                            "C1",
+                            "C2"
+                        ],
+                        "type": "string",
+                        "description": "keep only words at or above this CEFR level",
+                        "name": "min_level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "keep only words at or above the level in the profile of the caller",
+                        "name": "at_my_level",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "frequency",
+                            "keyness",
+                            "word"
+                        ],
+                        "type": "string",
+                        "description": "order of entries, most frequent or typical first",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "separate",
+                            "drop"
+                        ],
+                        "type": "string",
+                        "description": "proper nouns and product names in a separate list or dropped",
+                        "name": "names",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Dictionary"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            }
+        },
+        "/documents/{id}/dictionary": {
+            "get": {
+                "description": "download dictionary as csv, tsv, markdown table or Anki deck, filters are the same as for the document",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Documents"
+                ],
+                "summary": "Export document dictionary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "document_id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "tsv",
+                            "md",
+                            "apkg"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "file format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "leave out known and ignored words of the caller, true by default",
+                        "name": "hide_known",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "leave out words used less often",
+                        "name": "min_frequency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "keep only words with a translation of this part of speech",
+                        "name": "pos",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "A1",
+                            "A2",
+                            "B1",
+                            "B2",
+                            "C1",
+                            "C2"
+                        ],
+                        "type": "string",
+                        "description": "keep only words at or above this CEFR level",
+                        "name": "min_level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "keep only words at or above the level in the profile of the caller",
+                        "name": "at_my_level",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "frequency",
+                            "keyness",
+                            "word"
+                        ],
+                        "type": "string",
+                        "description": "order of entries, most frequent or typical first",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "separate",
+                            "drop"
+                        ],
+                        "type": "string",
+                        "description": "proper nouns and product names in a separate list or dropped",
+                        "name": "names",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "description": "get status of a background job, result holds the outcome of a finished job",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get job by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "job_id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews": {
+            "post": {
+                "description": "grade the answer to a card: 1 again, 2 hard, 3 good, 4 easy, returns the card with its next due date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Answer a card",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewCard"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/due": {
+            "get": {
+                "description": "get review cards of learning words which are due now, earliest first,\ncards are created for words marked as learning",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get due cards",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "number of cards, 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ReviewCard"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/settings": {
+            "get": {
+                "description": "get scheduling algorithm of current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get review settings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewSettings"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "choose scheduling algorithm: sm2 or fsrs, existing cards keep their review history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Update review settings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewSettings"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            }
+        },
+        "/words": {
+            "get": {
+                "description": "get vocabulary of current user, optionally filtered by state",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Words"
+                ],
+                "summary": "Get words",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "known, learning, ignored or name",
+                        "name": "state",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.UserWord"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "set state of words in the vocabulary of current user, known and ignored words\nare hidden from generated dictionaries, names go to the names of dictionaries",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Words"
+                ],
+                "summary": "Mark words",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.UserWord"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "remove words from the vocabulary of current user, returns number of removed words",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Words"
+                ],
+                "summary": "Unmark words",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "httpErrors.RestError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Collection": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "collection_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "documents": {
+                    "description": "Documents in the order they were added",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Document"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 250
+                },
+                "source_url": {
+                    "description": "Root page or sitemap of a crawled site",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CollectionCrawl": {
+            "type": "object",
+            "properties": {
+                "collection": {
+                    "$ref": "#/definitions/models.Collection"
+                },
+                "job": {
+                    "$ref": "#/definitions/models.Job"
+                }
+            }
+        },
+        "models.CollectionDictionary": {
+            "type": "object",
+            "properties": {
+                "above_level_share": {
+                    "description": "Share of words above the level of the caller, only for callers with a level",
+                    "type": "number"
+                },
+                "acronyms": {
+                    "description": "Glossary of acronyms left out of entries",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DictionaryEntry"
+                    }
+                },
+                "collection": {
+                    "$ref": "#/definitions/models.Collection"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DictionaryEntry"
+                    }
+                },
+                "names": {
+                    "description": "Proper nouns and product names left out of entries, they are not translated",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DictionaryEntry"
+                    }
+                }
+            }
+        },
+        "models.CollectionImport": {
+            "type": "object",
+            "properties": {
+                "collection": {
+                    "$ref": "#/definitions/models.Collection"
+                },
+                "job": {
+                    "$ref": "#/definitions/models.Job"
+                }
+            }
+        },
+        "models.Dictionary": {
+            "type": "object",
+            "properties": {
+                "above_level_share": {
+                    "description": "Share of words above the level of the caller, only for callers with a level",
+                    "type": "number"
+                },
+                "acronyms": {
+                    "description": "Glossary of acronyms left out of entries",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DictionaryEntry"
+                    }
+                },
+                "document": {
+                    "$ref": "#/definitions/models.Document"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DictionaryEntry"
+                    }
+                },
+                "names": {
+                    "description": "Proper nouns and product names left out of entries, they are not translated",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DictionaryEntry"
+                    }
+                }
+            }
+        },
+        "models.DictionaryEntry": {
+            "type": "object",
+            "properties": {
+                "band": {
+                    "description": "Thousand of most frequent english words the word belongs to, 0 for rare words",
+                    "type": "integer"
+                },
+                "document_ids": {
+                    "description": "Documents of a collection where the word is used",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "examples": {
+                    "description": "Sentences of the document where the word is used",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WordExample"
+                    }
+                },
+                "expansion": {
+                    "description": "Long form of an acronym, translations of acronyms are translations of their expansions",
+                    "type": "string"
+                },
+                "expansion_source": {
+                    "type": "string"
+                },
+                "forms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "frequency": {
+                    "type": "integer"
+                },
+                "from_code": {
+                    "type": "boolean"
+                },
+                "keyness": {
+                    "description": "How characteristic of the text the word is compared to general english and other documents of the caller",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Keyness"
+                        }
+                    ]
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "level": {
+                    "description": "CEFR level estimated by the frequency of the word in general english",
+                    "type": "string"
+                },
+                "state": {
+                    "description": "State of the word in the vocabulary of the caller",
+                    "type": "string"
+                },
+                "translation": {
+                    "$ref": "#/definitions/models.Translation"
+                },
+                "word": {
+                    "description": "Lemma of a word or lemmas of phrase words joined by spaces",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Document": {
+            "type": "object",
+            "properties": {
+                "code_mode": {
+                    "type": "string",
+                    "enum": [
+                        "skip",
+                        "split"
+                    ]
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "description": "Mean CEFR level of words from 1 for A1 to 6 for C2",
+                    "type": "number"
+                },
+                "document_id": {
+                    "type": "string"
+                },
+                "level": {
+                    "description": "Lowest CEFR level whose words cover 95% of the text",
+                    "type": "string"
+                },
+                "source_path": {
+                    "description": "Path of the file in an imported directory or archive",
+                    "type": "string"
+                },
+                "source_url": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 250
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "word_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Example": {
+            "type": "object",
+            "properties": {
+                "text": {
+                    "type": "string"
+                },
+                "translation": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ImportedDictionary": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dictionary_id": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "headwords_count": {
+                    "type": "integer"
+                },
+                "imported_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "source_language": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "target_language": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Job": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error of the last attempt",
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "max_attempts": {
+                    "type": "integer"
+                },
+                "result": {
+                    "description": "Outcome of a finished job, for example the created document",
+                    "type": "object"
+                },
+                "run_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Keyness": {
+            "type": "object",
+            "properties": {
+                "general": {
+                    "description": "Score against general english",
+                    "type": "number"
+                },
+                "general_per_million": {
+                    "description": "Occurrences per million words of general english estimated by the frequency rank of the word",
+                    "type": "number"
+                },
+                "personal": {
+                    "description": "Score against other documents of the caller, only for callers with other documents",
+                    "type": "number"
+                },
+                "personal_per_million": {
+                    "description": "Occurrences per million words in other documents of the caller",
+                    "type": "number"
+                },
+                "score": {
+                    "description": "Mean of the scores below, the key for sorting dictionaries by keyness",
+                    "type": "number"
+                },
+                "text_per_million": {
+                    "description": "Occurrences per million words in the text",
+                    "type": "number"
+                }
+            }
+        },
+        "models.ReviewCard": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "type": "number"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "ease": {
+                    "type": "number"
+                },
+                "interval": {
+                    "description": "Days between the last and the next review",
+                    "type": "number"
+                },
+                "lapses": {
+                    "type": "integer"
+                },
+                "last_reviewed_at": {
+                    "type": "string"
+                },
+                "repetitions": {
+                    "type": "integer"
+                },
+                "stability": {
+                    "type": "number"
+                },
+                "translation": {
+                    "$ref": "#/definitions/models.Translation"
+                },
+                "word": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ReviewSettings": {
+            "type": "object",
+            "properties": {
+                "algorithm": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Sense": {
+            "type": "object",
+            "properties": {
+                "examples": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Example"
+                    }
+                },
+                "part_of_speech": {
+                    "type": "string"
+                },
+                "translations": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.Translation": {
+            "type": "object",
+            "properties": {
+                "provider": {
+                    "type": "string"
+                },
+                "senses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Sense"
+                    }
+                },
+                "transcription": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.User": {
+            "type": "object",
+            "required": [
+                "first_name",
+                "last_name",
+                "password"
+            ],
+            "properties": {
+                "avatar": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "country": {
+                    "type": "string",
+                    "maxLength": 24
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 60
+                },
+                "first_name": {
+                    "type": "string",
+                    "maxLength": 30
+                },
+                "last_name": {
+                    "type": "string",
+                    "maxLength": 30
+                },
+                "level": {
+                    "description": "Declared CEFR level of english",
+                    "type": "string",
+                    "enum": [
+                        "A1",
+                        "A2",
+                        "B1",
+                        "B2",
+                        "C1",
+                        "C2"
+                    ]
+                },
+                "login_date": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UserWord": {
+            "type": "object",
+            "properties": {
+                "state": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "word": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WordExample": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "integer"
+                },
+                "heading": {
+                    "description": "Heading of the document section with the sentence",
+                    "type": "string"
+                },
+                "link": {
+                    "description": "Link to the section of the source page",
+                    "type": "string"
+                },
+                "location": {
+                    "description": "Part of the document with the sentence: page of a PDF or chapter of an EPUB, symbol of Go doc comments,\noperation or schema of an API spec, start of the subtitle cue:\n\"page 12\", \"chapter 3\", \"echo.Context.Bind\", \"getPet\", \"00:01:23.456\"",
+                    "type": "string"
+                },
+                "start": {
+                    "description": "Character offsets of the sentence in the document content",
+                    "type": "integer"
+                },
+                "text": {
                     "type": "string"
                 }
             }
@@ -90,7 +90,7 @@ func (h *authHandlers) Login() echo.HandlerFunc {
 
 // Update godoc
 // @Summary Update user
-// @Description update existing user
+// @Description update existing user, the CEFR level is used by dictionary filters
 // @Tags Auth
 // @Accept json
 // @Param id path int true "user_id"
@@ -34,6 +34,11 @@ func updateUserQuery(user *models.User) (string, []interface{}, error) {
 			"COALESCE(NULLIF(?, ''), country)",
 			user.Country,
 		),
+	).Set(
+		"level", sq.Expr(
+			"COALESCE(NULLIF(?, ''), level)",
+			user.Level,
+		),
 	).Set(
 		"updated_at", time.Now(),
 	).Where(
@@ -53,14 +58,14 @@ func deleteUserQuery(userID uuid.UUID) (string, []interface{}, error) {
 
 func getUserQuery(userID uuid.UUID) (string, []interface{}, error) {
 	return sq.Select().Columns(
-		"user_id", "first_name", "last_name", "email", "avatar", "country", "created_at", "updated_at",
+		"user_id", "first_name", "last_name", "email", "avatar", "country", "level", "created_at", "updated_at",
 		"login_date",
 	).From("users").Where("user_id = ?", userID).PlaceholderFormat(sq.Dollar).ToSql()
 }
 
 func findUserByEmail(email string) (string, []interface{}, error) {
 	return sq.Select(
-		"user_id", "first_name", "last_name", "email", "avatar", "country", "created_at", "updated_at",
+		"user_id", "first_name", "last_name", "email", "avatar", "country", "level", "created_at", "updated_at",
 		"login_date", "password",
 	).From("users").Where("email = ?", email).PlaceholderFormat(sq.Dollar).ToSql()
 }
@@ -0,0 +1,406 @@
+package http
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/shlembo598/text-lexicon-go/internal/collections"
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils/httpErrors"
+	r "github.com/shlembo598/text-lexicon-go/pkg/utils/responses"
+)
+
+type collectionsHandlers struct {
+	cfg           *config.Config
+	collectionsUC collections.UseCase
+}
+
+func NewCollectionsHandlers(cfg *config.Config, collectionsUC collections.UseCase) collections.Handlers {
+	return &collectionsHandlers{cfg: cfg, collectionsUC: collectionsUC}
+}
+
+// Create godoc
+// @Summary Create collection
+// @Description create collection of current user from their existing documents
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Success 201 {object} models.Collection
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Router /collections [post]
+func (h *collectionsHandlers) Create() echo.HandlerFunc {
+	type CreateCollection struct {
+		Name        string      `json:"name" validate:"required,lte=250"`
+		DocumentIDs []uuid.UUID `json:"document_ids" validate:"max=10000"`
+	}
+
+	return func(c echo.Context) error {
+		request := &CreateCollection{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		collection, err := h.collectionsUC.Create(
+			utils.GetRequestCtx(c), &models.Collection{Name: request.Name}, request.DocumentIDs,
+		)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusCreated, r.SuccessResponse(collection))
+	}
+}
+
+// GetAll godoc
+// @Summary Get collections
+// @Description get collections of current user, newest first
+// @Tags Collections
+// @Produce json
+// @Success 200 {array} models.Collection
+// @Failure 401 {object} httpErrors.RestError
+// @Router /collections [get]
+func (h *collectionsHandlers) GetAll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		result, err := h.collectionsUC.GetAll(utils.GetRequestCtx(c))
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, r.SuccessResponse(result))
+	}
+}
+
+// GetByID godoc
+// @Summary Get collection by id
+// @Description get collection of current user with its documents
+// @Tags Collections
+// @Produce json
+// @Param id path string true "collection_id"
+// @Success 200 {object} models.Collection
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Router /collections/{id} [get]
+func (h *collectionsHandlers) GetByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		collectionID, err := uuid.Parse(c.Param("collection_id"))
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		collection, err := h.collectionsUC.GetByID(utils.GetRequestCtx(c), collectionID)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, r.SuccessResponse(collection))
+	}
+}
+
+// Update godoc
+// @Summary Update collection
+// @Description rename collection of current user
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Param id path string true "collection_id"
+// @Success 200 {object} models.Collection
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Router /collections/{id} [put]
+func (h *collectionsHandlers) Update() echo.HandlerFunc {
+	type UpdateCollection struct {
+		Name string `json:"name" validate:"required,lte=250"`
+	}
+
+	return func(c echo.Context) error {
+		collectionID, err := uuid.Parse(c.Param("collection_id"))
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		request := &UpdateCollection{}
+		if err = utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		collection, err := h.collectionsUC.Update(
+			utils.GetRequestCtx(c), &models.Collection{CollectionID: collectionID, Name: request.Name},
+		)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, r.SuccessResponse(collection))
+	}
+}
+
+// Delete godoc
+// @Summary Delete collection
+// @Description delete collection of current user, its documents are kept
+// @Tags Collections
+// @Produce json
+// @Param id path string true "collection_id"
+// @Success 200 {string} string	"ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Router /collections/{id} [delete]
+func (h *collectionsHandlers) Delete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		collectionID, err := uuid.Parse(c.Param("collection_id"))
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		if err = h.collectionsUC.Delete(utils.GetRequestCtx(c), collectionID); err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, r.SuccessResponse("Successfully deleted"))
+	}
+}
+
+// AddDocuments godoc
+// @Summary Add documents to collection
+// @Description add existing documents of current user to their collection, documents already in it are skipped
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Param id path string true "collection_id"
+// @Success 200 {object} models.Collection
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Router /collections/{id}/documents [post]
+func (h *collectionsHandlers) AddDocuments() echo.HandlerFunc {
+	type AddDocuments struct {
+		DocumentIDs []uuid.UUID `json:"document_ids" validate:"required,min=1,max=10000"`
+	}
+
+	return func(c echo.Context) error {
+		collectionID, err := uuid.Parse(c.Param("collection_id"))
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		request := &AddDocuments{}
+		if err = utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		collection, err := h.collectionsUC.AddDocuments(utils.GetRequestCtx(c), collectionID, request.DocumentIDs)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, r.SuccessResponse(collection))
+	}
+}
+
+// RemoveDocument godoc
+// @Summary Remove document from collection
+// @Description remove document from collection of current user, the document itself is kept
+// @Tags Collections
+// @Produce json
+// @Param id path string true "collection_id"
+// @Param document_id path string true "document_id"
+// @Success 200 {object} models.Collection
+// @Failure 404 {object} httpErrors.RestError
+// @Router /collections/{id}/documents/{document_id} [delete]
+func (h *collectionsHandlers) RemoveDocument() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		collectionID, err := uuid.Parse(c.Param("collection_id"))
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		documentID, err := uuid.Parse(c.Param("document_id"))
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		collection, err := h.collectionsUC.RemoveDocument(utils.GetRequestCtx(c), collectionID, documentID)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, r.SuccessResponse(collection))
+	}
+}
+
+// GetDictionary godoc
+// @Summary Get collection dictionary
+// @Description get combined dictionary of collection documents, frequencies are summed across documents
+// @Description and every entry lists documents using the word
+// @Tags Collections
+// @Produce json
+// @Param id path string true "collection_id"
+// @Param hide_known query bool false "leave out known and ignored words of the caller, true by default"
+// @Param min_frequency query int false "leave out words used less often in all documents together"
+// @Param pos query string false "keep only words with a translation of this part of speech"
+// @Param min_level query string false "keep only words at or above this CEFR level" Enums(A1, A2, B1, B2, C1, C2)
+// @Param at_my_level query bool false "keep only words at or above the level in the profile of the caller"
+// @Param sort query string false "order of entries, most frequent or typical first" Enums(frequency, keyness, word)
+// @Param names query string false "proper nouns and product names in a separate list or dropped" Enums(separate, drop)
+// @Success 200 {object} models.CollectionDictionary
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Router /collections/{id}/dictionary [get]
+func (h *collectionsHandlers) GetDictionary() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		collectionID, err := uuid.Parse(c.Param("collection_id"))
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		params, err := utils.GetDictionaryParams(c)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		dictionary, err := h.collectionsUC.GetDictionary(utils.GetRequestCtx(c), collectionID, params)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, r.SuccessResponse(dictionary))
+	}
+}
+
+// Crawl godoc
+// @Summary Crawl site into collection
+// @Description create collection and crawl a documentation site into it starting from a page or sitemap.xml,
+// @Description same origin links under the path prefix are followed, robots.txt is respected
+// @Tags Collections
+// @Accept json
+// @Produce json
+// @Success 202 {object} models.CollectionCrawl
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Router /collections/crawl [post]
+func (h *collectionsHandlers) Crawl() echo.HandlerFunc {
+	type CrawlSite struct {
+		URL  string `json:"url" validate:"required,url"`
+		Name string `json:"name" validate:"omitempty,lte=250"`
+		// Path prefix of crawled pages, the directory of the start page by default
+		Prefix   string `json:"prefix" validate:"omitempty,startswith=/"`
+		MaxDepth *int   `json:"max_depth" validate:"omitempty,gte=0,lte=10"`
+		MaxPages int    `json:"max_pages" validate:"gte=0"`
+		CodeMode string `json:"code_mode" validate:"omitempty,oneof=skip split"`
+	}
+
+	return func(c echo.Context) error {
+		request := &CrawlSite{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		params := &models.CrawlParams{
+			URL:      request.URL,
+			Prefix:   request.Prefix,
+			MaxDepth: request.MaxDepth,
+			MaxPages: request.MaxPages,
+			CodeMode: request.CodeMode,
+		}
+
+		crawl, err := h.collectionsUC.Crawl(utils.GetRequestCtx(c), &models.Collection{Name: request.Name}, params)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusAccepted, r.SuccessResponse(crawl))
+	}
+}
+
+// Upload godoc
+// @Summary Import archive into collection
+// @Description create collection and import documentation files of a zip or tar archive into it, files matching
+// @Description include and not matching exclude glob patterns are imported, a file with the content of an earlier
+// @Description one is skipped, the job result lists documents of files with the words each file added.
+// @Description Markup, readme, Go and OpenAPI files are included and hidden files, dependencies and Go tests
+// @Description excluded by default, doc comments of Go files and descriptions of API specs are imported
+// @Tags Collections
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true ".zip, .tar, .tar.gz or .tgz archive, a directory holding all files is left out"
+// @Param name formData string false "name of the collection, the archive name by default"
+// @Param include formData []string false "glob patterns of imported files" collectionFormat(multi)
+// @Param exclude formData []string false "glob patterns of left out files" collectionFormat(multi)
+// @Param code_mode formData string false "skip leaves code out of the dictionary, split breaks identifiers into words"
+// @Success 202 {object} models.CollectionImport
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 413 {object} httpErrors.RestError
+// @Router /collections/upload [post]
+func (h *collectionsHandlers) Upload() echo.HandlerFunc {
+	type UploadArchive struct {
+		Name     string   `form:"name" validate:"omitempty,lte=250"`
+		Include  []string `form:"include" validate:"omitempty,dive,required"`
+		Exclude  []string `form:"exclude" validate:"omitempty,dive,required"`
+		CodeMode string   `form:"code_mode" validate:"omitempty,oneof=skip split"`
+	}
+
+	return func(c echo.Context) error {
+		request := &UploadArchive{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			err = httpErrors.NewBadRequestError(err)
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+		defer file.Close()
+
+		archive, err := io.ReadAll(file)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		params := &models.ImportParams{Include: request.Include, Exclude: request.Exclude, CodeMode: request.CodeMode}
+		imported, err := h.collectionsUC.Import(
+			utils.GetRequestCtx(c), &models.Collection{Name: request.Name}, params, fileHeader.Filename, archive,
+		)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusAccepted, r.SuccessResponse(imported))
+	}
+}
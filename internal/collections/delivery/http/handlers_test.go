@@ -0,0 +1,340 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/shlembo598/text-lexicon-go/internal/collections"
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils/httpErrors"
+)
+
+// Use case recording its arguments, documents other than the known one are not found
+type fakeCollectionsUC struct {
+	collections.UseCase
+	document     uuid.UUID
+	collection   *models.Collection
+	documentIDs  []uuid.UUID
+	params       *models.ImportParams
+	fileName     string
+	archive      []byte
+	removedID    uuid.UUID
+	collectionID uuid.UUID
+}
+
+func (f *fakeCollectionsUC) checkDocuments(documentIDs []uuid.UUID) error {
+	f.documentIDs = documentIDs
+	for _, documentID := range documentIDs {
+		if documentID != f.document {
+			return httpErrors.NewNotFoundError(fmt.Errorf("document not found: %s", documentID))
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeCollectionsUC) Create(
+	_ context.Context, collection *models.Collection, documentIDs []uuid.UUID,
+) (*models.Collection, error) {
+	f.collection = collection
+	if err := f.checkDocuments(documentIDs); err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+func (f *fakeCollectionsUC) GetByID(_ context.Context, collectionID uuid.UUID) (*models.Collection, error) {
+	f.collectionID = collectionID
+	if f.collection == nil || f.collection.CollectionID != collectionID {
+		return nil, fmt.Errorf("collections.useCase.getByID: %w", sql.ErrNoRows)
+	}
+
+	return f.collection, nil
+}
+
+func (f *fakeCollectionsUC) AddDocuments(
+	ctx context.Context, collectionID uuid.UUID, documentIDs []uuid.UUID,
+) (*models.Collection, error) {
+	collection, err := f.GetByID(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if err = f.checkDocuments(documentIDs); err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+func (f *fakeCollectionsUC) RemoveDocument(
+	ctx context.Context, collectionID uuid.UUID, documentID uuid.UUID,
+) (*models.Collection, error) {
+	f.removedID = documentID
+
+	return f.GetByID(ctx, collectionID)
+}
+
+func (f *fakeCollectionsUC) Import(
+	_ context.Context, collection *models.Collection, params *models.ImportParams, fileName string, archive []byte,
+) (*models.CollectionImport, error) {
+	f.collection, f.params, f.fileName, f.archive = collection, params, fileName, archive
+
+	return &models.CollectionImport{Collection: collection, Job: &models.Job{JobID: uuid.New()}}, nil
+}
+
+// Serve the request, or a request of the method to the target without a body when nil, by the handler of the route
+func serve(
+	t *testing.T, method, route, target string, h echo.HandlerFunc, request *http.Request,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	e := echo.New()
+	e.Add(method, route, h)
+	if request == nil {
+		request = httptest.NewRequest(method, target, nil)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, request)
+
+	return rec
+}
+
+// Request with a json body
+func jsonRequest(method, target, body string) *http.Request {
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	return request
+}
+
+func TestCreate(t *testing.T) {
+	document := uuid.New()
+
+	tests := []struct {
+		name        string
+		body        string
+		status      int
+		collection  string
+		documentIDs []uuid.UUID
+	}{
+		{
+			name:   "collection with documents",
+			body:   fmt.Sprintf(`{"name": "Go", "document_ids": ["%s"]}`, document),
+			status: http.StatusCreated, collection: "Go", documentIDs: []uuid.UUID{document},
+		},
+		{
+			name:   "empty collection",
+			body:   `{"name": "Go"}`,
+			status: http.StatusCreated, collection: "Go",
+		},
+		{
+			name:   "foreign document",
+			body:   fmt.Sprintf(`{"name": "Go", "document_ids": ["%s", "%s"]}`, document, uuid.Nil),
+			status: http.StatusNotFound, collection: "Go", documentIDs: []uuid.UUID{document, uuid.Nil},
+		},
+		{name: "without name", body: `{"document_ids": []}`, status: http.StatusBadRequest},
+		{name: "invalid document id", body: `{"name": "Go", "document_ids": ["42"]}`, status: http.StatusBadRequest},
+		{name: "name of wrong type", body: `{"name": 42}`, status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &fakeCollectionsUC{document: document}
+			h := NewCollectionsHandlers(&config.Config{}, uc)
+			rec := serve(t, http.MethodPost, "/collections", "", h.Create(),
+				jsonRequest(http.MethodPost, "/collections", tt.body))
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if tt.collection == "" {
+				if uc.collection != nil {
+					t.Errorf("use case is called with %+v", uc.collection)
+				}
+				return
+			}
+			if uc.collection.Name != tt.collection {
+				t.Errorf("collection name = %q, want %q", uc.collection.Name, tt.collection)
+			}
+			if !reflect.DeepEqual(uc.documentIDs, tt.documentIDs) {
+				t.Errorf("documentIDs = %v, want %v", uc.documentIDs, tt.documentIDs)
+			}
+		})
+	}
+}
+
+func TestGetByID(t *testing.T) {
+	collection := &models.Collection{CollectionID: uuid.New(), Name: "Go"}
+
+	tests := []struct {
+		name         string
+		collectionID string
+		status       int
+	}{
+		{name: "collection", collectionID: collection.CollectionID.String(), status: http.StatusOK},
+		{name: "unknown collection", collectionID: uuid.NewString(), status: http.StatusNotFound},
+		{name: "invalid id", collectionID: "go", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewCollectionsHandlers(&config.Config{}, &fakeCollectionsUC{collection: collection})
+			rec := serve(t, http.MethodGet, "/collections/:collection_id", "/collections/"+tt.collectionID,
+				h.GetByID(), nil)
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if tt.status == http.StatusOK && !strings.Contains(rec.Body.String(), collection.CollectionID.String()) {
+				t.Errorf("response = %s", rec.Body)
+			}
+		})
+	}
+}
+
+func TestAddDocuments(t *testing.T) {
+	document := uuid.New()
+	collection := &models.Collection{CollectionID: uuid.New(), Name: "Go"}
+	target := "/collections/" + collection.CollectionID.String() + "/documents"
+
+	tests := []struct {
+		name   string
+		target string
+		body   string
+		status int
+	}{
+		{name: "own document", target: target, body: fmt.Sprintf(`{"document_ids": ["%s"]}`, document),
+			status: http.StatusOK},
+		{name: "foreign document", target: target, body: fmt.Sprintf(`{"document_ids": ["%s"]}`, uuid.New()),
+			status: http.StatusNotFound},
+		{name: "unknown collection", target: "/collections/" + uuid.NewString() + "/documents",
+			body: fmt.Sprintf(`{"document_ids": ["%s"]}`, document), status: http.StatusNotFound},
+		{name: "no documents", target: target, body: `{"document_ids": []}`, status: http.StatusBadRequest},
+		{name: "invalid collection id", target: "/collections/go/documents",
+			body: fmt.Sprintf(`{"document_ids": ["%s"]}`, document), status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &fakeCollectionsUC{document: document, collection: collection}
+			h := NewCollectionsHandlers(&config.Config{}, uc)
+			rec := serve(t, http.MethodPost, "/collections/:collection_id/documents", "", h.AddDocuments(),
+				jsonRequest(http.MethodPost, tt.target, tt.body))
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+		})
+	}
+}
+
+func TestRemoveDocument(t *testing.T) {
+	document := uuid.New()
+	collection := &models.Collection{CollectionID: uuid.New(), Name: "Go"}
+	uc := &fakeCollectionsUC{collection: collection}
+	h := NewCollectionsHandlers(&config.Config{}, uc)
+
+	rec := serve(t, http.MethodDelete, "/collections/:collection_id/documents/:document_id",
+		fmt.Sprintf("/collections/%s/documents/%s", collection.CollectionID, document), h.RemoveDocument(), nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	if uc.collectionID != collection.CollectionID || uc.removedID != document {
+		t.Errorf("removed document %s of %s, want %s of %s", uc.removedID, uc.collectionID, document,
+			collection.CollectionID)
+	}
+
+	rec = serve(t, http.MethodDelete, "/collections/:collection_id/documents/:document_id",
+		fmt.Sprintf("/collections/%s/documents/readme", collection.CollectionID), h.RemoveDocument(), nil)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d: %s", rec.Code, http.StatusBadRequest, rec.Body)
+	}
+}
+
+func TestUpload(t *testing.T) {
+	type field struct {
+		name  string
+		value string
+	}
+
+	tests := []struct {
+		name     string
+		fields   []field
+		file     string
+		status   int
+		params   *models.ImportParams
+		fileName string
+	}{
+		{
+			name:   "archive with patterns",
+			fields: []field{{"name", "Docs"}, {"include", "*.md"}, {"include", "*.rst"}, {"code_mode", "split"}},
+			file:   "docs.zip", status: http.StatusAccepted,
+			params: &models.ImportParams{Include: []string{"*.md", "*.rst"}, CodeMode: "split"}, fileName: "docs.zip",
+		},
+		{
+			name: "archive with defaults", file: "docs.tar.gz", status: http.StatusAccepted,
+			params: &models.ImportParams{}, fileName: "docs.tar.gz",
+		},
+		{name: "without archive", fields: []field{{"name", "Docs"}}, status: http.StatusBadRequest},
+		{
+			name: "invalid code mode", fields: []field{{"code_mode", "keep"}}, file: "docs.zip",
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body bytes.Buffer
+			w := multipart.NewWriter(&body)
+			for _, f := range tt.fields {
+				if err := w.WriteField(f.name, f.value); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.file != "" {
+				fw, err := w.CreateFormFile("file", tt.file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err = fw.Write([]byte("archive")); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+			request := httptest.NewRequest(http.MethodPost, "/collections/upload", &body)
+			request.Header.Set(echo.HeaderContentType, w.FormDataContentType())
+
+			uc := &fakeCollectionsUC{}
+			h := NewCollectionsHandlers(&config.Config{}, uc)
+			rec := serve(t, http.MethodPost, "/collections/upload", "", h.Upload(), request)
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if tt.params == nil {
+				return
+			}
+			if !reflect.DeepEqual(uc.params, tt.params) {
+				t.Errorf("params = %+v, want %+v", uc.params, tt.params)
+			}
+			if uc.fileName != tt.fileName || string(uc.archive) != "archive" {
+				t.Errorf("archive %q of %q, want %q of %q", uc.archive, uc.fileName, "archive", tt.fileName)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+
+	"github.com/shlembo598/text-lexicon-go/internal/auth"
+	"github.com/shlembo598/text-lexicon-go/internal/collections"
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/middleware"
+)
+
+func MapCollectionsRoutes(
+	collectionsGroup *echo.Group, h collections.Handlers, mw *middleware.MiddlewareManager, authUC auth.UseCase,
+	cfg *config.Config,
+) {
+	collectionsGroup.Use(mw.AuthJWTMiddleware(authUC, cfg))
+	collectionsGroup.POST("", h.Create())
+	collectionsGroup.GET("", h.GetAll())
+	collectionsGroup.POST("/crawl", h.Crawl())
+	collectionsGroup.POST("/upload", h.Upload(), echoMiddleware.BodyLimit(cfg.Imports.MaxArchiveSize))
+	collectionsGroup.GET("/:collection_id", h.GetByID())
+	collectionsGroup.PUT("/:collection_id", h.Update())
+	collectionsGroup.DELETE("/:collection_id", h.Delete())
+	collectionsGroup.POST("/:collection_id/documents", h.AddDocuments())
+	collectionsGroup.DELETE("/:collection_id/documents/:document_id", h.RemoveDocument())
+	collectionsGroup.GET("/:collection_id/dictionary", h.GetDictionary())
+}
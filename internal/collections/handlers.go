@@ -0,0 +1,18 @@
+package collections
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+type Handlers interface {
+	Create() echo.HandlerFunc
+	GetAll() echo.HandlerFunc
+	GetByID() echo.HandlerFunc
+	Update() echo.HandlerFunc
+	Delete() echo.HandlerFunc
+	AddDocuments() echo.HandlerFunc
+	RemoveDocument() echo.HandlerFunc
+	GetDictionary() echo.HandlerFunc
+	Crawl() echo.HandlerFunc
+	Upload() echo.HandlerFunc
+}
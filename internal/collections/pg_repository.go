@@ -0,0 +1,41 @@
+package collections
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+type Repository interface {
+	Create(ctx context.Context, collection *models.Collection) (*models.Collection, error)
+	GetByID(ctx context.Context, collectionID uuid.UUID) (*models.Collection, error)
+	// Collections of the user, newest first
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Collection, error)
+	Update(ctx context.Context, collection *models.Collection) (*models.Collection, error)
+	Delete(ctx context.Context, collectionID uuid.UUID) error
+	AddDocuments(ctx context.Context, collectionID uuid.UUID, documentIDs []uuid.UUID) error
+	RemoveDocument(ctx context.Context, collectionID uuid.UUID, documentID uuid.UUID) error
+	// Documents of the collection without content
+	GetDocuments(ctx context.Context, collectionID uuid.UUID) ([]*models.Document, error)
+	// Documents of the collection with content and code mode only
+	GetDocumentContents(ctx context.Context, collectionID uuid.UUID) ([]*models.Document, error)
+	// Ids of the given documents which exist and belong to the user
+	GetOwnedDocuments(ctx context.Context, userID uuid.UUID, documentIDs []uuid.UUID) ([]uuid.UUID, error)
+	// Source urls of documents in the collection
+	GetSourceURLs(ctx context.Context, collectionID uuid.UUID) ([]string, error)
+	// Paths of documents in the collection imported from files
+	GetSourcePaths(ctx context.Context, collectionID uuid.UUID) ([]string, error)
+	// Store contents of files of a queued import until the import is done
+	SaveImportFiles(ctx context.Context, collectionID uuid.UUID, files []*models.ImportFile) error
+	// Content of a stored file of an import by its hash
+	GetImportFile(ctx context.Context, collectionID uuid.UUID, hash string) ([]byte, error)
+	DeleteImportFiles(ctx context.Context, collectionID uuid.UUID) error
+	// Collocations stored for the version of the collection documents, sql.ErrNoRows for another version
+	GetCollocations(ctx context.Context, collectionID uuid.UUID, version string) (json.RawMessage, error)
+	SaveCollocations(ctx context.Context, collectionID uuid.UUID, version string, entries json.RawMessage) error
+	// Combined word list of collection documents ordered by summed frequency
+	GetEntries(ctx context.Context, collectionID uuid.UUID) ([]*models.DictionaryEntry, error)
+}
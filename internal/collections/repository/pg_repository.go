@@ -0,0 +1,400 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/shlembo598/text-lexicon-go/internal/collections"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+// Postgres caps a statement at 65535 bind parameters, so documents are added in chunks
+const documentsChunkSize = 1000
+
+type collectionsRepo struct {
+	db *sqlx.DB
+}
+
+func NewCollectionsRepository(db *sqlx.DB) collections.Repository {
+	return &collectionsRepo{db: db}
+}
+
+// Create collection
+func (r *collectionsRepo) Create(ctx context.Context, collection *models.Collection) (*models.Collection, error) {
+	const op = "collections.pg_repository.create"
+
+	query, args, err := createCollectionQuery(collection)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	created := &models.Collection{}
+	if err = r.db.GetContext(ctx, created, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.GetContext: %w", op, err)
+	}
+
+	return created, nil
+}
+
+// Get collection by id
+func (r *collectionsRepo) GetByID(ctx context.Context, collectionID uuid.UUID) (*models.Collection, error) {
+	const op = "collections.pg_repository.getByID"
+
+	query, args, err := getCollectionQuery(collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	collection := &models.Collection{}
+	if err = r.db.GetContext(ctx, collection, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.GetContext: %w", op, err)
+	}
+
+	return collection, nil
+}
+
+// Get collections of the user
+func (r *collectionsRepo) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Collection, error) {
+	const op = "collections.pg_repository.getByUserID"
+
+	query, args, err := getUserCollectionsQuery(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	result := make([]*models.Collection, 0)
+	if err = r.db.SelectContext(ctx, &result, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.SelectContext: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// Rename collection
+func (r *collectionsRepo) Update(ctx context.Context, collection *models.Collection) (*models.Collection, error) {
+	const op = "collections.pg_repository.update"
+
+	query, args, err := updateCollectionQuery(collection)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	updated := &models.Collection{}
+	if err = r.db.GetContext(ctx, updated, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.GetContext: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+// Delete collection, its documents are kept
+func (r *collectionsRepo) Delete(ctx context.Context, collectionID uuid.UUID) error {
+	const op = "collections.pg_repository.delete"
+
+	query, args, err := deleteCollectionQuery(collectionID)
+	if err != nil {
+		return fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	return r.exec(ctx, op, query, args)
+}
+
+// Add documents to collection, documents already in it are skipped
+func (r *collectionsRepo) AddDocuments(ctx context.Context, collectionID uuid.UUID, documentIDs []uuid.UUID) error {
+	const op = "collections.pg_repository.addDocuments"
+
+	for start := 0; start < len(documentIDs); start += documentsChunkSize {
+		query, args, err := addDocumentsQuery(
+			collectionID, documentIDs[start:min(start+documentsChunkSize, len(documentIDs))],
+		)
+		if err != nil {
+			return fmt.Errorf("%s.query: %w", op, err)
+		}
+
+		if _, err = r.db.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("%s.ExecContext: %w", op, err)
+		}
+	}
+
+	return nil
+}
+
+// Remove document from collection, the document itself is kept
+func (r *collectionsRepo) RemoveDocument(ctx context.Context, collectionID uuid.UUID, documentID uuid.UUID) error {
+	const op = "collections.pg_repository.removeDocument"
+
+	query, args, err := removeDocumentQuery(collectionID, documentID)
+	if err != nil {
+		return fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	return r.exec(ctx, op, query, args)
+}
+
+// Get collection documents
+func (r *collectionsRepo) GetDocuments(ctx context.Context, collectionID uuid.UUID) ([]*models.Document, error) {
+	const op = "collections.pg_repository.getDocuments"
+
+	query, args, err := getDocumentsQuery(collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	result := make([]*models.Document, 0)
+	if err = r.db.SelectContext(ctx, &result, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.SelectContext: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// Get collection documents with content
+func (r *collectionsRepo) GetDocumentContents(ctx context.Context, collectionID uuid.UUID) ([]*models.Document, error) {
+	const op = "collections.pg_repository.getDocumentContents"
+
+	query, args, err := getDocumentContentsQuery(collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	result := make([]*models.Document, 0)
+	if err = r.db.SelectContext(ctx, &result, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.SelectContext: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// Get ids of existing documents of the user
+func (r *collectionsRepo) GetOwnedDocuments(
+	ctx context.Context, userID uuid.UUID, documentIDs []uuid.UUID,
+) ([]uuid.UUID, error) {
+	const op = "collections.pg_repository.getOwnedDocuments"
+
+	result := make([]uuid.UUID, 0, len(documentIDs))
+	for start := 0; start < len(documentIDs); start += documentsChunkSize {
+		query, args, err := getOwnedDocumentsQuery(
+			userID, documentIDs[start:min(start+documentsChunkSize, len(documentIDs))],
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s.query: %w", op, err)
+		}
+
+		chunk := make([]uuid.UUID, 0)
+		if err = r.db.SelectContext(ctx, &chunk, query, args...); err != nil {
+			return nil, fmt.Errorf("%s.SelectContext: %w", op, err)
+		}
+		result = append(result, chunk...)
+	}
+
+	return result, nil
+}
+
+// Get source urls of collection documents
+func (r *collectionsRepo) GetSourceURLs(ctx context.Context, collectionID uuid.UUID) ([]string, error) {
+	const op = "collections.pg_repository.getSourceURLs"
+
+	query, args, err := getSourceURLsQuery(collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	result := make([]string, 0)
+	if err = r.db.SelectContext(ctx, &result, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.SelectContext: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// Get paths of collection documents imported from files
+func (r *collectionsRepo) GetSourcePaths(ctx context.Context, collectionID uuid.UUID) ([]string, error) {
+	const op = "collections.pg_repository.getSourcePaths"
+
+	query, args, err := getSourcePathsQuery(collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	result := make([]string, 0)
+	if err = r.db.SelectContext(ctx, &result, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.SelectContext: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// Store file contents of an import one by one in a transaction, files may be megabytes each
+func (r *collectionsRepo) SaveImportFiles(
+	ctx context.Context, collectionID uuid.UUID, files []*models.ImportFile,
+) error {
+	const op = "collections.pg_repository.saveImportFiles"
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s.BeginTxx: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	for _, file := range files {
+		query, args, err := saveImportFileQuery(collectionID, file)
+		if err != nil {
+			return fmt.Errorf("%s.query: %w", op, err)
+		}
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("%s.ExecContext: %w", op, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s.Commit: %w", op, err)
+	}
+
+	return nil
+}
+
+// Get stored content of an import file
+func (r *collectionsRepo) GetImportFile(ctx context.Context, collectionID uuid.UUID, hash string) ([]byte, error) {
+	const op = "collections.pg_repository.getImportFile"
+
+	query, args, err := getImportFileQuery(collectionID, hash)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	var body []byte
+	if err = r.db.GetContext(ctx, &body, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.GetContext: %w", op, err)
+	}
+
+	return body, nil
+}
+
+// Delete stored contents of import files of the collection
+func (r *collectionsRepo) DeleteImportFiles(ctx context.Context, collectionID uuid.UUID) error {
+	const op = "collections.pg_repository.deleteImportFiles"
+
+	query, args, err := deleteImportFilesQuery(collectionID)
+	if err != nil {
+		return fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	if _, err = r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%s.ExecContext: %w", op, err)
+	}
+
+	return nil
+}
+
+// Get collocations of the collection found for the version of its documents
+func (r *collectionsRepo) GetCollocations(
+	ctx context.Context, collectionID uuid.UUID, version string,
+) (json.RawMessage, error) {
+	const op = "collections.pg_repository.getCollocations"
+
+	query, args, err := getCollocationsQuery(collectionID, version)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	var entries json.RawMessage
+	if err = r.db.GetContext(ctx, &entries, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.GetContext: %w", op, err)
+	}
+
+	return entries, nil
+}
+
+// Store collocations of the collection replacing ones of an earlier version
+func (r *collectionsRepo) SaveCollocations(
+	ctx context.Context, collectionID uuid.UUID, version string, entries json.RawMessage,
+) error {
+	const op = "collections.pg_repository.saveCollocations"
+
+	query, args, err := saveCollocationsQuery(collectionID, version, entries)
+	if err != nil {
+		return fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	if _, err = r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%s.ExecContext: %w", op, err)
+	}
+
+	return nil
+}
+
+// Get words of collection documents with summed frequencies, their forms and documents using them
+func (r *collectionsRepo) GetEntries(ctx context.Context, collectionID uuid.UUID) ([]*models.DictionaryEntry, error) {
+	const op = "collections.pg_repository.getEntries"
+
+	query, args, err := getEntriesQuery(collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	entries := make([]*models.DictionaryEntry, 0)
+	if err = r.db.SelectContext(ctx, &entries, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.SelectContext: %w", op, err)
+	}
+
+	query, args, err = getFormsQuery(collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.formsQuery: %w", op, err)
+	}
+
+	forms := make([]*models.WordForm, 0)
+	if err = r.db.SelectContext(ctx, &forms, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.SelectContext: %w", op, err)
+	}
+
+	query, args, err = getWordDocumentsQuery(collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.wordDocumentsQuery: %w", op, err)
+	}
+
+	wordDocuments := make([]*models.WordDocument, 0)
+	if err = r.db.SelectContext(ctx, &wordDocuments, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.SelectContext: %w", op, err)
+	}
+
+	byWord := make(map[string]*models.DictionaryEntry, len(entries))
+	for _, entry := range entries {
+		entry.Forms = make([]string, 0)
+		entry.DocumentIDs = make([]uuid.UUID, 0)
+		byWord[entry.Word] = entry
+	}
+	for _, form := range forms {
+		if entry, ok := byWord[form.Word]; ok {
+			entry.Forms = append(entry.Forms, form.Form)
+		}
+	}
+	for _, wordDocument := range wordDocuments {
+		if entry, ok := byWord[wordDocument.Word]; ok {
+			entry.DocumentIDs = append(entry.DocumentIDs, wordDocument.DocumentID)
+		}
+	}
+
+	return entries, nil
+}
+
+// Execute statement which must affect a row
+func (r *collectionsRepo) exec(ctx context.Context, op string, query string, args []interface{}) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s.ExecContext: %w", op, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s.RowsAffected: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s.rowsAffected: %w", op, sql.ErrNoRows)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+func createCollectionQuery(collection *models.Collection) (string, []interface{}, error) {
+	return sq.Insert("collections").Columns("user_id", "name", "source_url").Values(
+		collection.UserID, collection.Name, collection.SourceURL,
+	).Suffix("RETURNING *").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getCollectionQuery(collectionID uuid.UUID) (string, []interface{}, error) {
+	return sq.Select("*").From("collections").Where(
+		"collection_id = ?", collectionID,
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func addDocumentsQuery(collectionID uuid.UUID, documentIDs []uuid.UUID) (string, []interface{}, error) {
+	query := sq.Insert("collection_documents").Columns("collection_id", "document_id")
+	for _, documentID := range documentIDs {
+		query = query.Values(collectionID, documentID)
+	}
+
+	return query.Suffix(
+		"ON CONFLICT (collection_id, document_id) DO NOTHING",
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func saveImportFileQuery(collectionID uuid.UUID, file *models.ImportFile) (string, []interface{}, error) {
+	return sq.Insert("collection_import_files").Columns("collection_id", "hash", "body").Values(
+		collectionID, file.Hash, file.Body,
+	).Suffix("ON CONFLICT (collection_id, hash) DO NOTHING").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getImportFileQuery(collectionID uuid.UUID, hash string) (string, []interface{}, error) {
+	return sq.Select("body").From("collection_import_files").Where(
+		sq.Eq{"collection_id": collectionID, "hash": hash},
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func deleteImportFilesQuery(collectionID uuid.UUID) (string, []interface{}, error) {
+	return sq.Delete("collection_import_files").Where(
+		"collection_id = ?", collectionID,
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getCollocationsQuery(collectionID uuid.UUID, version string) (string, []interface{}, error) {
+	return sq.Select("entries").From("collection_collocations").Where(
+		sq.Eq{"collection_id": collectionID, "version": version},
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func saveCollocationsQuery(
+	collectionID uuid.UUID, version string, entries json.RawMessage,
+) (string, []interface{}, error) {
+	return sq.Insert("collection_collocations").Columns("collection_id", "version", "entries").Values(
+		collectionID, version, entries,
+	).Suffix(
+		"ON CONFLICT (collection_id) DO UPDATE SET version = EXCLUDED.version, entries = EXCLUDED.entries, " +
+			"created_at = NOW()",
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getSourceURLsQuery(collectionID uuid.UUID) (string, []interface{}, error) {
+	return sq.Select("d.source_url").From("collection_documents cd").Join(
+		"documents d ON d.document_id = cd.document_id",
+	).Where(
+		sq.Eq{"cd.collection_id": collectionID},
+	).Where("d.source_url IS NOT NULL").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getSourcePathsQuery(collectionID uuid.UUID) (string, []interface{}, error) {
+	return sq.Select("d.source_path").From("collection_documents cd").Join(
+		"documents d ON d.document_id = cd.document_id",
+	).Where(
+		sq.Eq{"cd.collection_id": collectionID},
+	).Where("d.source_path IS NOT NULL").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getUserCollectionsQuery(userID uuid.UUID) (string, []interface{}, error) {
+	return sq.Select("*").From("collections").Where(
+		"user_id = ?", userID,
+	).OrderBy("created_at DESC").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func updateCollectionQuery(collection *models.Collection) (string, []interface{}, error) {
+	return sq.Update("collections").Set("name", collection.Name).Set("updated_at", time.Now()).Where(
+		"collection_id = ?", collection.CollectionID,
+	).Suffix("RETURNING *").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func deleteCollectionQuery(collectionID uuid.UUID) (string, []interface{}, error) {
+	return sq.Delete("collections").Where("collection_id = ?", collectionID).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func removeDocumentQuery(collectionID uuid.UUID, documentID uuid.UUID) (string, []interface{}, error) {
+	return sq.Delete("collection_documents").Where(
+		"collection_id = ? AND document_id = ?", collectionID, documentID,
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getDocumentsQuery(collectionID uuid.UUID) (string, []interface{}, error) {
+	return sq.Select(
+		"d.document_id", "d.user_id", "d.source_url", "d.source_path", "d.title", "d.code_mode", "d.word_count",
+		"d.level", "d.difficulty", "d.created_at",
+	).From("collection_documents cd").Join(
+		"documents d ON d.document_id = cd.document_id",
+	).Where(
+		sq.Eq{"cd.collection_id": collectionID},
+	).OrderBy("cd.added_at", "d.created_at").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getOwnedDocumentsQuery(userID uuid.UUID, documentIDs []uuid.UUID) (string, []interface{}, error) {
+	return sq.Select("document_id").From("documents").Where(sq.And{
+		sq.Eq{"document_id": documentIDs},
+		sq.Eq{"user_id": userID},
+	}).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+// Kind of an entry found with different kinds in documents is the most specific one: a name, an acronym,
+// a phrasal verb, a collocation and a word otherwise
+func getEntriesQuery(collectionID uuid.UUID) (string, []interface{}, error) {
+	return sq.Select(
+		"dw.word",
+		"CASE WHEN bool_or(dw.kind = 'name') THEN 'name' WHEN bool_or(dw.kind = 'acronym') THEN 'acronym' "+
+			"WHEN bool_or(dw.kind = 'phrasal_verb') THEN 'phrasal_verb' "+
+			"WHEN bool_or(dw.kind = 'collocation') THEN 'collocation' ELSE 'word' END AS kind",
+		"SUM(dw.frequency)::int AS frequency", "bool_or(dw.from_code) AS from_code", "MAX(dw.expansion) AS expansion",
+	).From("collection_documents cd").Join(
+		"document_words dw ON dw.document_id = cd.document_id",
+	).Where(
+		sq.Eq{"cd.collection_id": collectionID},
+	).GroupBy("dw.word").OrderBy("frequency DESC", "dw.word").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getFormsQuery(collectionID uuid.UUID) (string, []interface{}, error) {
+	return sq.Select("f.word", "f.form").From("collection_documents cd").Join(
+		"document_word_forms f ON f.document_id = cd.document_id",
+	).Where(
+		sq.Eq{"cd.collection_id": collectionID},
+	).GroupBy("f.word", "f.form").OrderBy("f.word", "MIN(f.position)", "f.form").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getWordDocumentsQuery(collectionID uuid.UUID) (string, []interface{}, error) {
+	return sq.Select("dw.word", "dw.document_id").From("collection_documents cd").Join(
+		"document_words dw ON dw.document_id = cd.document_id",
+	).Where(
+		sq.Eq{"cd.collection_id": collectionID},
+	).OrderBy("dw.word", "cd.added_at").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getDocumentContentsQuery(collectionID uuid.UUID) (string, []interface{}, error) {
+	return sq.Select("d.document_id", "d.code_mode", "d.content").From("collection_documents cd").Join(
+		"documents d ON d.document_id = cd.document_id",
+	).Where(
+		sq.Eq{"cd.collection_id": collectionID},
+	).OrderBy("cd.added_at", "d.created_at").PlaceholderFormat(sq.Dollar).ToSql()
+}
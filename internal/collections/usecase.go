@@ -0,0 +1,42 @@
+package collections
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+type UseCase interface {
+	Create(ctx context.Context, collection *models.Collection, documentIDs []uuid.UUID) (*models.Collection, error)
+	// Collections of the current user
+	GetAll(ctx context.Context) ([]*models.Collection, error)
+	// Get collection with its documents
+	GetByID(ctx context.Context, collectionID uuid.UUID) (*models.Collection, error)
+	Update(ctx context.Context, collection *models.Collection) (*models.Collection, error)
+	Delete(ctx context.Context, collectionID uuid.UUID) error
+	AddDocuments(ctx context.Context, collectionID uuid.UUID, documentIDs []uuid.UUID) (*models.Collection, error)
+	RemoveDocument(ctx context.Context, collectionID uuid.UUID, documentID uuid.UUID) (*models.Collection, error)
+	// Combined dictionary of collection documents
+	GetDictionary(
+		ctx context.Context, collectionID uuid.UUID, params *models.DictionaryParams,
+	) (*models.CollectionDictionary, error)
+	// Create collection and queue crawl of the site into it
+	Crawl(
+		ctx context.Context, collection *models.Collection, params *models.CrawlParams,
+	) (*models.CollectionCrawl, error)
+	// Crawl site and save its pages as documents of the collection, handler of crawl jobs
+	ProcessCrawl(ctx context.Context, job *models.Job) (interface{}, error)
+	// Create collection and queue import of documentation files of an uploaded archive into it
+	Import(
+		ctx context.Context, collection *models.Collection, params *models.ImportParams, fileName string,
+		archive []byte,
+	) (*models.CollectionImport, error)
+	// Create collection and import documentation files of a local directory or archive into it right away
+	ImportPath(
+		ctx context.Context, collection *models.Collection, params *models.ImportParams, name string,
+	) (*models.ImportSummary, error)
+	// Save imported files as documents of the collection, handler of import jobs
+	ProcessImport(ctx context.Context, job *models.Job) (interface{}, error)
+}
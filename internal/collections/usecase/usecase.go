@@ -0,0 +1,723 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/collections"
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/documents"
+	"github.com/shlembo598/text-lexicon-go/internal/jobs"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/pkg/crawler"
+	"github.com/shlembo598/text-lexicon-go/pkg/doctree"
+	"github.com/shlembo598/text-lexicon-go/pkg/fetcher"
+	"github.com/shlembo598/text-lexicon-go/pkg/logger/sl"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils/httpErrors"
+)
+
+// New words of a file listed in an import summary
+const maxSummaryWords = 50
+
+var ErrUnknownDocument = errors.New("document not found")
+
+type collectionsUC struct {
+	cfg             *config.Config
+	collectionsRepo collections.Repository
+	documentsUC     documents.UseCase
+	jobsUC          jobs.UseCase
+	fetcher         fetcher.Fetcher
+}
+
+func NewCollectionsUseCase(
+	cfg *config.Config, collectionsRepo collections.Repository, documentsUC documents.UseCase, jobsUC jobs.UseCase,
+	fetcher fetcher.Fetcher,
+) collections.UseCase {
+	return &collectionsUC{
+		cfg: cfg, collectionsRepo: collectionsRepo, documentsUC: documentsUC, jobsUC: jobsUC, fetcher: fetcher,
+	}
+}
+
+// Create collection of the current user with the given documents
+func (u *collectionsUC) Create(
+	ctx context.Context, collection *models.Collection, documentIDs []uuid.UUID,
+) (*models.Collection, error) {
+	const op = "collections.useCase.create"
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(fmt.Errorf("%s.GetUserFromCtx: %w", op, err))
+	}
+
+	if err = u.checkDocuments(ctx, documentIDs); err != nil {
+		return nil, err
+	}
+
+	collection.UserID = user.UserID
+	collection.SourceURL = nil
+	collection.PrepareCreate()
+
+	created, err := u.collectionsRepo.Create(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.collectionsRepo.AddDocuments(ctx, created.CollectionID, documentIDs); err != nil {
+		return nil, err
+	}
+
+	return u.withDocuments(ctx, created)
+}
+
+// Get collections of the current user
+func (u *collectionsUC) GetAll(ctx context.Context) ([]*models.Collection, error) {
+	const op = "collections.useCase.getAll"
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(fmt.Errorf("%s.GetUserFromCtx: %w", op, err))
+	}
+
+	return u.collectionsRepo.GetByUserID(ctx, user.UserID)
+}
+
+// Get collection of the current user with its documents
+func (u *collectionsUC) GetByID(ctx context.Context, collectionID uuid.UUID) (*models.Collection, error) {
+	collection, err := u.owned(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.withDocuments(ctx, collection)
+}
+
+// Rename collection of the current user
+func (u *collectionsUC) Update(ctx context.Context, collection *models.Collection) (*models.Collection, error) {
+	if _, err := u.owned(ctx, collection.CollectionID); err != nil {
+		return nil, err
+	}
+
+	collection.PrepareCreate()
+	updated, err := u.collectionsRepo.Update(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.withDocuments(ctx, updated)
+}
+
+// Delete collection of the current user, its documents are kept
+func (u *collectionsUC) Delete(ctx context.Context, collectionID uuid.UUID) error {
+	if _, err := u.owned(ctx, collectionID); err != nil {
+		return err
+	}
+
+	return u.collectionsRepo.Delete(ctx, collectionID)
+}
+
+// Add documents to collection of the current user
+func (u *collectionsUC) AddDocuments(
+	ctx context.Context, collectionID uuid.UUID, documentIDs []uuid.UUID,
+) (*models.Collection, error) {
+	collection, err := u.owned(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.checkDocuments(ctx, documentIDs); err != nil {
+		return nil, err
+	}
+
+	if err = u.collectionsRepo.AddDocuments(ctx, collectionID, documentIDs); err != nil {
+		return nil, err
+	}
+
+	return u.withDocuments(ctx, collection)
+}
+
+// Remove document from collection of the current user
+func (u *collectionsUC) RemoveDocument(
+	ctx context.Context, collectionID uuid.UUID, documentID uuid.UUID,
+) (*models.Collection, error) {
+	collection, err := u.owned(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.collectionsRepo.RemoveDocument(ctx, collectionID, documentID); err != nil {
+		return nil, err
+	}
+
+	return u.withDocuments(ctx, collection)
+}
+
+// Get dictionary of collection documents with summed frequencies and collocations found across documents,
+// filters are the same as for documents
+func (u *collectionsUC) GetDictionary(
+	ctx context.Context, collectionID uuid.UUID, params *models.DictionaryParams,
+) (*models.CollectionDictionary, error) {
+	collection, err := u.owned(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if collection, err = u.withDocuments(ctx, collection); err != nil {
+		return nil, err
+	}
+
+	entries, err := u.collectionsRepo.GetEntries(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	collocations, err := u.collocations(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+	entries = mergeCollocations(entries, collocations)
+	share := u.documentsUC.AboveLevelShare(ctx, entries)
+
+	documentIDs, wordCount := make([]uuid.UUID, 0, len(collection.Documents)), 0
+	for _, document := range collection.Documents {
+		documentIDs = append(documentIDs, document.DocumentID)
+		wordCount += document.WordCount
+	}
+	if err = u.documentsUC.ScoreKeyness(ctx, entries, documentIDs, wordCount); err != nil {
+		return nil, err
+	}
+
+	filtered, err := u.documentsUC.FilterEntries(ctx, entries, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CollectionDictionary{
+		Collection:      collection,
+		Entries:         filtered.Entries,
+		Names:           filtered.Names,
+		Acronyms:        filtered.Acronyms,
+		AboveLevelShare: share,
+	}, nil
+}
+
+// Collocations of the collection documents, found once per set of documents and thresholds
+func (u *collectionsUC) collocations(
+	ctx context.Context, collection *models.Collection,
+) ([]*models.DictionaryEntry, error) {
+	const op = "collections.useCase.collocations"
+
+	hash := sha256.New()
+	for _, document := range collection.Documents {
+		hash.Write(document.DocumentID[:])
+	}
+	fmt.Fprintf(hash, "%d %g", u.cfg.Documents.CollocationMinCount, u.cfg.Documents.CollocationMinScore)
+	version := hex.EncodeToString(hash.Sum(nil))
+
+	stored, err := u.collectionsRepo.GetCollocations(ctx, collection.CollectionID, version)
+	if err == nil {
+		collocations := make([]*models.DictionaryEntry, 0)
+		if err = json.Unmarshal(stored, &collocations); err != nil {
+			return nil, fmt.Errorf("%s.Unmarshal: %w", op, err)
+		}
+		return collocations, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	contents, err := u.collectionsRepo.GetDocumentContents(ctx, collection.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	collocations, err := u.documentsUC.FindCollocations(ctx, contents)
+	if err != nil {
+		return nil, err
+	}
+
+	if stored, err = json.Marshal(collocations); err != nil {
+		return nil, fmt.Errorf("%s.Marshal: %w", op, err)
+	}
+	if err = u.collectionsRepo.SaveCollocations(ctx, collection.CollectionID, version, stored); err != nil {
+		slog.Warn(
+			"collocations not stored", slog.String("collection_id", collection.CollectionID.String()), sl.Err(err),
+		)
+	}
+
+	return collocations, nil
+}
+
+// Create collection of the current user named after the site unless a name is given and queue the crawl
+func (u *collectionsUC) Crawl(
+	ctx context.Context, collection *models.Collection, params *models.CrawlParams,
+) (*models.CollectionCrawl, error) {
+	const op = "collections.useCase.crawl"
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(fmt.Errorf("%s.GetUserFromCtx: %w", op, err))
+	}
+
+	root, err := url.Parse(params.URL)
+	if err != nil || root.Host == "" {
+		return nil, httpErrors.NewBadRequestError(fmt.Errorf("%s: %w: %s", op, crawler.ErrInvalidRoot, params.URL))
+	}
+
+	collection.UserID = user.UserID
+	collection.SourceURL = &params.URL
+	if collection.Name == "" {
+		collection.Name = root.Host + root.Path
+	}
+	collection.PrepareCreate()
+
+	created, err := u.collectionsRepo.Create(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	params.CollectionID = created.CollectionID
+	job, err := u.jobsUC.Enqueue(ctx, models.JobKindCrawlCollection, &user.UserID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CollectionCrawl{Collection: created, Job: job}, nil
+}
+
+// Crawl site and save every page as a document of the collection. Pages saved by an interrupted
+// attempt are not saved again, pages without text are skipped.
+func (u *collectionsUC) ProcessCrawl(ctx context.Context, job *models.Job) (interface{}, error) {
+	const op = "collections.useCase.processCrawl"
+
+	params := &models.CrawlParams{}
+	if err := json.Unmarshal(job.Payload, params); err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("%s.Unmarshal: %w", op, err))
+	}
+
+	collection, err := u.collectionsRepo.GetByID(ctx, params.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.GetByID: %w", op, err)
+	}
+
+	sourceURLs, err := u.collectionsRepo.GetSourceURLs(ctx, collection.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.GetSourceURLs: %w", op, err)
+	}
+	saved := make(map[string]bool, len(sourceURLs))
+	for _, sourceURL := range sourceURLs {
+		saved[sourceURL] = true
+	}
+
+	options := crawler.Options{
+		Prefix:         params.Prefix,
+		MaxDepth:       u.cfg.Crawler.MaxDepth,
+		MaxPages:       u.cfg.Crawler.MaxPages,
+		Delay:          u.cfg.Crawler.Delay,
+		UserAgent:      u.cfg.Documents.UserAgent,
+		MaxSitemapSize: u.cfg.Crawler.MaxSitemapSize,
+	}
+	if params.MaxDepth != nil {
+		options.MaxDepth = *params.MaxDepth
+	}
+	if params.MaxPages > 0 {
+		options.MaxPages = min(params.MaxPages, u.cfg.Crawler.MaxPages)
+	}
+
+	documentsCount := 0
+	stats, err := crawler.NewCrawler(u.fetcher, options).Crawl(
+		ctx, params.URL, func(ctx context.Context, page *fetcher.Page) error {
+			if saved[page.URL] {
+				return nil
+			}
+
+			document, err := u.documentsUC.CreateFromPage(
+				ctx, &models.Document{UserID: &collection.UserID, CodeMode: params.CodeMode}, page,
+			)
+			if err != nil {
+				if errors.Is(err, jobs.ErrPermanent) {
+					slog.Info("crawled page skipped", slog.String("url", page.URL), sl.Err(err))
+					return nil
+				}
+				return err
+			}
+
+			err = u.collectionsRepo.AddDocuments(ctx, collection.CollectionID, []uuid.UUID{document.DocumentID})
+			if err != nil {
+				return err
+			}
+			saved[page.URL] = true
+			documentsCount++
+
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.Is(err, crawler.ErrInvalidRoot) {
+			err = jobs.Permanent(err)
+		}
+		return nil, fmt.Errorf("%s.Crawl: %w", op, err)
+	}
+
+	return map[string]interface{}{
+		"collection_id": collection.CollectionID,
+		"documents":     documentsCount,
+		"stats":         stats,
+	}, nil
+}
+
+// Read documentation files of an uploaded archive, create collection of the current user named after the archive
+// unless a name is given and queue the import of the files
+func (u *collectionsUC) Import(
+	ctx context.Context, collection *models.Collection, params *models.ImportParams, fileName string, archive []byte,
+) (*models.CollectionImport, error) {
+	const op = "collections.useCase.import"
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(fmt.Errorf("%s.GetUserFromCtx: %w", op, err))
+	}
+
+	files, err := doctree.ReadArchive(fileName, archive, u.treeOptions(params))
+	if err != nil {
+		return nil, httpErrors.NewBadRequestError(fmt.Errorf("%s.ReadArchive: %w", op, err))
+	}
+	prepareImport(params, files)
+
+	created, err := u.createImported(ctx, collection, user.UserID, fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	params.CollectionID = created.CollectionID
+	// Contents are stored apart, the payload holds paths and hashes only
+	if err = u.collectionsRepo.SaveImportFiles(ctx, created.CollectionID, params.Files); err != nil {
+		return nil, fmt.Errorf("%s.SaveImportFiles: %w", op, err)
+	}
+	job, err := u.jobsUC.Enqueue(ctx, models.JobKindImportCollection, &user.UserID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CollectionImport{Collection: created, Job: job}, nil
+}
+
+// Read documentation files of a local directory or archive and import them into a new collection of the current
+// user right away, used by the command line where no workers run
+func (u *collectionsUC) ImportPath(
+	ctx context.Context, collection *models.Collection, params *models.ImportParams, name string,
+) (*models.ImportSummary, error) {
+	const op = "collections.useCase.importPath"
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s.GetUserFromCtx: %w", op, err)
+	}
+
+	files, err := doctree.Read(name, u.treeOptions(params))
+	if err != nil {
+		return nil, fmt.Errorf("%s.Read: %w", op, err)
+	}
+	prepareImport(params, files)
+
+	created, err := u.createImported(ctx, collection, user.UserID, filepath.Base(filepath.Clean(name)))
+	if err != nil {
+		return nil, err
+	}
+	params.CollectionID = created.CollectionID
+
+	return u.importFiles(ctx, created, params)
+}
+
+// Save imported files as documents of the collection, handler of import jobs. Stored contents of the files
+// are deleted once all of them are saved.
+func (u *collectionsUC) ProcessImport(ctx context.Context, job *models.Job) (interface{}, error) {
+	const op = "collections.useCase.processImport"
+
+	params := &models.ImportParams{}
+	if err := json.Unmarshal(job.Payload, params); err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("%s.Unmarshal: %w", op, err))
+	}
+
+	collection, err := u.collectionsRepo.GetByID(ctx, params.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.GetByID: %w", op, err)
+	}
+
+	summary, err := u.importFiles(ctx, collection, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.collectionsRepo.DeleteImportFiles(ctx, collection.CollectionID); err != nil {
+		return nil, fmt.Errorf("%s.DeleteImportFiles: %w", op, err)
+	}
+
+	return summary, nil
+}
+
+// Save every file as a document of the collection in path order. Files saved by an interrupted attempt are not
+// saved again, files without text are skipped. New words of a file are the ones not found in the collection
+// before it.
+func (u *collectionsUC) importFiles(
+	ctx context.Context, collection *models.Collection, params *models.ImportParams,
+) (*models.ImportSummary, error) {
+	const op = "collections.useCase.importFiles"
+
+	sourcePaths, err := u.collectionsRepo.GetSourcePaths(ctx, collection.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.GetSourcePaths: %w", op, err)
+	}
+	saved := make(map[string]bool, len(sourcePaths))
+	for _, sourcePath := range sourcePaths {
+		saved[sourcePath] = true
+	}
+
+	entries, err := u.collectionsRepo.GetEntries(ctx, collection.CollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.GetEntries: %w", op, err)
+	}
+	known := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		known[entry.Word] = true
+	}
+
+	summary := &models.ImportSummary{
+		CollectionID: collection.CollectionID,
+		Files:        make([]*models.ImportedFile, 0, len(params.Files)),
+		Skipped:      append(make([]*models.SkippedFile, 0, len(params.Skipped)), params.Skipped...),
+	}
+	for _, file := range params.Files {
+		if saved[file.Path] {
+			continue
+		}
+
+		// Files of queued imports are loaded one at a time
+		body := file.Body
+		if body == nil {
+			if body, err = u.collectionsRepo.GetImportFile(ctx, collection.CollectionID, file.Hash); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					err = jobs.Permanent(err)
+				}
+				return nil, fmt.Errorf("%s.GetImportFile: %w", op, err)
+			}
+		}
+
+		sourcePath := file.Path
+		dictionary, err := u.documentsUC.Import(
+			ctx, &models.Document{UserID: &collection.UserID, SourcePath: &sourcePath, CodeMode: params.CodeMode},
+			body,
+		)
+		if err != nil {
+			if errors.Is(err, jobs.ErrPermanent) {
+				slog.Info("imported file skipped", slog.String("path", file.Path), sl.Err(err))
+				summary.Skipped = append(summary.Skipped, &models.SkippedFile{Path: file.Path, Reason: err.Error()})
+				continue
+			}
+			return nil, fmt.Errorf("%s.Import: %w", op, err)
+		}
+
+		documentID := dictionary.Document.DocumentID
+		if err = u.collectionsRepo.AddDocuments(ctx, collection.CollectionID, []uuid.UUID{documentID}); err != nil {
+			return nil, fmt.Errorf("%s.AddDocuments: %w", op, err)
+		}
+		saved[file.Path] = true
+
+		summary.Files = append(summary.Files, importedFile(file, dictionary, known))
+	}
+
+	return summary, nil
+}
+
+// Limits of a tree read for an import
+func (u *collectionsUC) treeOptions(params *models.ImportParams) doctree.Options {
+	return doctree.Options{
+		Include:         params.Include,
+		Exclude:         params.Exclude,
+		MaxFileSize:     u.cfg.Imports.MaxFileSize,
+		MaxFiles:        u.cfg.Imports.MaxFiles,
+		MaxTotalSize:    u.cfg.Imports.MaxTotalSize,
+		MaxUnpackedSize: u.cfg.Imports.MaxUnpackedSize,
+	}
+}
+
+// Fill files of the import, files larger than the limit and files with the content of an earlier file
+// are skipped
+func prepareImport(params *models.ImportParams, files []doctree.File) {
+	params.Files = make([]*models.ImportFile, 0, len(files))
+	params.Skipped = make([]*models.SkippedFile, 0)
+
+	byHash := make(map[string]string, len(files))
+	for _, file := range files {
+		if file.Body == nil {
+			params.Skipped = append(params.Skipped, &models.SkippedFile{
+				Path: file.Path, Reason: fmt.Sprintf("file of %d bytes is too large", file.Size),
+			})
+			continue
+		}
+
+		sum := sha256.Sum256(file.Body)
+		hash := hex.EncodeToString(sum[:])
+		if original, ok := byHash[hash]; ok {
+			params.Skipped = append(params.Skipped, &models.SkippedFile{
+				Path: file.Path, Reason: "duplicate of " + original,
+			})
+			continue
+		}
+		byHash[hash] = file.Path
+
+		params.Files = append(params.Files, &models.ImportFile{Path: file.Path, Hash: hash, Body: file.Body})
+	}
+}
+
+// Create collection of the user for an import named after the imported tree unless a name is given
+func (u *collectionsUC) createImported(
+	ctx context.Context, collection *models.Collection, userID uuid.UUID, treeName string,
+) (*models.Collection, error) {
+	collection.UserID = userID
+	collection.SourceURL = nil
+	if strings.TrimSpace(collection.Name) == "" {
+		collection.Name = treeName
+		for _, ext := range []string{".tar.gz", ".tgz", ".tar", ".zip"} {
+			collection.Name = strings.TrimSuffix(collection.Name, ext)
+		}
+	}
+	collection.PrepareCreate()
+
+	return u.collectionsRepo.Create(ctx, collection)
+}
+
+// Summary of a saved file with its entries not known before, known words are updated with them
+func importedFile(
+	file *models.ImportFile, dictionary *models.Dictionary, known map[string]bool,
+) *models.ImportedFile {
+	added := make([]*models.DictionaryEntry, 0)
+	for _, entry := range dictionary.Entries {
+		if !known[entry.Word] {
+			known[entry.Word] = true
+			added = append(added, entry)
+		}
+	}
+	sort.SliceStable(added, func(i, j int) bool {
+		if added[i].Frequency != added[j].Frequency {
+			return added[i].Frequency > added[j].Frequency
+		}
+		return added[i].Word < added[j].Word
+	})
+
+	newWords := make([]string, 0, min(len(added), maxSummaryWords))
+	for _, entry := range added[:min(len(added), maxSummaryWords)] {
+		newWords = append(newWords, entry.Word)
+	}
+
+	return &models.ImportedFile{
+		Path:          file.Path,
+		Hash:          file.Hash,
+		DocumentID:    dictionary.Document.DocumentID,
+		WordCount:     dictionary.Document.WordCount,
+		NewWords:      newWords,
+		NewWordsCount: len(added),
+	}
+}
+
+// Get collection of the current user, collections of other users are reported as not found
+func (u *collectionsUC) owned(ctx context.Context, collectionID uuid.UUID) (*models.Collection, error) {
+	const op = "collections.useCase.owned"
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(fmt.Errorf("%s.GetUserFromCtx: %w", op, err))
+	}
+
+	collection, err := u.collectionsRepo.GetByID(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if collection.UserID != user.UserID {
+		return nil, fmt.Errorf("%s: %w", op, sql.ErrNoRows)
+	}
+
+	return collection, nil
+}
+
+// Load documents of the collection
+func (u *collectionsUC) withDocuments(ctx context.Context, collection *models.Collection) (*models.Collection, error) {
+	documents, err := u.collectionsRepo.GetDocuments(ctx, collection.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+	collection.Documents = documents
+
+	return collection, nil
+}
+
+// Check that all documents exist and belong to the current user, documents of other users are not found
+func (u *collectionsUC) checkDocuments(ctx context.Context, documentIDs []uuid.UUID) error {
+	const op = "collections.useCase.checkDocuments"
+
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return httpErrors.NewUnauthorizedError(fmt.Errorf("%s.GetUserFromCtx: %w", op, err))
+	}
+
+	existing, err := u.collectionsRepo.GetOwnedDocuments(ctx, user.UserID, documentIDs)
+	if err != nil {
+		return err
+	}
+
+	found := make(map[uuid.UUID]bool, len(existing))
+	for _, documentID := range existing {
+		found[documentID] = true
+	}
+	for _, documentID := range documentIDs {
+		if !found[documentID] {
+			return httpErrors.NewNotFoundError(fmt.Errorf("%s: %w: %s", op, ErrUnknownDocument, documentID))
+		}
+	}
+
+	return nil
+}
+
+// Add collocations found across documents to entries, counts across documents replace sums of counts
+// in documents where a collocation was found alone. Entries are ordered by frequency.
+func mergeCollocations(entries, collocations []*models.DictionaryEntry) []*models.DictionaryEntry {
+	byWord := make(map[string]*models.DictionaryEntry, len(entries))
+	for _, entry := range entries {
+		byWord[entry.Word] = entry
+	}
+
+	for _, collocation := range collocations {
+		entry, ok := byWord[collocation.Word]
+		if !ok {
+			entries = append(entries, collocation)
+			continue
+		}
+		if entry.Kind == models.EntryKindCollocation && collocation.Frequency > entry.Frequency {
+			entry.Frequency = collocation.Frequency
+			entry.DocumentIDs = collocation.DocumentIDs
+		}
+	}
+
+	sort.SliceStable(
+		entries, func(i, j int) bool {
+			if entries[i].Frequency != entries[j].Frequency {
+				return entries[i].Frequency > entries[j].Frequency
+			}
+			return entries[i].Word < entries[j].Word
+		},
+	)
+
+	return entries
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/collections"
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/documents"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+// Repository keeping stored collocations of a single collection
+type fakeCollectionsRepo struct {
+	collections.Repository
+	version string
+	entries json.RawMessage
+}
+
+func (f *fakeCollectionsRepo) GetCollocations(
+	_ context.Context, _ uuid.UUID, version string,
+) (json.RawMessage, error) {
+	if f.entries == nil || f.version != version {
+		return nil, sql.ErrNoRows
+	}
+
+	return f.entries, nil
+}
+
+func (f *fakeCollectionsRepo) SaveCollocations(
+	_ context.Context, _ uuid.UUID, version string, entries json.RawMessage,
+) error {
+	f.version, f.entries = version, entries
+
+	return nil
+}
+
+func (f *fakeCollectionsRepo) GetDocumentContents(context.Context, uuid.UUID) ([]*models.Document, error) {
+	return []*models.Document{}, nil
+}
+
+// Documents use case counting searches for collocations
+type fakeDocumentsUC struct {
+	documents.UseCase
+	calls int
+}
+
+func (f *fakeDocumentsUC) FindCollocations(
+	context.Context, []*models.Document,
+) ([]*models.DictionaryEntry, error) {
+	f.calls++
+
+	return []*models.DictionaryEntry{{Word: "build tag", Kind: models.EntryKindCollocation, Frequency: 3}}, nil
+}
+
+func TestCollocations(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+
+	cfg := &config.Config{}
+
+	tests := []struct {
+		name       string
+		documents  []uuid.UUID
+		minCount   int
+		wantSearch bool
+	}{
+		{name: "first request", documents: []uuid.UUID{first}, minCount: 3, wantSearch: true},
+		{name: "same documents", documents: []uuid.UUID{first}, minCount: 3},
+		{name: "document added", documents: []uuid.UUID{first, second}, minCount: 3, wantSearch: true},
+		{name: "same documents again", documents: []uuid.UUID{first, second}, minCount: 3},
+		{name: "threshold changed", documents: []uuid.UUID{first, second}, minCount: 4, wantSearch: true},
+	}
+
+	repo, documentsUC := &fakeCollectionsRepo{}, &fakeDocumentsUC{}
+	u := &collectionsUC{cfg: cfg, collectionsRepo: repo, documentsUC: documentsUC}
+	collection := &models.Collection{CollectionID: uuid.New()}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg.Documents.CollocationMinCount = tt.minCount
+			collection.Documents = make([]*models.Document, 0, len(tt.documents))
+			for _, documentID := range tt.documents {
+				collection.Documents = append(collection.Documents, &models.Document{DocumentID: documentID})
+			}
+
+			calls := documentsUC.calls
+			got, err := u.collocations(context.Background(), collection)
+			if err != nil {
+				t.Fatalf("collocations() error = %v", err)
+			}
+			if searched := documentsUC.calls > calls; searched != tt.wantSearch {
+				t.Errorf("collocations() searched = %v, want %v", searched, tt.wantSearch)
+			}
+			if len(got) != 1 || got[0].Word != "build tag" || got[0].Frequency != 3 {
+				t.Errorf("collocations() = %+v, want the build tag collocation", got)
+			}
+		})
+	}
+}
@@ -15,9 +15,16 @@ const (
 )
 
 type Config struct {
-	Env       string     `yaml:"env" env-default:"local"`
-	Server    HttpServer `yaml:"server"`
-	Postrgres Postgres   `yaml:"postgres"`
+	Env          string       `yaml:"env" env-default:"local"`
+	Server       HttpServer   `yaml:"server"`
+	Postrgres    Postgres     `yaml:"postgres"`
+	Documents    Documents    `yaml:"documents"`
+	Translation  Translation  `yaml:"translation"`
+	Dictionaries Dictionaries `yaml:"dictionaries"`
+	Reviews      Reviews      `yaml:"reviews"`
+	Jobs         Jobs         `yaml:"jobs"`
+	Crawler      Crawler      `yaml:"crawler"`
+	Imports      Imports      `yaml:"imports"`
 }
 
 type HttpServer struct {
@@ -29,6 +36,8 @@ type HttpServer struct {
 	Timeout      time.Duration `yaml:"timeout" env-default:"5s"`
 	IdleTimeout  time.Duration `yaml:"ideTimeout" env-default:"60s"`
 	Debug        bool          `yaml:"debug" env-default:"false"`
+	// Users allowed to call admin endpoints
+	AdminEmails []string `yaml:"adminEmails"`
 }
 
 type Postgres struct {
@@ -41,6 +50,106 @@ type Postgres struct {
 	Driver   string `yaml:"pgDriver" env-required:"true"`
 }
 
+type Documents struct {
+	FetchTimeout    time.Duration `yaml:"fetchTimeout" env-default:"10s"`
+	MaxDocumentSize int64         `yaml:"maxDocumentSize" env-default:"5242880"`
+	UserAgent       string        `yaml:"userAgent" env-default:"text-lexicon-go"`
+	// Body limit of file uploads, larger than the limit of other requests
+	MaxUploadSize string `yaml:"maxUploadSize" env-default:"10M"`
+	// Sentences kept for every dictionary entry
+	MaxExamples int `yaml:"maxExamples" env-default:"3"`
+	// Word sequences used less often are not considered collocations
+	CollocationMinCount int `yaml:"collocationMinCount" env-default:"3"`
+	// Lowest log-likelihood ratio of a collocation, 10.83 is the 0.001 significance level
+	CollocationMinScore float64 `yaml:"collocationMinScore" env-default:"10.83"`
+	// Capitalized uses in the middle of sentences needed to take a word for a name
+	NameMinCount int `yaml:"nameMinCount" env-default:"2"`
+}
+
+type Translation struct {
+	// Providers asked in order until one knows the word: offline, http
+	Providers      []string `yaml:"providers" env-default:"offline"`
+	SourceLanguage string   `yaml:"sourceLanguage" env-default:"en"`
+	TargetLanguage string   `yaml:"targetLanguage" env-default:"ru"`
+	CacheSize      int      `yaml:"cacheSize" env-default:"10000"`
+	// Time words without translation are not looked up again
+	MissTTL     time.Duration `yaml:"missTTL" env-default:"10m"`
+	Concurrency int           `yaml:"concurrency" env-default:"4"`
+	// Lookups of remote providers made by one request, other words are left untranslated
+	MaxRemoteLookups int             `yaml:"maxRemoteLookups" env-default:"50"`
+	HTTP             TranslationHTTP `yaml:"http"`
+}
+
+// LibreTranslate compatible api
+type TranslationHTTP struct {
+	URL     string        `yaml:"url" env-default:"http://localhost:5000"`
+	APIKey  string        `yaml:"apiKey"`
+	Timeout time.Duration `yaml:"timeout" env-default:"5s"`
+}
+
+type Dictionaries struct {
+	// Directory with dictionary files which can be imported through the admin api
+	ImportDir string `yaml:"importDir" env-default:"./dictionaries"`
+	// Entries inserted by one statement during import
+	BatchSize int `yaml:"batchSize" env-default:"500"`
+	// Longest run of one import job
+	ImportTimeout time.Duration `yaml:"importTimeout" env-default:"1h"`
+}
+
+type Reviews struct {
+	// Scheduling algorithm of users without settings: sm2 or fsrs
+	Algorithm string `yaml:"algorithm" env-default:"sm2"`
+	// Probability of recall at the due date targeted by FSRS
+	Retention float64 `yaml:"retention" env-default:"0.9"`
+	// Most cards returned by one request
+	MaxDueCards int `yaml:"maxDueCards" env-default:"100"`
+}
+
+type Jobs struct {
+	// Jobs processed at the same time
+	Workers int `yaml:"workers" env-default:"4"`
+	// Delay between checks of an empty queue
+	PollInterval time.Duration `yaml:"pollInterval" env-default:"1s"`
+	MaxAttempts  int           `yaml:"maxAttempts" env-default:"3"`
+	// Delay before the first retry, doubled with every attempt up to MaxBackoff
+	Backoff    time.Duration `yaml:"backoff" env-default:"5s"`
+	MaxBackoff time.Duration `yaml:"maxBackoff" env-default:"5m"`
+	// Longest run of one attempt
+	Timeout time.Duration `yaml:"timeout" env-default:"2m"`
+	// Running jobs whose lock was not refreshed for this long are considered abandoned by a crashed worker
+	// and run again, workers refresh locks of their jobs three times within it
+	LockTimeout time.Duration `yaml:"lockTimeout" env-default:"10m"`
+	// Time given to running jobs on shutdown, unfinished jobs are returned to the queue
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout" env-default:"20s"`
+}
+
+type Crawler struct {
+	// Links followed from the start page when the request does not limit depth
+	MaxDepth int `yaml:"maxDepth" env-default:"3"`
+	// Most pages saved by one crawl
+	MaxPages int `yaml:"maxPages" env-default:"200"`
+	// Shortest time between requests to one host
+	Delay time.Duration `yaml:"delay" env-default:"1s"`
+	// Longest crawl, it runs as one job
+	Timeout        time.Duration `yaml:"timeout" env-default:"1h"`
+	MaxSitemapSize int64         `yaml:"maxSitemapSize" env-default:"52428800"`
+}
+
+type Imports struct {
+	// Most files imported from one directory or archive
+	MaxFiles int `yaml:"maxFiles" env-default:"1000"`
+	// Larger files of a tree are skipped
+	MaxFileSize int64 `yaml:"maxFileSize" env-default:"5242880"`
+	// Files of a tree adding up to more bytes are not imported
+	MaxTotalSize int64 `yaml:"maxTotalSize" env-default:"104857600"`
+	// Archives unpacking to more bytes are not imported, whether they match or not files are unpacked to be read
+	MaxUnpackedSize int64 `yaml:"maxUnpackedSize" env-default:"524288000"`
+	// Body limit of archive uploads
+	MaxArchiveSize string `yaml:"maxArchiveSize" env-default:"20M"`
+	// Longest import, it runs as one job
+	Timeout time.Duration `yaml:"timeout" env-default:"30m"`
+}
+
 func MustLoad() *Config {
 	configPath := os.Getenv("CONFIG_PATH")
 	if configPath == "" {
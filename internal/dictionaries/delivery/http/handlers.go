@@ -0,0 +1,129 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/dictionaries"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils/httpErrors"
+	r "github.com/shlembo598/text-lexicon-go/pkg/utils/responses"
+)
+
+var ErrOutsideImportDir = errors.New("dictionary file must be inside the import directory")
+
+type dictionariesHandlers struct {
+	cfg            *config.Config
+	dictionariesUC dictionaries.UseCase
+}
+
+func NewDictionariesHandlers(cfg *config.Config, dictionariesUC dictionaries.UseCase) dictionaries.Handlers {
+	return &dictionariesHandlers{cfg: cfg, dictionariesUC: dictionariesUC}
+}
+
+// Import godoc
+// @Summary Import dictionary
+// @Description queue import of a StarDict, DSL or TEI dictionary from the server import directory,
+// @Description unchanged dictionary is not imported again unless forced, the dictionary status tells
+// @Description when the import is done
+// @Tags Dictionaries
+// @Accept json
+// @Produce json
+// @Success 202 {object} models.ImportedDictionary
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 403 {object} httpErrors.RestError
+// @Router /admin/dictionaries/import [post]
+func (h *dictionariesHandlers) Import() echo.HandlerFunc {
+	type ImportDictionary struct {
+		// Path relative to the import directory: .ifo of a StarDict dictionary, .dsl or .tei file
+		File   string `json:"file" validate:"required"`
+		Format string `json:"format" validate:"omitempty,oneof=stardict dsl tei"`
+		Name   string `json:"name" validate:"omitempty,lte=250"`
+		Force  bool   `json:"force"`
+	}
+
+	return func(c echo.Context) error {
+		request := &ImportDictionary{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		if !filepath.IsLocal(request.File) {
+			err := httpErrors.NewBadRequestError(ErrOutsideImportDir)
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		dictionary, err := h.dictionariesUC.StartImport(
+			utils.GetRequestCtx(c), &models.DictionaryImport{
+				Path:   filepath.Join(h.cfg.Dictionaries.ImportDir, request.File),
+				Format: request.Format,
+				Name:   request.Name,
+				Force:  request.Force,
+			},
+		)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusAccepted, r.SuccessResponse(dictionary))
+	}
+}
+
+// GetAll godoc
+// @Summary Get dictionaries
+// @Description get imported dictionaries with their import status
+// @Tags Dictionaries
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.ImportedDictionary
+// @Failure 403 {object} httpErrors.RestError
+// @Router /admin/dictionaries [get]
+func (h *dictionariesHandlers) GetAll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		result, err := h.dictionariesUC.GetAll(utils.GetRequestCtx(c))
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, r.SuccessResponse(result))
+	}
+}
+
+// GetByID godoc
+// @Summary Get dictionary by id
+// @Description get dictionary with its import status
+// @Tags Dictionaries
+// @Accept json
+// @Produce json
+// @Param id path int true "dictionary_id"
+// @Success 200 {object} models.ImportedDictionary
+// @Failure 404 {object} httpErrors.RestError
+// @Router /admin/dictionaries/{id} [get]
+func (h *dictionariesHandlers) GetByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		dictionaryID, err := strconv.Atoi(c.Param("dictionary_id"))
+		if err != nil {
+			err = httpErrors.NewBadRequestError(err)
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		dictionary, err := h.dictionariesUC.GetByID(utils.GetRequestCtx(c), dictionaryID)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, r.SuccessResponse(dictionary))
+	}
+}
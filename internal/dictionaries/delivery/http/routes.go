@@ -0,0 +1,20 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/shlembo598/text-lexicon-go/internal/auth"
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/dictionaries"
+	"github.com/shlembo598/text-lexicon-go/internal/middleware"
+)
+
+func MapDictionariesRoutes(
+	dictionariesGroup *echo.Group, h dictionaries.Handlers, mw *middleware.MiddlewareManager, authUC auth.UseCase,
+	cfg *config.Config,
+) {
+	dictionariesGroup.Use(mw.AuthJWTMiddleware(authUC, cfg), mw.AdminMiddleware)
+	dictionariesGroup.POST("/import", h.Import())
+	dictionariesGroup.GET("", h.GetAll())
+	dictionariesGroup.GET("/:dictionary_id", h.GetByID())
+}
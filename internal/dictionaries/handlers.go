@@ -0,0 +1,11 @@
+package dictionaries
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+type Handlers interface {
+	Import() echo.HandlerFunc
+	GetAll() echo.HandlerFunc
+	GetByID() echo.HandlerFunc
+}
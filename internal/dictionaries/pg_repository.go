@@ -0,0 +1,23 @@
+package dictionaries
+
+import (
+	"context"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/pkg/dictfile"
+)
+
+type Repository interface {
+	// Create dictionary or mark existing one as importing, fails if another import of it is running
+	StartImport(ctx context.Context, dictionary *models.ImportedDictionary, force bool) (*models.ImportedDictionary, error)
+	// Replace dictionary headwords with entries passed by read to its handler in one transaction
+	Import(
+		ctx context.Context, dictionary *models.ImportedDictionary, read func(add dictfile.Handler) error,
+	) (*models.ImportedDictionary, error)
+	SetFailed(ctx context.Context, dictionaryID int, reason string) error
+	// Fail importing dictionaries without a pending or running import job, returns their count
+	FailInterrupted(ctx context.Context, reason string) (int64, error)
+	GetByName(ctx context.Context, name string) (*models.ImportedDictionary, error)
+	GetByID(ctx context.Context, dictionaryID int) (*models.ImportedDictionary, error)
+	GetAll(ctx context.Context) ([]*models.ImportedDictionary, error)
+}
@@ -0,0 +1,362 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/dictionaries"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/pkg/dictfile"
+)
+
+const (
+	// Postgres caps a statement at 65535 bind parameters, so rows are inserted in chunks
+	rowsChunkSize = 1000
+	// Length limits of dictionary_headwords columns
+	maxHeadwordLength      = 128
+	maxTranscriptionLength = 128
+	maxPartOfSpeechLength  = 32
+	// Separator of translation variants stored in one sense
+	translationsSeparator = ";"
+)
+
+var ErrImportInProgress = errors.New("dictionary import is already in progress")
+
+type dictionariesRepo struct {
+	cfg *config.Config
+	db  *sqlx.DB
+}
+
+func NewDictionariesRepository(cfg *config.Config, db *sqlx.DB) dictionaries.Repository {
+	return &dictionariesRepo{cfg: cfg, db: db}
+}
+
+type headwordRow struct {
+	HeadwordID    int64
+	DictionaryID  int
+	Headword      string
+	Transcription *string
+}
+
+type senseRow struct {
+	SenseID      int64
+	HeadwordID   int64
+	PartOfSpeech *string
+	Translations string
+	Position     int
+}
+
+type exampleRow struct {
+	SenseID     int64
+	Text        string
+	Translation *string
+}
+
+// Create dictionary or mark existing one as importing
+func (r *dictionariesRepo) StartImport(
+	ctx context.Context, dictionary *models.ImportedDictionary, force bool,
+) (*models.ImportedDictionary, error) {
+	const op = "dictionaries.pg_repository.startImport"
+
+	query, args, err := startImportQuery(dictionary, force)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	d := &models.ImportedDictionary{}
+	if err = r.db.QueryRowxContext(ctx, query, args...).StructScan(d); err != nil {
+		// Conflicting row is not updated while its import is running
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrImportInProgress)
+		}
+		return nil, fmt.Errorf("%s.StructScan: %w", op, err)
+	}
+
+	return d, nil
+}
+
+// Replace dictionary headwords in one transaction, so translations use the previous version until commit
+func (r *dictionariesRepo) Import(
+	ctx context.Context, dictionary *models.ImportedDictionary, read func(add dictfile.Handler) error,
+) (*models.ImportedDictionary, error) {
+	const op = "dictionaries.pg_repository.import"
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s.BeginTxx: %w", op, err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	query, args, err := deleteHeadwordsQuery(dictionary.DictionaryID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.deleteQuery: %w", op, err)
+	}
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.ExecContext: %w", op, err)
+	}
+
+	batchSize := max(r.cfg.Dictionaries.BatchSize, 1)
+	batch := make([]*dictfile.Entry, 0, batchSize)
+	count := 0
+
+	err = read(
+		func(entry *dictfile.Entry) error {
+			if utf8.RuneCountInString(entry.Headword) > maxHeadwordLength {
+				return nil
+			}
+
+			batch = append(batch, entry)
+			if len(batch) < batchSize {
+				return nil
+			}
+
+			count += len(batch)
+			err := r.insertEntries(ctx, tx, dictionary.DictionaryID, batch)
+			batch = batch[:0]
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s.read: %w", op, err)
+	}
+
+	if len(batch) > 0 {
+		count += len(batch)
+		if err = r.insertEntries(ctx, tx, dictionary.DictionaryID, batch); err != nil {
+			return nil, fmt.Errorf("%s.insertEntries: %w", op, err)
+		}
+	}
+
+	dictionary.HeadwordsCount = count
+	query, args, err = finishImportQuery(dictionary)
+	if err != nil {
+		return nil, fmt.Errorf("%s.finishQuery: %w", op, err)
+	}
+
+	d := &models.ImportedDictionary{}
+	if err = tx.QueryRowxContext(ctx, query, args...).StructScan(d); err != nil {
+		return nil, fmt.Errorf("%s.StructScan: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s.Commit: %w", op, err)
+	}
+
+	return d, nil
+}
+
+// Insert entries with their senses and examples, ids are reserved beforehand to link rows without
+// inserting them one by one
+func (r *dictionariesRepo) insertEntries(
+	ctx context.Context, tx *sqlx.Tx, dictionaryID int, entries []*dictfile.Entry,
+) error {
+	headwordIDs, err := r.nextIDs(ctx, tx, "dictionary_headwords", "headword_id", len(entries))
+	if err != nil {
+		return err
+	}
+
+	sensesCount := 0
+	for _, entry := range entries {
+		sensesCount += len(entry.Senses)
+	}
+	senseIDs, err := r.nextIDs(ctx, tx, "dictionary_senses", "sense_id", sensesCount)
+	if err != nil {
+		return err
+	}
+
+	headwords := make([]headwordRow, 0, len(entries))
+	senses := make([]senseRow, 0, sensesCount)
+	examples := make([]exampleRow, 0)
+
+	for i, entry := range entries {
+		headwords = append(
+			headwords, headwordRow{
+				HeadwordID:    headwordIDs[i],
+				DictionaryID:  dictionaryID,
+				Headword:      entry.Headword,
+				Transcription: optional(entry.Transcription, maxTranscriptionLength),
+			},
+		)
+
+		for position, sense := range entry.Senses {
+			senseID := senseIDs[len(senses)]
+
+			translations := make([]string, 0, len(sense.Translations))
+			for _, t := range sense.Translations {
+				translations = append(translations, strings.ReplaceAll(t, translationsSeparator, ","))
+			}
+
+			senses = append(
+				senses, senseRow{
+					SenseID:      senseID,
+					HeadwordID:   headwordIDs[i],
+					PartOfSpeech: optional(sense.PartOfSpeech, maxPartOfSpeechLength),
+					Translations: strings.Join(translations, translationsSeparator),
+					Position:     position,
+				},
+			)
+
+			for _, example := range sense.Examples {
+				examples = append(
+					examples, exampleRow{SenseID: senseID, Text: example.Text, Translation: optional(example.Translation, 0)},
+				)
+			}
+		}
+	}
+
+	if err = insertChunks(ctx, tx, headwords, createHeadwordsQuery); err != nil {
+		return err
+	}
+	if err = insertChunks(ctx, tx, senses, createSensesQuery); err != nil {
+		return err
+	}
+
+	return insertChunks(ctx, tx, examples, createExamplesQuery)
+}
+
+func (r *dictionariesRepo) nextIDs(ctx context.Context, tx *sqlx.Tx, table, column string, count int) ([]int64, error) {
+	ids := make([]int64, 0, count)
+	if count == 0 {
+		return ids, nil
+	}
+
+	query, args, err := nextIDsQuery(table, column, count)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.SelectContext(ctx, &ids, query, args...); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Mark import as failed with the reason
+func (r *dictionariesRepo) SetFailed(ctx context.Context, dictionaryID int, reason string) error {
+	const op = "dictionaries.pg_repository.setFailed"
+
+	query, args, err := setFailedQuery(dictionaryID, reason)
+	if err != nil {
+		return fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	if _, err = r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%s.ExecContext: %w", op, err)
+	}
+
+	return nil
+}
+
+// Mark imports without a queued job as failed with the reason
+func (r *dictionariesRepo) FailInterrupted(ctx context.Context, reason string) (int64, error) {
+	const op = "dictionaries.pg_repository.failInterrupted"
+
+	query, args, err := failInterruptedQuery(reason)
+	if err != nil {
+		return 0, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%s.ExecContext: %w", op, err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s.RowsAffected: %w", op, err)
+	}
+
+	return count, nil
+}
+
+// Get dictionary by unique name
+func (r *dictionariesRepo) GetByName(ctx context.Context, name string) (*models.ImportedDictionary, error) {
+	const op = "dictionaries.pg_repository.getByName"
+
+	query, args, err := getDictionaryByNameQuery(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	dictionary := &models.ImportedDictionary{}
+	if err = r.db.GetContext(ctx, dictionary, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.GetContext: %w", op, err)
+	}
+
+	return dictionary, nil
+}
+
+// Get dictionary by id
+func (r *dictionariesRepo) GetByID(ctx context.Context, dictionaryID int) (*models.ImportedDictionary, error) {
+	const op = "dictionaries.pg_repository.getByID"
+
+	query, args, err := getDictionaryQuery(dictionaryID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	dictionary := &models.ImportedDictionary{}
+	if err = r.db.GetContext(ctx, dictionary, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.GetContext: %w", op, err)
+	}
+
+	return dictionary, nil
+}
+
+// Get all dictionaries ordered by name
+func (r *dictionariesRepo) GetAll(ctx context.Context) ([]*models.ImportedDictionary, error) {
+	const op = "dictionaries.pg_repository.getAll"
+
+	query, args, err := getDictionariesQuery()
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	result := make([]*models.ImportedDictionary, 0)
+	if err = r.db.SelectContext(ctx, &result, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.SelectContext: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// Insert rows by chunks small enough for the bind parameters limit
+func insertChunks[T any](
+	ctx context.Context, tx *sqlx.Tx, rows []T, queryFn func([]T) (string, []interface{}, error),
+) error {
+	for start := 0; start < len(rows); start += rowsChunkSize {
+		query, args, err := queryFn(rows[start:min(start+rowsChunkSize, len(rows))])
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Nil for an empty string, longer strings are cut to maxLength runes when it is set
+func optional(s string, maxLength int) *string {
+	if s == "" {
+		return nil
+	}
+	if maxLength > 0 && utf8.RuneCountInString(s) > maxLength {
+		s = string([]rune(s)[:maxLength])
+	}
+
+	return &s
+}
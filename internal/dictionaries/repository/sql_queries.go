@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+func startImportQuery(dictionary *models.ImportedDictionary, force bool) (string, []interface{}, error) {
+	suffix := "ON CONFLICT (name) DO UPDATE SET format = EXCLUDED.format, checksum = EXCLUDED.checksum, " +
+		"status = EXCLUDED.status, error = NULL"
+	if !force {
+		suffix += " WHERE dictionaries.status <> EXCLUDED.status"
+	}
+
+	return sq.Insert("dictionaries").Columns(
+		"name", "format", "checksum", "status", "created_at",
+	).Values(
+		dictionary.Name, dictionary.Format, dictionary.Checksum, models.DictionaryStatusImporting, time.Now(),
+	).Suffix(suffix + " RETURNING *").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func finishImportQuery(dictionary *models.ImportedDictionary) (string, []interface{}, error) {
+	return sq.Update("dictionaries").SetMap(
+		map[string]interface{}{
+			"title":           dictionary.Title,
+			"source_language": dictionary.SourceLanguage,
+			"target_language": dictionary.TargetLanguage,
+			"headwords_count": dictionary.HeadwordsCount,
+			"status":          models.DictionaryStatusDone,
+			"error":           nil,
+			"imported_at":     time.Now(),
+		},
+	).Where("dictionary_id = ?", dictionary.DictionaryID).Suffix("RETURNING *").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func setFailedQuery(dictionaryID int, reason string) (string, []interface{}, error) {
+	return sq.Update("dictionaries").Set("status", models.DictionaryStatusFailed).Set("error", reason).Where(
+		"dictionary_id = ?", dictionaryID,
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+// Imports of the old in-process runner and imports whose job failed without running leave dictionaries importing
+func failInterruptedQuery(reason string) (string, []interface{}, error) {
+	queued := sq.Select("1").From("jobs").Where(
+		sq.Eq{
+			"kind":   models.JobKindImportDictionary,
+			"status": []string{models.JobStatusPending, models.JobStatusRunning},
+		},
+	).Where("(payload->>'dictionary_id')::int = dictionaries.dictionary_id")
+
+	return sq.Update("dictionaries").Set("status", models.DictionaryStatusFailed).Set("error", reason).Where(
+		sq.Eq{"status": models.DictionaryStatusImporting},
+	).Where(sq.Expr("NOT EXISTS (?)", queued)).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func deleteHeadwordsQuery(dictionaryID int) (string, []interface{}, error) {
+	return sq.Delete("dictionary_headwords").Where(
+		"dictionary_id = ?", dictionaryID,
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+// Reserve ids of a serial column so rows referencing each other can be inserted in batches
+func nextIDsQuery(table, column string, count int) (string, []interface{}, error) {
+	return sq.Select().Column(
+		sq.Expr("nextval(pg_get_serial_sequence(?, ?))", table, column),
+	).From(fmt.Sprintf("generate_series(1, %d)", count)).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func createHeadwordsQuery(rows []headwordRow) (string, []interface{}, error) {
+	query := sq.Insert("dictionary_headwords").Columns("headword_id", "dictionary_id", "headword", "transcription")
+	for _, row := range rows {
+		query = query.Values(row.HeadwordID, row.DictionaryID, row.Headword, row.Transcription)
+	}
+
+	return query.PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func createSensesQuery(rows []senseRow) (string, []interface{}, error) {
+	query := sq.Insert("dictionary_senses").Columns(
+		"sense_id", "headword_id", "part_of_speech", "translations", "position",
+	)
+	for _, row := range rows {
+		query = query.Values(row.SenseID, row.HeadwordID, row.PartOfSpeech, row.Translations, row.Position)
+	}
+
+	return query.PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func createExamplesQuery(rows []exampleRow) (string, []interface{}, error) {
+	query := sq.Insert("dictionary_examples").Columns("sense_id", "text", "translation")
+	for _, row := range rows {
+		query = query.Values(row.SenseID, row.Text, row.Translation)
+	}
+
+	return query.PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getDictionaryByNameQuery(name string) (string, []interface{}, error) {
+	return sq.Select("*").From("dictionaries").Where("name = ?", name).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getDictionaryQuery(dictionaryID int) (string, []interface{}, error) {
+	return sq.Select("*").From("dictionaries").Where(
+		"dictionary_id = ?", dictionaryID,
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getDictionariesQuery() (string, []interface{}, error) {
+	return sq.Select("*").From("dictionaries").OrderBy("name").PlaceholderFormat(sq.Dollar).ToSql()
+}
@@ -0,0 +1,20 @@
+package dictionaries
+
+import (
+	"context"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+type UseCase interface {
+	// Import dictionary and wait for the result
+	Import(ctx context.Context, params *models.DictionaryImport) (*models.ImportedDictionary, error)
+	// Queue import for job workers, returns dictionary in importing status
+	StartImport(ctx context.Context, params *models.DictionaryImport) (*models.ImportedDictionary, error)
+	// Job handler of queued imports
+	ProcessImport(ctx context.Context, job *models.Job) (interface{}, error)
+	// Fail imports interrupted without a queued job to finish them
+	FailInterrupted(ctx context.Context) error
+	GetAll(ctx context.Context) ([]*models.ImportedDictionary, error)
+	GetByID(ctx context.Context, dictionaryID int) (*models.ImportedDictionary, error)
+}
@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/dictionaries"
+	"github.com/shlembo598/text-lexicon-go/internal/jobs"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/internal/translation"
+	"github.com/shlembo598/text-lexicon-go/pkg/dictfile"
+	"github.com/shlembo598/text-lexicon-go/pkg/dictfile/dsl"
+	"github.com/shlembo598/text-lexicon-go/pkg/dictfile/stardict"
+	"github.com/shlembo598/text-lexicon-go/pkg/dictfile/tei"
+	"github.com/shlembo598/text-lexicon-go/pkg/logger/sl"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils/httpErrors"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown dictionary format")
+	ErrInterrupted   = errors.New("import was interrupted, start it again")
+)
+
+// Dictionary readers by format
+var readers = map[string]func(path string, handler dictfile.Handler) (*dictfile.Info, error){
+	models.DictionaryFormatStarDict: stardict.Read,
+	models.DictionaryFormatDSL:      dsl.Read,
+	models.DictionaryFormatTEI:      tei.Read,
+}
+
+type dictionariesUC struct {
+	cfg              *config.Config
+	dictionariesRepo dictionaries.Repository
+	jobsUC           jobs.UseCase
+	// Translations cached before an import, nil where nothing is cached
+	translations translation.Cache
+}
+
+// Payload of import jobs, the dictionary is created in importing status before the job is queued
+type importPayload struct {
+	DictionaryID int    `json:"dictionary_id"`
+	Path         string `json:"path"`
+	Format       string `json:"format"`
+}
+
+func NewDictionariesUseCase(
+	cfg *config.Config, dictionariesRepo dictionaries.Repository, jobsUC jobs.UseCase, translations translation.Cache,
+) dictionaries.UseCase {
+	return &dictionariesUC{cfg: cfg, dictionariesRepo: dictionariesRepo, jobsUC: jobsUC, translations: translations}
+}
+
+// Import dictionary and wait for the result, unchanged dictionary is not imported again unless forced
+func (u *dictionariesUC) Import(
+	ctx context.Context, params *models.DictionaryImport,
+) (*models.ImportedDictionary, error) {
+	dictionary, started, err := u.start(ctx, params)
+	if err != nil || !started {
+		return dictionary, err
+	}
+
+	return u.run(ctx, params, dictionary)
+}
+
+// Queue import for job workers, returns dictionary in importing status
+func (u *dictionariesUC) StartImport(
+	ctx context.Context, params *models.DictionaryImport,
+) (*models.ImportedDictionary, error) {
+	const op = "dictionaries.useCase.startImport"
+
+	dictionary, started, err := u.start(ctx, params)
+	if err != nil || !started {
+		return dictionary, err
+	}
+
+	var userID *uuid.UUID
+	if user, err := utils.GetUserFromCtx(ctx); err == nil {
+		userID = &user.UserID
+	}
+
+	payload := &importPayload{DictionaryID: dictionary.DictionaryID, Path: params.Path, Format: params.Format}
+	if _, err = u.jobsUC.Enqueue(ctx, models.JobKindImportDictionary, userID, payload); err != nil {
+		failErr := u.dictionariesRepo.SetFailed(context.WithoutCancel(ctx), dictionary.DictionaryID, err.Error())
+		if failErr != nil {
+			slog.Error("dictionary import status", slog.String("name", dictionary.Name), sl.Err(failErr))
+		}
+		return nil, fmt.Errorf("%s.Enqueue: %w", op, err)
+	}
+
+	return dictionary, nil
+}
+
+// Import dictionary of a queued job. The failure is saved in the dictionary, so it is not retried
+// and the import is started again by the admin.
+func (u *dictionariesUC) ProcessImport(ctx context.Context, job *models.Job) (interface{}, error) {
+	const op = "dictionaries.useCase.processImport"
+
+	payload := &importPayload{}
+	if err := json.Unmarshal(job.Payload, payload); err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("%s.Unmarshal: %w", op, err))
+	}
+
+	dictionary, err := u.dictionariesRepo.GetByID(ctx, payload.DictionaryID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.GetByID: %w", op, err)
+	}
+
+	imported, err := u.run(
+		ctx, &models.DictionaryImport{Path: payload.Path, Format: payload.Format, Name: dictionary.Name}, dictionary,
+	)
+	if err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("%s.run: %w", op, err))
+	}
+
+	return imported, nil
+}
+
+// Fail imports left importing by stopped processes or by jobs failed without running, so they can be
+// started again
+func (u *dictionariesUC) FailInterrupted(ctx context.Context) error {
+	const op = "dictionaries.useCase.failInterrupted"
+
+	count, err := u.dictionariesRepo.FailInterrupted(ctx, ErrInterrupted.Error())
+	if err != nil {
+		return fmt.Errorf("%s.FailInterrupted: %w", op, err)
+	}
+	if count > 0 {
+		slog.Warn("interrupted dictionary imports failed", slog.Int64("count", count))
+	}
+
+	return nil
+}
+
+// Get all imported dictionaries
+func (u *dictionariesUC) GetAll(ctx context.Context) ([]*models.ImportedDictionary, error) {
+	return u.dictionariesRepo.GetAll(ctx)
+}
+
+// Get dictionary by id
+func (u *dictionariesUC) GetByID(ctx context.Context, dictionaryID int) (*models.ImportedDictionary, error) {
+	return u.dictionariesRepo.GetByID(ctx, dictionaryID)
+}
+
+// Check files and mark dictionary as importing, started is false when files were not changed since
+// the last successful import
+func (u *dictionariesUC) start(
+	ctx context.Context, params *models.DictionaryImport,
+) (*models.ImportedDictionary, bool, error) {
+	const op = "dictionaries.useCase.start"
+
+	params.PrepareImport()
+	if _, ok := readers[params.Format]; !ok {
+		return nil, false, httpErrors.NewBadRequestError(fmt.Errorf("%s: %w: %s", op, ErrUnknownFormat, params.Path))
+	}
+
+	checksum, err := filesChecksum(params)
+	if err != nil {
+		return nil, false, httpErrors.NewBadRequestError(fmt.Errorf("%s.filesChecksum: %w", op, err))
+	}
+
+	existing, err := u.dictionariesRepo.GetByName(ctx, params.Name)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, err
+	}
+	if existing != nil && !params.Force && existing.Checksum == checksum &&
+		existing.Status == models.DictionaryStatusDone {
+		return existing, false, nil
+	}
+
+	dictionary, err := u.dictionariesRepo.StartImport(
+		ctx, &models.ImportedDictionary{Name: params.Name, Format: params.Format, Checksum: checksum}, params.Force,
+	)
+	if err != nil {
+		return nil, false, httpErrors.NewBadRequestError(fmt.Errorf("%s.StartImport: %w", op, err))
+	}
+
+	return dictionary, true, nil
+}
+
+// Read dictionary files into the database, failure reason is saved in the dictionary
+func (u *dictionariesUC) run(
+	ctx context.Context, params *models.DictionaryImport, dictionary *models.ImportedDictionary,
+) (*models.ImportedDictionary, error) {
+	const op = "dictionaries.useCase.run"
+
+	slog.Info("dictionary import started", slog.String("name", dictionary.Name), slog.String("path", params.Path))
+
+	imported, err := u.dictionariesRepo.Import(
+		ctx, dictionary, func(add dictfile.Handler) error {
+			info, err := readers[params.Format](params.Path, add)
+			if err != nil {
+				return err
+			}
+
+			dictionary.Title = nonEmpty(info.Name)
+			dictionary.SourceLanguage = nonEmpty(info.SourceLanguage)
+			dictionary.TargetLanguage = nonEmpty(info.TargetLanguage)
+			if dictionary.SourceLanguage == nil {
+				dictionary.SourceLanguage = nonEmpty(u.cfg.Translation.SourceLanguage)
+			}
+			if dictionary.TargetLanguage == nil {
+				dictionary.TargetLanguage = nonEmpty(u.cfg.Translation.TargetLanguage)
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		failErr := u.dictionariesRepo.SetFailed(context.WithoutCancel(ctx), dictionary.DictionaryID, err.Error())
+		if failErr != nil {
+			slog.Error("dictionary import status", slog.String("name", dictionary.Name), sl.Err(failErr))
+		}
+		return nil, fmt.Errorf("%s.Import: %w", op, err)
+	}
+
+	// Words missed before are known now
+	if u.translations != nil {
+		u.translations.Clear()
+	}
+
+	slog.Info(
+		"dictionary import finished",
+		slog.String("name", imported.Name),
+		slog.Int("headwords", imported.HeadwordsCount),
+	)
+
+	return imported, nil
+}
+
+// Hash of all dictionary files, used to skip re-import of unchanged dictionaries
+func filesChecksum(params *models.DictionaryImport) (string, error) {
+	paths := []string{params.Path}
+	if params.Format == models.DictionaryFormatStarDict {
+		var err error
+		if paths, err = stardict.Files(params.Path); err != nil {
+			return "", err
+		}
+	}
+
+	hash := sha256.New()
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return "", err
+		}
+
+		_, err = io.Copy(hash, f)
+		_ = f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func nonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
@@ -0,0 +1,224 @@
+package http
+
+import (
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/documents"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils/httpErrors"
+	r "github.com/shlembo598/text-lexicon-go/pkg/utils/responses"
+)
+
+type documentsHandlers struct {
+	cfg         *config.Config
+	documentsUC documents.UseCase
+}
+
+func NewDocumentsHandlers(cfg *config.Config, documentsUC documents.UseCase) documents.Handlers {
+	return &documentsHandlers{cfg: cfg, documentsUC: documentsUC}
+}
+
+// Create godoc
+// @Summary Create document
+// @Description queue document from url or raw text, the dictionary is built in background,
+// @Description the job result holds the created document when it is done
+// @Tags Documents
+// @Accept json
+// @Produce json
+// @Success 202 {object} models.Job
+// @Failure 400 {object} httpErrors.RestError
+// @Router /documents [post]
+func (h *documentsHandlers) Create() echo.HandlerFunc {
+	type CreateDocument struct {
+		URL   string `json:"url" validate:"required_without=Text,omitempty,url"`
+		Text  string `json:"text" validate:"required_without=URL"`
+		Title string `json:"title" validate:"omitempty,lte=250"`
+		// skip leaves code out of the dictionary, split breaks identifiers into words
+		CodeMode string `json:"code_mode" validate:"omitempty,oneof=skip split"`
+	}
+
+	return func(c echo.Context) error {
+		request := &CreateDocument{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		document := &models.Document{
+			Title:    request.Title,
+			Content:  request.Text,
+			CodeMode: request.CodeMode,
+		}
+		if request.URL != "" {
+			document.SourceURL = &request.URL
+		}
+
+		job, err := h.documentsUC.Create(utils.GetRequestCtx(c), document)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusAccepted, r.SuccessResponse(job))
+	}
+}
+
+// Upload godoc
+// @Summary Upload document
+// @Description queue markdown, reStructuredText, AsciiDoc, PDF or EPUB file, headings, code blocks and admonitions
+// @Description are parsed as on html pages, examples of PDF and EPUB files keep their page or chapter.
+// @Description Doc comments of Go files and descriptions of OpenAPI specs are taken with examples linked to
+// @Description the documented symbol, operation id or schema path. Cues of SRT and WebVTT subtitles are joined
+// @Description into paragraphs, examples keep the timestamp of the cue they start in,
+// @Description the job result holds the created document when it is done
+// @Tags Documents
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true ".md, .rst, .adoc, .pdf, .epub, .srt, .vtt, .go or OpenAPI .json or .yaml file"
+// @Param title formData string false "title of the document, the first heading of the file by default"
+// @Param code_mode formData string false "skip leaves code out of the dictionary, split breaks identifiers into words"
+// @Success 202 {object} models.Job
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 413 {object} httpErrors.RestError
+// @Router /documents/upload [post]
+func (h *documentsHandlers) Upload() echo.HandlerFunc {
+	type UploadDocument struct {
+		Title    string `form:"title" validate:"omitempty,lte=250"`
+		CodeMode string `form:"code_mode" validate:"omitempty,oneof=skip split"`
+	}
+
+	return func(c echo.Context) error {
+		request := &UploadDocument{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			err = httpErrors.NewBadRequestError(err)
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+		defer file.Close()
+
+		body, err := io.ReadAll(file)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		document := &models.Document{Title: request.Title, CodeMode: request.CodeMode}
+		job, err := h.documentsUC.CreateFromFile(utils.GetRequestCtx(c), document, fileHeader.Filename, body)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusAccepted, r.SuccessResponse(job))
+	}
+}
+
+// GetByID godoc
+// @Summary Get document by id
+// @Description get document with its generated dictionary, documents added by a user are found by the user only
+// @Tags Documents
+// @Accept json
+// @Produce json
+// @Param id path string true "document_id"
+// @Param hide_known query bool false "leave out known and ignored words of the caller, true by default"
+// @Param min_frequency query int false "leave out words used less often"
+// @Param pos query string false "keep only words with a translation of this part of speech"
+// @Param min_level query string false "keep only words at or above this CEFR level" Enums(A1, A2, B1, B2, C1, C2)
+// @Param at_my_level query bool false "keep only words at or above the level in the profile of the caller"
+// @Param sort query string false "order of entries, most frequent or typical first" Enums(frequency, keyness, word)
+// @Param names query string false "proper nouns and product names in a separate list or dropped" Enums(separate, drop)
+// @Success 200 {object} models.Dictionary
+// @Failure 404 {object} httpErrors.RestError
+// @Router /documents/{id} [get]
+func (h *documentsHandlers) GetByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		documentID, err := uuid.Parse(c.Param("document_id"))
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		params, err := utils.GetDictionaryParams(c)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		dictionary, err := h.documentsUC.GetByID(utils.GetRequestCtx(c), documentID, params)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, r.SuccessResponse(dictionary))
+	}
+}
+
+// Export godoc
+// @Summary Export document dictionary
+// @Description download dictionary as csv, tsv, markdown table or Anki deck, filters are the same as for the document
+// @Tags Documents
+// @Produce octet-stream
+// @Param id path string true "document_id"
+// @Param format query string false "file format" Enums(csv, tsv, md, apkg) default(csv)
+// @Param hide_known query bool false "leave out known and ignored words of the caller, true by default"
+// @Param min_frequency query int false "leave out words used less often"
+// @Param pos query string false "keep only words with a translation of this part of speech"
+// @Param min_level query string false "keep only words at or above this CEFR level" Enums(A1, A2, B1, B2, C1, C2)
+// @Param at_my_level query bool false "keep only words at or above the level in the profile of the caller"
+// @Param sort query string false "order of entries, most frequent or typical first" Enums(frequency, keyness, word)
+// @Param names query string false "proper nouns and product names in a separate list or dropped" Enums(separate, drop)
+// @Success 200 {file} file
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Router /documents/{id}/dictionary [get]
+func (h *documentsHandlers) Export() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		documentID, err := uuid.Parse(c.Param("document_id"))
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		params, err := utils.GetDictionaryParams(c)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		format := c.QueryParam("format")
+		if format == "" {
+			format = models.ExportFormatCSV
+		}
+
+		export, err := h.documentsUC.Export(utils.GetRequestCtx(c), documentID, params, format)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		disposition := mime.FormatMediaType("attachment", map[string]string{"filename": export.FileName})
+		c.Response().Header().Set(echo.HeaderContentDisposition, disposition)
+
+		return c.Blob(http.StatusOK, export.ContentType, export.Body)
+	}
+}
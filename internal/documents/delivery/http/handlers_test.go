@@ -0,0 +1,216 @@
+package http
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/documents"
+	documentsUseCase "github.com/shlembo598/text-lexicon-go/internal/documents/usecase"
+	"github.com/shlembo598/text-lexicon-go/internal/jobs"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/internal/translation"
+	"github.com/shlembo598/text-lexicon-go/internal/words"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils"
+)
+
+// Repository of stored documents without entries
+type fakeDocumentsRepo struct {
+	documents.Repository
+	documents map[uuid.UUID]*models.Document
+	contents  map[uuid.UUID]string
+}
+
+func (f *fakeDocumentsRepo) GetByID(_ context.Context, documentID uuid.UUID) (*models.Document, error) {
+	document, ok := f.documents[documentID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+
+	return document, nil
+}
+
+func (f *fakeDocumentsRepo) GetEntries(context.Context, uuid.UUID) ([]*models.DictionaryEntry, error) {
+	return []*models.DictionaryEntry{}, nil
+}
+
+func (f *fakeDocumentsRepo) GetUserCorpus(context.Context, uuid.UUID, []uuid.UUID) (*models.ReferenceCorpus, error) {
+	return &models.ReferenceCorpus{}, nil
+}
+
+func (f *fakeDocumentsRepo) Create(
+	_ context.Context, document *models.Document, _ []*models.DictionaryEntry,
+) (*models.Document, error) {
+	document.DocumentID = uuid.New()
+	f.documents[document.DocumentID] = document
+
+	return document, nil
+}
+
+func (f *fakeDocumentsRepo) SaveContent(_ context.Context, content string) (uuid.UUID, error) {
+	contentID := uuid.New()
+	f.contents[contentID] = content
+
+	return contentID, nil
+}
+
+func (f *fakeDocumentsRepo) GetContent(_ context.Context, contentID uuid.UUID) (string, error) {
+	content, ok := f.contents[contentID]
+	if !ok {
+		return "", sql.ErrNoRows
+	}
+
+	return content, nil
+}
+
+func (f *fakeDocumentsRepo) DeleteContent(_ context.Context, contentID uuid.UUID) error {
+	delete(f.contents, contentID)
+
+	return nil
+}
+
+// Queue keeping the last enqueued job
+type fakeJobsUC struct {
+	jobs.UseCase
+	job *models.Job
+}
+
+func (f *fakeJobsUC) Enqueue(
+	_ context.Context, kind string, userID *uuid.UUID, payload interface{},
+) (*models.Job, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	f.job = &models.Job{JobID: uuid.New(), Kind: kind, UserID: userID, Payload: body}
+
+	return f.job, nil
+}
+
+type fakeWordsUC struct {
+	words.UseCase
+}
+
+func (fakeWordsUC) ApplyStates(
+	_ context.Context, _ uuid.UUID, entries []*models.DictionaryEntry, _ bool,
+) ([]*models.DictionaryEntry, error) {
+	return entries, nil
+}
+
+type fakeTranslator struct{}
+
+func (fakeTranslator) Name() string {
+	return "fake"
+}
+
+func (fakeTranslator) Lookup(context.Context, string) (*models.Translation, error) {
+	return nil, translation.ErrNotFound
+}
+
+func TestGetByIDOwnership(t *testing.T) {
+	owner := &models.User{UserID: uuid.New()}
+	stranger := &models.User{UserID: uuid.New()}
+	owned := &models.Document{DocumentID: uuid.New(), UserID: &owner.UserID, Title: "Owned", WordCount: 10}
+	anonymous := &models.Document{DocumentID: uuid.New(), Title: "Anonymous", WordCount: 10}
+
+	repo := &fakeDocumentsRepo{documents: map[uuid.UUID]*models.Document{
+		owned.DocumentID: owned, anonymous.DocumentID: anonymous,
+	}, contents: map[uuid.UUID]string{}}
+	uc := documentsUseCase.NewDocumentsUseCase(&config.Config{}, repo, nil, fakeTranslator{}, fakeWordsUC{}, nil)
+	h := NewDocumentsHandlers(&config.Config{}, uc)
+
+	tests := []struct {
+		name     string
+		user     *models.User
+		document uuid.UUID
+		route    string
+		status   int
+	}{
+		{name: "owner", user: owner, document: owned.DocumentID, status: http.StatusOK},
+		{name: "other user", user: stranger, document: owned.DocumentID, status: http.StatusNotFound},
+		{name: "anonymous caller", document: owned.DocumentID, status: http.StatusNotFound},
+		{name: "anonymous document", user: stranger, document: anonymous.DocumentID, status: http.StatusOK},
+		{name: "anonymous document and caller", document: anonymous.DocumentID, status: http.StatusOK},
+		{name: "unknown document", user: owner, document: uuid.New(), status: http.StatusNotFound},
+		{name: "export by owner", user: owner, document: owned.DocumentID, route: "/dictionary",
+			status: http.StatusOK},
+		{name: "export by other user", user: stranger, document: owned.DocumentID, route: "/dictionary",
+			status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			handler := h.GetByID()
+			if tt.route != "" {
+				handler = h.Export()
+			}
+			e.GET("/documents/:document_id"+tt.route, handler, func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					if tt.user != nil {
+						ctx := context.WithValue(c.Request().Context(), utils.UserCtxKey{}, tt.user)
+						c.SetRequest(c.Request().WithContext(ctx))
+					}
+					return next(c)
+				}
+			})
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/documents/"+tt.document.String()+tt.route, nil))
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if tt.status == http.StatusNotFound && strings.Contains(rec.Body.String(), "Owned") {
+				t.Errorf("response of a foreign document has its content: %s", rec.Body)
+			}
+		})
+	}
+}
+
+func TestCreateStoresContentApart(t *testing.T) {
+	const text = "Workers claim queued jobs and process documents in the background."
+
+	repo := &fakeDocumentsRepo{documents: map[uuid.UUID]*models.Document{}, contents: map[uuid.UUID]string{}}
+	jobsUC := &fakeJobsUC{}
+	cfg := &config.Config{}
+	cfg.Documents.MaxExamples = 1
+	uc := documentsUseCase.NewDocumentsUseCase(cfg, repo, nil, fakeTranslator{}, fakeWordsUC{}, jobsUC)
+	h := NewDocumentsHandlers(cfg, uc)
+
+	e := echo.New()
+	e.POST("/documents", h.Create())
+	req := httptest.NewRequest(http.MethodPost, "/documents", strings.NewReader(`{"text":"`+text+`"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusAccepted, rec.Body)
+	}
+	if strings.Contains(string(jobsUC.job.Payload), "Workers") {
+		t.Errorf("job payload has the content: %s", jobsUC.job.Payload)
+	}
+	if len(repo.contents) != 1 {
+		t.Fatalf("stored contents = %d, want 1", len(repo.contents))
+	}
+
+	result, err := uc.Process(context.Background(), jobsUC.job)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if document := result.(*models.Document); document.Content != text {
+		t.Errorf("Process() content = %q, want %q", document.Content, text)
+	}
+	if len(repo.contents) != 0 {
+		t.Errorf("stored contents = %d after processing, want 0", len(repo.contents))
+	}
+}
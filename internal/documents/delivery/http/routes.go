@@ -0,0 +1,22 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+
+	"github.com/shlembo598/text-lexicon-go/internal/auth"
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/documents"
+	"github.com/shlembo598/text-lexicon-go/internal/middleware"
+)
+
+func MapDocumentsRoutes(
+	documentsGroup *echo.Group, h documents.Handlers, mw *middleware.MiddlewareManager, authUC auth.UseCase,
+	cfg *config.Config,
+) {
+	documentsGroup.Use(mw.OptionalAuthJWTMiddleware(authUC, cfg))
+	documentsGroup.POST("", h.Create())
+	documentsGroup.POST("/upload", h.Upload(), echoMiddleware.BodyLimit(cfg.Documents.MaxUploadSize))
+	documentsGroup.GET("/:document_id", h.GetByID())
+	documentsGroup.GET("/:document_id/dictionary", h.Export())
+}
@@ -0,0 +1,12 @@
+package documents
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+type Handlers interface {
+	Create() echo.HandlerFunc
+	Upload() echo.HandlerFunc
+	GetByID() echo.HandlerFunc
+	Export() echo.HandlerFunc
+}
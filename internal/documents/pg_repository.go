@@ -0,0 +1,23 @@
+package documents
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+type Repository interface {
+	Create(ctx context.Context, document *models.Document, entries []*models.DictionaryEntry) (*models.Document, error)
+	// Store content of a queued document apart from the job payload until the document is saved
+	SaveContent(ctx context.Context, content string) (uuid.UUID, error)
+	GetContent(ctx context.Context, contentID uuid.UUID) (string, error)
+	DeleteContent(ctx context.Context, contentID uuid.UUID) error
+	GetByID(ctx context.Context, documentID uuid.UUID) (*models.Document, error)
+	GetEntries(ctx context.Context, documentID uuid.UUID) ([]*models.DictionaryEntry, error)
+	// Word counts of documents of the user other than the given ones, only words of the given documents are counted
+	GetUserCorpus(
+		ctx context.Context, userID uuid.UUID, documentIDs []uuid.UUID,
+	) (*models.ReferenceCorpus, error)
+}
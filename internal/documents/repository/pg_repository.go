@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/shlembo598/text-lexicon-go/internal/documents"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+// Postgres caps a statement at 65535 bind parameters, so entries are inserted in chunks
+const entriesChunkSize = 1000
+
+type documentsRepo struct {
+	db *sqlx.DB
+}
+
+func NewDocumentsRepository(db *sqlx.DB) documents.Repository {
+	return &documentsRepo{db: db}
+}
+
+// Create document with its word list
+func (r *documentsRepo) Create(
+	ctx context.Context, document *models.Document, entries []*models.DictionaryEntry,
+) (*models.Document, error) {
+	const op = "documents.pg_repository.create"
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s.BeginTxx: %w", op, err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	query, args, err := createDocumentQuery(document)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	d := &models.Document{}
+	if err = tx.QueryRowxContext(ctx, query, args...).StructScan(d); err != nil {
+		return nil, fmt.Errorf("%s.StructScan: %w", op, err)
+	}
+
+	for start := 0; start < len(entries); start += entriesChunkSize {
+		end := min(start+entriesChunkSize, len(entries))
+
+		query, args, err = createEntriesQuery(d.DocumentID, entries[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("%s.entriesQuery: %w", op, err)
+		}
+
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return nil, fmt.Errorf("%s.ExecContext: %w", op, err)
+		}
+
+		if !hasForms(entries[start:end]) {
+			continue
+		}
+
+		query, args, err = createFormsQuery(d.DocumentID, entries[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("%s.formsQuery: %w", op, err)
+		}
+
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return nil, fmt.Errorf("%s.ExecContext: %w", op, err)
+		}
+	}
+
+	// Examples go after all words they reference, chunks are limited by examples count
+	for _, chunk := range exampleChunks(entries) {
+		query, args, err = createExamplesQuery(d.DocumentID, chunk)
+		if err != nil {
+			return nil, fmt.Errorf("%s.examplesQuery: %w", op, err)
+		}
+
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return nil, fmt.Errorf("%s.ExecContext: %w", op, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s.Commit: %w", op, err)
+	}
+
+	return d, nil
+}
+
+// Store content of a queued document until the document is saved, returns id of the content
+func (r *documentsRepo) SaveContent(ctx context.Context, content string) (uuid.UUID, error) {
+	const op = "documents.pg_repository.saveContent"
+
+	query, args, err := saveContentQuery(content)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	var contentID uuid.UUID
+	if err = r.db.GetContext(ctx, &contentID, query, args...); err != nil {
+		return uuid.Nil, fmt.Errorf("%s.GetContext: %w", op, err)
+	}
+
+	return contentID, nil
+}
+
+// Get stored content of a queued document
+func (r *documentsRepo) GetContent(ctx context.Context, contentID uuid.UUID) (string, error) {
+	const op = "documents.pg_repository.getContent"
+
+	query, args, err := getContentQuery(contentID)
+	if err != nil {
+		return "", fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	var content string
+	if err = r.db.GetContext(ctx, &content, query, args...); err != nil {
+		return "", fmt.Errorf("%s.GetContext: %w", op, err)
+	}
+
+	return content, nil
+}
+
+// Delete stored content of a queued document
+func (r *documentsRepo) DeleteContent(ctx context.Context, contentID uuid.UUID) error {
+	const op = "documents.pg_repository.deleteContent"
+
+	query, args, err := deleteContentQuery(contentID)
+	if err != nil {
+		return fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	if _, err = r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%s.ExecContext: %w", op, err)
+	}
+
+	return nil
+}
+
+// Get document by id
+func (r *documentsRepo) GetByID(ctx context.Context, documentID uuid.UUID) (*models.Document, error) {
+	const op = "documents.pg_repository.getByID"
+
+	query, args, err := getDocumentQuery(documentID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	document := &models.Document{}
+	if err = r.db.GetContext(ctx, document, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.GetContext: %w", op, err)
+	}
+
+	return document, nil
+}
+
+// Get document word list ordered by frequency
+func (r *documentsRepo) GetEntries(ctx context.Context, documentID uuid.UUID) ([]*models.DictionaryEntry, error) {
+	const op = "documents.pg_repository.getEntries"
+
+	query, args, err := getEntriesQuery(documentID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	entries := make([]*models.DictionaryEntry, 0)
+	if err = r.db.SelectContext(ctx, &entries, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.SelectContext: %w", op, err)
+	}
+
+	query, args, err = getFormsQuery(documentID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.formsQuery: %w", op, err)
+	}
+
+	forms := make([]*models.WordForm, 0)
+	if err = r.db.SelectContext(ctx, &forms, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.SelectContext: %w", op, err)
+	}
+
+	query, args, err = getExamplesQuery(documentID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.examplesQuery: %w", op, err)
+	}
+
+	examples := make([]*models.WordExample, 0)
+	if err = r.db.SelectContext(ctx, &examples, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.SelectContext: %w", op, err)
+	}
+
+	byWord := make(map[string]*models.DictionaryEntry, len(entries))
+	for _, entry := range entries {
+		entry.Forms = make([]string, 0)
+		byWord[entry.Word] = entry
+	}
+	for _, form := range forms {
+		if entry, ok := byWord[form.Word]; ok {
+			entry.Forms = append(entry.Forms, form.Form)
+		}
+	}
+	for _, example := range examples {
+		if entry, ok := byWord[example.Word]; ok {
+			entry.Examples = append(entry.Examples, example)
+		}
+	}
+
+	return entries, nil
+}
+
+// Get counts of words of the given documents in other documents of the user
+func (r *documentsRepo) GetUserCorpus(
+	ctx context.Context, userID uuid.UUID, documentIDs []uuid.UUID,
+) (*models.ReferenceCorpus, error) {
+	const op = "documents.pg_repository.getUserCorpus"
+
+	query, args, err := getCorpusTotalQuery(userID, documentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s.totalQuery: %w", op, err)
+	}
+
+	corpus := &models.ReferenceCorpus{Counts: make(map[string]int)}
+	if err = r.db.GetContext(ctx, &corpus.Total, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.GetContext: %w", op, err)
+	}
+	if corpus.Total == 0 {
+		return corpus, nil
+	}
+
+	query, args, err = getCorpusCountsQuery(userID, documentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s.countsQuery: %w", op, err)
+	}
+
+	counts := make([]*models.DictionaryEntry, 0)
+	if err = r.db.SelectContext(ctx, &counts, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.SelectContext: %w", op, err)
+	}
+	for _, count := range counts {
+		corpus.Counts[count.Word] = count.Frequency
+	}
+
+	return corpus, nil
+}
+
+// Split entries with examples into chunks of at most entriesChunkSize examples
+func exampleChunks(entries []*models.DictionaryEntry) [][]*models.DictionaryEntry {
+	chunks := make([][]*models.DictionaryEntry, 0)
+	chunk := make([]*models.DictionaryEntry, 0)
+	count := 0
+
+	for _, entry := range entries {
+		if len(entry.Examples) == 0 {
+			continue
+		}
+		if count+len(entry.Examples) > entriesChunkSize && len(chunk) > 0 {
+			chunks = append(chunks, chunk)
+			chunk, count = make([]*models.DictionaryEntry, 0), 0
+		}
+		chunk = append(chunk, entry)
+		count += len(entry.Examples)
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks
+}
+
+func hasForms(entries []*models.DictionaryEntry) bool {
+	for _, entry := range entries {
+		if len(entry.Forms) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+func createDocumentQuery(document *models.Document) (string, []interface{}, error) {
+	return sq.Insert("documents").Columns(
+		"user_id", "source_url", "source_path", "title", "content", "code_mode", "word_count", "level", "difficulty",
+		"created_at",
+	).Values(
+		document.UserID, document.SourceURL, document.SourcePath, document.Title, document.Content, document.CodeMode,
+		document.WordCount, document.Level, document.Difficulty, time.Now(),
+	).Suffix("RETURNING *").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func createEntriesQuery(documentID uuid.UUID, entries []*models.DictionaryEntry) (string, []interface{}, error) {
+	query := sq.Insert("document_words").Columns("document_id", "word", "kind", "frequency", "from_code", "expansion")
+	for _, entry := range entries {
+		query = query.Values(documentID, entry.Word, entry.Kind, entry.Frequency, entry.FromCode, entry.Expansion)
+	}
+
+	return query.PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func createFormsQuery(documentID uuid.UUID, entries []*models.DictionaryEntry) (string, []interface{}, error) {
+	query := sq.Insert("document_word_forms").Columns("document_id", "word", "form", "position")
+	for _, entry := range entries {
+		for position, form := range entry.Forms {
+			query = query.Values(documentID, entry.Word, form, position)
+		}
+	}
+
+	return query.PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func createExamplesQuery(documentID uuid.UUID, entries []*models.DictionaryEntry) (string, []interface{}, error) {
+	query := sq.Insert("document_word_examples").Columns(
+		"document_id", "word", "position", "text", "start_offset", "end_offset", "heading", "anchor", "location",
+	)
+	for _, entry := range entries {
+		for position, example := range entry.Examples {
+			query = query.Values(
+				documentID, entry.Word, position, example.Text, example.Start, example.End, example.Heading,
+				example.Anchor, example.Location,
+			)
+		}
+	}
+
+	return query.PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func saveContentQuery(content string) (string, []interface{}, error) {
+	return sq.Insert("queued_document_contents").Columns("content").Values(
+		content,
+	).Suffix("RETURNING content_id").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getContentQuery(contentID uuid.UUID) (string, []interface{}, error) {
+	return sq.Select("content").From("queued_document_contents").Where(
+		"content_id = ?", contentID,
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func deleteContentQuery(contentID uuid.UUID) (string, []interface{}, error) {
+	return sq.Delete("queued_document_contents").Where(
+		"content_id = ?", contentID,
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getDocumentQuery(documentID uuid.UUID) (string, []interface{}, error) {
+	return sq.Select(
+		"document_id", "user_id", "source_url", "source_path", "title", "content", "code_mode", "word_count",
+		"level", "difficulty", "created_at",
+	).From("documents").Where("document_id = ?", documentID).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getEntriesQuery(documentID uuid.UUID) (string, []interface{}, error) {
+	return sq.Select("word", "kind", "frequency", "from_code", "expansion").From("document_words").Where(
+		"document_id = ?", documentID,
+	).OrderBy("frequency DESC", "word").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getCorpusCountsQuery(userID uuid.UUID, documentIDs []uuid.UUID) (string, []interface{}, error) {
+	words := sq.Select("word").From("document_words").Where(sq.Eq{"document_id": documentIDs})
+
+	return sq.Select("dw.word", "SUM(dw.frequency) AS frequency").From("document_words dw").Join(
+		"documents d ON d.document_id = dw.document_id",
+	).Where(sq.Eq{"d.user_id": userID}).Where(sq.NotEq{"d.document_id": documentIDs}).Where(
+		sq.Expr("dw.word IN (?)", words),
+	).GroupBy("dw.word").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getCorpusTotalQuery(userID uuid.UUID, documentIDs []uuid.UUID) (string, []interface{}, error) {
+	return sq.Select("COALESCE(SUM(word_count), 0)").From("documents").Where(sq.Eq{"user_id": userID}).Where(
+		sq.NotEq{"document_id": documentIDs},
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getFormsQuery(documentID uuid.UUID) (string, []interface{}, error) {
+	return sq.Select("word", "form").From("document_word_forms").Where(
+		"document_id = ?", documentID,
+	).OrderBy("word", "position").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getExamplesQuery(documentID uuid.UUID) (string, []interface{}, error) {
+	return sq.Select("word", "text", "start_offset", "end_offset", "heading", "anchor", "location").From(
+		"document_word_examples",
+	).Where("document_id = ?", documentID).OrderBy("word", "position").PlaceholderFormat(sq.Dollar).ToSql()
+}
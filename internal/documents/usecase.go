@@ -0,0 +1,38 @@
+package documents
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/pkg/fetcher"
+)
+
+type UseCase interface {
+	// Queue document for processing, returns the job building its dictionary
+	Create(ctx context.Context, document *models.Document) (*models.Job, error)
+	// Parse markup file and queue it as a document
+	CreateFromFile(ctx context.Context, document *models.Document, fileName string, body []byte) (*models.Job, error)
+	// Build dictionary of a queued document, handler of document jobs
+	Process(ctx context.Context, job *models.Job) (interface{}, error)
+	// Save fetched page as a document with its dictionary
+	CreateFromPage(ctx context.Context, document *models.Document, page *fetcher.Page) (*models.Document, error)
+	// Save file of an imported directory or archive as a document, returns the document with its entries
+	Import(ctx context.Context, document *models.Document, body []byte) (*models.Dictionary, error)
+	GetByID(ctx context.Context, documentID uuid.UUID, params *models.DictionaryParams) (*models.Dictionary, error)
+	Export(
+		ctx context.Context, documentID uuid.UUID, params *models.DictionaryParams, format string,
+	) (*models.DictionaryExport, error)
+	// Find collocations significant across documents, entries list the documents using them
+	FindCollocations(ctx context.Context, documents []*models.Document) ([]*models.DictionaryEntry, error)
+	// Share of words above the level of the caller, nil for callers without a level
+	AboveLevelShare(ctx context.Context, entries []*models.DictionaryEntry) *float64
+	// Score keyness of entries of documents with wordCount words in total, sets keyness of each entry
+	ScoreKeyness(ctx context.Context, entries []*models.DictionaryEntry, documentIDs []uuid.UUID, wordCount int) error
+	// Apply word state, frequency and part of speech filters of the caller to entries and translate them,
+	// names and acronyms are returned separately
+	FilterEntries(
+		ctx context.Context, entries []*models.DictionaryEntry, params *models.DictionaryParams,
+	) (*models.FilteredEntries, error)
+}
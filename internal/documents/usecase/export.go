@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/pkg/anki"
+	"github.com/shlembo598/text-lexicon-go/pkg/tokenizer"
+)
+
+var exportFormats = map[string]bool{
+	models.ExportFormatCSV:      true,
+	models.ExportFormatTSV:      true,
+	models.ExportFormatMarkdown: true,
+	models.ExportFormatAnki:     true,
+}
+
+// Columns of csv and tsv exports
+var exportColumns = []string{
+	"word", "transcription", "part_of_speech", "translations", "frequency", "level", "keyness", "forms", "example",
+	"state",
+}
+
+// Write dictionary in the given format, the file is named after the document title
+func exportDictionary(
+	ctx context.Context, dictionary *models.Dictionary, format string,
+) (*models.DictionaryExport, error) {
+	name := tokenizer.Anchor(dictionary.Document.Title)
+	if name == "" {
+		name = "dictionary-" + dictionary.Document.DocumentID.String()
+	}
+	export := &models.DictionaryExport{FileName: name + "." + format}
+
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case models.ExportFormatCSV:
+		export.ContentType = "text/csv; charset=utf-8"
+		err = writeSeparated(&buf, dictionary.Entries, ',')
+	case models.ExportFormatTSV:
+		export.ContentType = "text/tab-separated-values; charset=utf-8"
+		err = writeSeparated(&buf, dictionary.Entries, '\t')
+	case models.ExportFormatMarkdown:
+		export.ContentType = "text/markdown; charset=utf-8"
+		writeMarkdown(&buf, dictionary)
+	case models.ExportFormatAnki:
+		export.ContentType = "application/apkg"
+		err = anki.Write(ctx, &buf, ankiDeck(dictionary))
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownExportFormat, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	export.Body = buf.Bytes()
+
+	return export, nil
+}
+
+func writeSeparated(buf *bytes.Buffer, entries []*models.DictionaryEntry, separator rune) error {
+	w := csv.NewWriter(buf)
+	w.Comma = separator
+
+	if err := w.Write(exportColumns); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		record := []string{
+			entry.Word,
+			transcription(entry),
+			strings.Join(partsOfSpeech(entry), ", "),
+			strings.Join(translations(entry), "; "),
+			strconv.Itoa(entry.Frequency),
+			entry.Level,
+			keynessScore(entry),
+			strings.Join(entry.Forms, ", "),
+			firstExample(entry),
+			entry.State,
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+
+	return w.Error()
+}
+
+// Keyness score rounded to two decimals, empty for entries without keyness
+func keynessScore(entry *models.DictionaryEntry) string {
+	if entry.Keyness == nil {
+		return ""
+	}
+
+	return strconv.FormatFloat(entry.Keyness.Score, 'f', 2, 64)
+}
+
+func writeMarkdown(buf *bytes.Buffer, dictionary *models.Dictionary) {
+	if title := dictionary.Document.Title; title != "" {
+		fmt.Fprintf(buf, "# %s\n\n", strings.Join(strings.Fields(title), " "))
+	}
+
+	buf.WriteString("| Word | Transcription | Part of speech | Translations | Frequency | Example |\n")
+	buf.WriteString("| --- | --- | --- | --- | ---: | --- |\n")
+	for _, entry := range dictionary.Entries {
+		fmt.Fprintf(
+			buf, "| %s | %s | %s | %s | %d | %s |\n",
+			markdownCell(entry.Word),
+			markdownCell(transcription(entry)),
+			markdownCell(strings.Join(partsOfSpeech(entry), ", ")),
+			markdownCell(strings.Join(translations(entry), "; ")),
+			entry.Frequency,
+			markdownCell(firstExample(entry)),
+		)
+	}
+}
+
+// Table cell on one line with escaped pipes
+func markdownCell(s string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(s), " "), "|", `\|`)
+}
+
+// Deck with one note per entry: word on the front, transcription and translations grouped by part of speech
+// on the back and a sentence of the document as an example
+func ankiDeck(dictionary *models.Dictionary) *anki.Deck {
+	deck := &anki.Deck{Name: dictionary.Document.Title, Notes: make([]anki.Note, 0, len(dictionary.Entries))}
+	if deck.Name == "" {
+		deck.Name = "Text Lexicon"
+	}
+
+	for _, entry := range dictionary.Entries {
+		note := anki.Note{Front: html.EscapeString(entry.Word), Example: html.EscapeString(firstExample(entry))}
+
+		if entry.Translation != nil {
+			lines := make([]string, 0, len(entry.Translation.Senses)+1)
+			if t := entry.Translation.Transcription; t != "" {
+				lines = append(lines, "["+html.EscapeString(t)+"]")
+			}
+			for _, sense := range entry.Translation.Senses {
+				line := html.EscapeString(strings.Join(sense.Translations, ", "))
+				if sense.PartOfSpeech != "" {
+					line = "<i>" + sense.PartOfSpeech + "</i> " + line
+				}
+				lines = append(lines, line)
+			}
+			note.Back = strings.Join(lines, "<br>")
+		}
+
+		if entry.State != "" {
+			note.Tags = append(note.Tags, entry.State)
+		}
+		deck.Notes = append(deck.Notes, note)
+	}
+
+	return deck
+}
+
+func transcription(entry *models.DictionaryEntry) string {
+	if entry.Translation == nil {
+		return ""
+	}
+
+	return entry.Translation.Transcription
+}
+
+// Distinct parts of speech of entry translations
+func partsOfSpeech(entry *models.DictionaryEntry) []string {
+	result := make([]string, 0)
+	if entry.Translation == nil {
+		return result
+	}
+
+	seen := make(map[string]bool)
+	for _, sense := range entry.Translation.Senses {
+		if sense.PartOfSpeech != "" && !seen[sense.PartOfSpeech] {
+			seen[sense.PartOfSpeech] = true
+			result = append(result, sense.PartOfSpeech)
+		}
+	}
+
+	return result
+}
+
+func translations(entry *models.DictionaryEntry) []string {
+	result := make([]string, 0)
+	if entry.Translation == nil {
+		return result
+	}
+
+	for _, sense := range entry.Translation.Senses {
+		result = append(result, sense.Translations...)
+	}
+
+	return result
+}
+
+func firstExample(entry *models.DictionaryEntry) string {
+	if len(entry.Examples) == 0 {
+		return ""
+	}
+
+	return entry.Examples[0].Text
+}
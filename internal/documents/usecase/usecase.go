@@ -0,0 +1,1057 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/documents"
+	"github.com/shlembo598/text-lexicon-go/internal/jobs"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/internal/translation"
+	"github.com/shlembo598/text-lexicon-go/internal/words"
+	"github.com/shlembo598/text-lexicon-go/pkg/acronyms"
+	"github.com/shlembo598/text-lexicon-go/pkg/cefr"
+	"github.com/shlembo598/text-lexicon-go/pkg/dictfile"
+	"github.com/shlembo598/text-lexicon-go/pkg/docfile"
+	"github.com/shlembo598/text-lexicon-go/pkg/extractor"
+	"github.com/shlembo598/text-lexicon-go/pkg/fetcher"
+	"github.com/shlembo598/text-lexicon-go/pkg/godoc"
+	"github.com/shlembo598/text-lexicon-go/pkg/keyness"
+	"github.com/shlembo598/text-lexicon-go/pkg/logger/sl"
+	"github.com/shlembo598/text-lexicon-go/pkg/markup"
+	"github.com/shlembo598/text-lexicon-go/pkg/names"
+	"github.com/shlembo598/text-lexicon-go/pkg/openapi"
+	"github.com/shlembo598/text-lexicon-go/pkg/phrases"
+	"github.com/shlembo598/text-lexicon-go/pkg/subtitles"
+	"github.com/shlembo598/text-lexicon-go/pkg/textnorm"
+	"github.com/shlembo598/text-lexicon-go/pkg/tokenizer"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils/httpErrors"
+)
+
+const (
+	// Longest word kept in a dictionary, longer tokens are usually hashes or encoded data
+	maxWordLength = 64
+	// Longest sentence used as an example in bytes, longer ones are usually tables or lists without punctuation
+	maxExampleLength = 500
+	// Longest phrase kept in a dictionary, the size of the word column
+	maxPhraseLength = 128
+	// Surface forms kept for a phrase, phrasal verbs with objects have too many of them
+	maxPhraseForms = 10
+	// Longest location of an example, the size of the location column
+	maxLocationLength = 64
+	// Size of the general english corpus the frequency list stands for, the british national corpus size
+	generalCorpusSize = 100_000_000
+)
+
+var (
+	ErrEmptyDocument       = errors.New("document has no text")
+	ErrUnknownPartOfSpeech = errors.New("unknown part of speech")
+	ErrUnknownExportFormat = errors.New("unknown export format")
+	ErrUnknownLevel        = errors.New("unknown CEFR level")
+	ErrLevelNotSet         = errors.New("level is not set in the user profile")
+	ErrUnknownSort         = errors.New("unknown sort order")
+	ErrUnknownNamesMode    = errors.New("names must be separate or drop")
+	ErrUnsupportedFile     = errors.New(
+		"unsupported file type, expected .md, .rst, .adoc, .pdf, .epub, .srt, .vtt, .go or OpenAPI .json or .yaml",
+	)
+	ErrFileTooLarge = errors.New("file is too large")
+	ErrNoSourcePath = errors.New("imported document has no source path")
+)
+
+type documentsUC struct {
+	cfg           *config.Config
+	documentsRepo documents.Repository
+	fetcher       fetcher.Fetcher
+	translator    translation.Provider
+	wordsUC       words.UseCase
+	jobsUC        jobs.UseCase
+}
+
+func NewDocumentsUseCase(
+	cfg *config.Config, documentsRepo documents.Repository, fetcher fetcher.Fetcher, translator translation.Provider,
+	wordsUC words.UseCase, jobsUC jobs.UseCase,
+) documents.UseCase {
+	return &documentsUC{
+		cfg: cfg, documentsRepo: documentsRepo, fetcher: fetcher, translator: translator, wordsUC: wordsUC,
+		jobsUC: jobsUC,
+	}
+}
+
+// Document waiting for processing, raw text is stored apart and the payload holds its id only
+type documentPayload struct {
+	UserID    *uuid.UUID `json:"user_id,omitempty"`
+	SourceURL *string    `json:"source_url,omitempty"`
+	Title     string     `json:"title"`
+	ContentID *uuid.UUID `json:"content_id,omitempty"`
+	CodeMode  string     `json:"code_mode"`
+	// Pages or chapters of documents from PDF and EPUB files
+	Locations []extractor.Location `json:"locations,omitempty"`
+}
+
+// Queue document from url or raw text for processing, the dictionary is built by a job worker.
+// Typography of raw text and titles is normalized as the one of fetched pages.
+func (u *documentsUC) Create(ctx context.Context, document *models.Document) (*models.Job, error) {
+	document.Title = textnorm.Normalize(document.Title)
+	document.Content = textnorm.Normalize(document.Content)
+
+	return u.enqueue(ctx, document, nil)
+}
+
+// Queue document with pages or chapters of its content
+func (u *documentsUC) enqueue(
+	ctx context.Context, document *models.Document, locations []extractor.Location,
+) (*models.Job, error) {
+	const op = "documents.useCase.enqueue"
+
+	if user, err := utils.GetUserFromCtx(ctx); err == nil {
+		document.UserID = &user.UserID
+	}
+
+	if err := document.PrepareCreate(); err != nil {
+		return nil, httpErrors.NewBadRequestError(fmt.Errorf("%s.PrepareCreate: %w", op, err))
+	}
+
+	payload := &documentPayload{
+		UserID:    document.UserID,
+		SourceURL: document.SourceURL,
+		Title:     document.Title,
+		CodeMode:  document.CodeMode,
+		Locations: locations,
+	}
+	if document.SourceURL == nil {
+		contentID, err := u.documentsRepo.SaveContent(ctx, document.Content)
+		if err != nil {
+			return nil, fmt.Errorf("%s.SaveContent: %w", op, err)
+		}
+		payload.ContentID = &contentID
+	}
+
+	return u.jobsUC.Enqueue(ctx, models.JobKindCreateDocument, document.UserID, payload)
+}
+
+// Parse markdown, reStructuredText, AsciiDoc, PDF or EPUB file into text structured as extracted html pages
+// and queue it, the document title or the first top level heading is the title of documents without one.
+// Pages of PDF and chapters of EPUB files are kept as locations of examples.
+func (u *documentsUC) CreateFromFile(
+	ctx context.Context, document *models.Document, fileName string, body []byte,
+) (*models.Job, error) {
+	const op = "documents.useCase.createFromFile"
+
+	article, err := parseFile(fileName, "", body)
+	if err != nil {
+		return nil, httpErrors.NewBadRequestError(fmt.Errorf("%s.parseFile: %w", op, err))
+	}
+	article.Normalize()
+	if document.Title == "" {
+		document.Title = article.Title
+	}
+	document.Content = article.Text()
+
+	// Binary files are larger than their text, the limit is checked for the text
+	if int64(len(document.Content)) > u.cfg.Documents.MaxDocumentSize {
+		return nil, httpErrors.NewBadRequestError(fmt.Errorf("%s: %w", op, ErrFileTooLarge))
+	}
+
+	return u.enqueue(ctx, document, article.Locations())
+}
+
+// Fetch document, count its words and save the dictionary, returns created document.
+// Documents which can not be processed on retry fail permanently.
+func (u *documentsUC) Process(ctx context.Context, job *models.Job) (interface{}, error) {
+	const op = "documents.useCase.process"
+
+	payload := &documentPayload{}
+	if err := json.Unmarshal(job.Payload, payload); err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("%s.Unmarshal: %w", op, err))
+	}
+
+	document := &models.Document{
+		UserID:    payload.UserID,
+		SourceURL: payload.SourceURL,
+		Title:     payload.Title,
+		CodeMode:  payload.CodeMode,
+	}
+
+	if document.SourceURL == nil {
+		return u.saveQueued(ctx, document, payload)
+	}
+
+	page, err := u.fetcher.Fetch(ctx, *document.SourceURL)
+	if err != nil {
+		if errors.Is(err, fetcher.ErrTooLarge) {
+			err = jobs.Permanent(err)
+		}
+		return nil, fmt.Errorf("%s.Fetch: %w", op, err)
+	}
+
+	return u.CreateFromPage(ctx, document, page)
+}
+
+// Save raw text of a queued document, its stored content is deleted once the document is saved
+func (u *documentsUC) saveQueued(
+	ctx context.Context, document *models.Document, payload *documentPayload,
+) (*models.Document, error) {
+	const op = "documents.useCase.saveQueued"
+
+	if payload.ContentID == nil {
+		return nil, jobs.Permanent(fmt.Errorf("%s: %w", op, ErrEmptyDocument))
+	}
+	content, err := u.documentsRepo.GetContent(ctx, *payload.ContentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = jobs.Permanent(err)
+		}
+		return nil, fmt.Errorf("%s.GetContent: %w", op, err)
+	}
+	document.Content = content
+
+	created, err := u.save(ctx, document, payload.Locations)
+	if err != nil {
+		return nil, err
+	}
+
+	// A content left behind is not worth retrying the job and saving the document again
+	if err = u.documentsRepo.DeleteContent(ctx, *payload.ContentID); err != nil {
+		slog.Warn("queued content not deleted", slog.String("content_id", payload.ContentID.String()), sl.Err(err))
+	}
+
+	return created, nil
+}
+
+// Extract text of a fetched page and save it as a document, the page url is the source of documents
+// without one. Pages without text fail permanently.
+func (u *documentsUC) CreateFromPage(
+	ctx context.Context, document *models.Document, page *fetcher.Page,
+) (*models.Document, error) {
+	const op = "documents.useCase.createFromPage"
+
+	article, err := pageArticle(page)
+	if err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("%s.pageArticle: %w", op, err))
+	}
+	article.Normalize()
+	if document.Title == "" {
+		document.Title = article.Title
+	}
+	if document.SourceURL == nil {
+		document.SourceURL = &page.URL
+	}
+	document.Content = article.Text()
+
+	if err = document.PrepareCreate(); err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("%s.PrepareCreate: %w", op, err))
+	}
+
+	return u.save(ctx, document, article.Locations())
+}
+
+// Parse file of an imported directory or archive and save it as a document right away, the path of the
+// document is the file name. Files of other formats are taken as plain text. Files without text fail permanently.
+func (u *documentsUC) Import(
+	ctx context.Context, document *models.Document, body []byte,
+) (*models.Dictionary, error) {
+	const op = "documents.useCase.import"
+
+	if document.SourcePath == nil {
+		return nil, jobs.Permanent(fmt.Errorf("%s: %w", op, ErrNoSourcePath))
+	}
+
+	article, err := parseFile(*document.SourcePath, "", body)
+	if errors.Is(err, ErrUnsupportedFile) {
+		article, err = plainArticle("", body), nil
+	}
+	if err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("%s.parseFile: %w", op, err))
+	}
+	article.Normalize()
+	if document.Title == "" {
+		document.Title = article.Title
+	}
+	if document.Title == "" {
+		// File name without the extension, dot files keep their name
+		name := path.Base(*document.SourcePath)
+		if document.Title = strings.TrimSuffix(name, path.Ext(name)); document.Title == "" {
+			document.Title = name
+		}
+	}
+	document.Content = article.Text()
+
+	if int64(len(document.Content)) > u.cfg.Documents.MaxDocumentSize {
+		return nil, jobs.Permanent(fmt.Errorf("%s: %w", op, ErrFileTooLarge))
+	}
+	if err = document.PrepareCreate(); err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("%s.PrepareCreate: %w", op, err))
+	}
+
+	return u.saveDictionary(ctx, document, article.Locations())
+}
+
+// Count words of the document and save it with its dictionary, examples get pages or chapters of locations
+func (u *documentsUC) save(
+	ctx context.Context, document *models.Document, locations []extractor.Location,
+) (*models.Document, error) {
+	dictionary, err := u.saveDictionary(ctx, document, locations)
+	if err != nil {
+		return nil, err
+	}
+
+	return dictionary.Document, nil
+}
+
+// Save document with its dictionary, returns the created document with entries
+func (u *documentsUC) saveDictionary(
+	ctx context.Context, document *models.Document, locations []extractor.Location,
+) (*models.Dictionary, error) {
+	const op = "documents.useCase.saveDictionary"
+
+	entries, total := countWords(
+		document.Content, document.CodeMode, u.cfg.Documents.MaxExamples, u.phraseOptions(), u.nameOptions(),
+	)
+	if total == 0 {
+		return nil, jobs.Permanent(fmt.Errorf("%s: %w", op, ErrEmptyDocument))
+	}
+	document.WordCount = total
+	document.Level, document.Difficulty = estimateLevel(entries)
+	setExampleLocations(entries, locations)
+
+	created, err := u.documentsRepo.Create(ctx, document, entries)
+	if err != nil {
+		return nil, fmt.Errorf("%s.Create: %w", op, err)
+	}
+
+	return &models.Dictionary{Document: created, Entries: entries}, nil
+}
+
+// Estimate CEFR level and difficulty of a document from its prose words
+func estimateLevel(entries []*models.DictionaryEntry) (*string, *float64) {
+	counts := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		if entry.Kind == models.EntryKindWord && !entry.FromCode {
+			counts[entry.Word] = entry.Frequency
+		}
+	}
+	if len(counts) == 0 {
+		return nil, nil
+	}
+
+	level, difficulty := cefr.Estimate(counts)
+
+	return &level, &difficulty
+}
+
+// Get document with its dictionary
+func (u *documentsUC) GetByID(
+	ctx context.Context, documentID uuid.UUID, params *models.DictionaryParams,
+) (*models.Dictionary, error) {
+	return u.dictionary(ctx, documentID, params)
+}
+
+// Get document dictionary as a file in the given format
+func (u *documentsUC) Export(
+	ctx context.Context, documentID uuid.UUID, params *models.DictionaryParams, format string,
+) (*models.DictionaryExport, error) {
+	const op = "documents.useCase.export"
+
+	if !exportFormats[format] {
+		return nil, httpErrors.NewBadRequestError(fmt.Errorf("%s: %w: %s", op, ErrUnknownExportFormat, format))
+	}
+
+	dictionary, err := u.dictionary(ctx, documentID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	export, err := exportDictionary(ctx, dictionary, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s.exportDictionary: %w", op, err)
+	}
+
+	return export, nil
+}
+
+// Load stored dictionary of a document and apply filters of the caller
+func (u *documentsUC) dictionary(
+	ctx context.Context, documentID uuid.UUID, params *models.DictionaryParams,
+) (*models.Dictionary, error) {
+	const op = "documents.useCase.dictionary"
+
+	if err := prepareParams(ctx, params); err != nil {
+		return nil, httpErrors.NewBadRequestError(fmt.Errorf("%s.prepareParams: %w", op, err))
+	}
+
+	document, err := u.documentsRepo.GetByID(ctx, documentID)
+	if err != nil {
+		return nil, err
+	}
+	// Documents of a user are found by the user only, documents added anonymously by everyone
+	if document.UserID != nil {
+		user, err := utils.GetUserFromCtx(ctx)
+		if err != nil || user.UserID != *document.UserID {
+			return nil, sql.ErrNoRows
+		}
+	}
+
+	entries, err := u.documentsRepo.GetEntries(ctx, documentID)
+	if err != nil {
+		return nil, err
+	}
+
+	share := u.AboveLevelShare(ctx, entries)
+
+	if err = u.ScoreKeyness(ctx, entries, []uuid.UUID{documentID}, document.WordCount); err != nil {
+		return nil, err
+	}
+
+	filtered, err := u.filterEntries(ctx, entries, params)
+	if err != nil {
+		return nil, err
+	}
+	for _, list := range [][]*models.DictionaryEntry{filtered.Entries, filtered.Names, filtered.Acronyms} {
+		setExampleLinks(document, list)
+	}
+
+	return &models.Dictionary{
+		Document:        document,
+		Entries:         filtered.Entries,
+		Names:           filtered.Names,
+		Acronyms:        filtered.Acronyms,
+		AboveLevelShare: share,
+	}, nil
+}
+
+// Filter and translate dictionary entries built elsewhere the same way as document dictionaries
+func (u *documentsUC) FilterEntries(
+	ctx context.Context, entries []*models.DictionaryEntry, params *models.DictionaryParams,
+) (*models.FilteredEntries, error) {
+	const op = "documents.useCase.filterEntries"
+
+	if err := prepareParams(ctx, params); err != nil {
+		return nil, httpErrors.NewBadRequestError(fmt.Errorf("%s.prepareParams: %w", op, err))
+	}
+
+	return u.filterEntries(ctx, entries, params)
+}
+
+// Share of words above the level of the caller among words of entries, nil for callers without a level.
+// Words found only in code and phrases are not counted.
+func (u *documentsUC) AboveLevelShare(ctx context.Context, entries []*models.DictionaryEntry) *float64 {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil || user.Level == nil {
+		return nil
+	}
+	level := cefr.Index(*user.Level)
+
+	above, total := 0, 0
+	for _, entry := range entries {
+		if entry.Kind != models.EntryKindWord || entry.FromCode {
+			continue
+		}
+		total++
+		if cefr.Index(cefr.Level(entry.Word)) > level {
+			above++
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	share := float64(above) / float64(total)
+
+	return &share
+}
+
+// Score keyness of entries of the given documents with wordCount words in total against general english and,
+// for authenticated callers, against their other documents
+func (u *documentsUC) ScoreKeyness(
+	ctx context.Context, entries []*models.DictionaryEntry, documentIDs []uuid.UUID, wordCount int,
+) error {
+	if wordCount == 0 {
+		return nil
+	}
+
+	var corpus *models.ReferenceCorpus
+	if user, err := utils.GetUserFromCtx(ctx); err == nil {
+		if corpus, err = u.documentsRepo.GetUserCorpus(ctx, user.UserID, documentIDs); err != nil {
+			return err
+		}
+	}
+
+	size := float64(wordCount)
+	for _, entry := range entries {
+		frequency := float64(entry.Frequency)
+		general := cefr.PerMillion(entry.Word)
+		score := &models.Keyness{
+			TextPerMillion:    frequency / size * 1e6,
+			GeneralPerMillion: general,
+			General:           keyness.LogLikelihood(frequency, size, general*generalCorpusSize/1e6, generalCorpusSize),
+		}
+		score.Score = score.General
+
+		if corpus != nil && corpus.Total > 0 {
+			count, total := float64(corpus.Counts[entry.Word]), float64(corpus.Total)
+			personal := keyness.LogLikelihood(frequency, size, count, total)
+			perMillion := count / total * 1e6
+			score.Personal, score.PersonalPerMillion = &personal, &perMillion
+			score.Score = (score.General + personal) / 2
+		}
+
+		entry.Keyness = score
+	}
+
+	return nil
+}
+
+// Apply frequency and word state filters, move names and acronyms out of entries, filter the rest by level,
+// translate them and filter by part of speech of translations. Names are nil when dropped by params.
+func (u *documentsUC) filterEntries(
+	ctx context.Context, entries []*models.DictionaryEntry, params *models.DictionaryParams,
+) (*models.FilteredEntries, error) {
+	for _, entry := range entries {
+		entry.Level = cefr.Level(entry.Word)
+		entry.Band = cefr.Band(entry.Word)
+	}
+
+	if params != nil && params.MinFrequency > 1 {
+		entries = filter(entries, func(e *models.DictionaryEntry) bool { return e.Frequency >= params.MinFrequency })
+	}
+
+	entries, err := u.applyWordStates(ctx, entries, params)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, nameEntries := splitNames(entries)
+	if params != nil && params.Names == models.NamesDrop {
+		nameEntries = nil
+	}
+	entries, acronymEntries := splitAcronyms(entries)
+
+	if params != nil && params.MinLevel != "" {
+		minLevel := cefr.Index(params.MinLevel)
+		entries = filter(entries, func(e *models.DictionaryEntry) bool { return cefr.Index(e.Level) >= minLevel })
+	}
+
+	// Entries and acronyms share the remote lookups of the request
+	ctx = translation.WithLookupLimit(ctx, u.cfg.Translation.MaxRemoteLookups)
+	u.translate(ctx, entries)
+	u.translate(ctx, acronymEntries)
+
+	if params != nil && params.PartOfSpeech != "" {
+		entries = filter(
+			entries, func(e *models.DictionaryEntry) bool { return hasPartOfSpeech(e, params.PartOfSpeech) },
+		)
+	}
+
+	result := &models.FilteredEntries{Entries: entries, Names: nameEntries, Acronyms: acronymEntries}
+	if params != nil {
+		for _, list := range [][]*models.DictionaryEntry{result.Entries, result.Names, result.Acronyms} {
+			sortEntries(list, params.Sort)
+		}
+	}
+
+	return result, nil
+}
+
+// Split entries into vocabulary and names. Word states of the caller win over detection, so a word marked
+// as a name is always a name and a detected name marked with another state is a word. Known brands are
+// names in documents processed before names were detected.
+func splitNames(entries []*models.DictionaryEntry) ([]*models.DictionaryEntry, []*models.DictionaryEntry) {
+	words := make([]*models.DictionaryEntry, 0, len(entries))
+	nameEntries := make([]*models.DictionaryEntry, 0)
+	for _, entry := range entries {
+		isName := entry.State == models.WordStateName
+		if entry.State == "" {
+			isName = entry.Kind == models.EntryKindName ||
+				entry.Kind == models.EntryKindWord && names.IsBrand(entry.Word)
+		}
+
+		switch {
+		case isName:
+			entry.Kind = models.EntryKindName
+			nameEntries = append(nameEntries, entry)
+			continue
+		case entry.Kind == models.EntryKindName:
+			entry.Kind = models.EntryKindWord
+		}
+		words = append(words, entry)
+	}
+
+	return words, nameEntries
+}
+
+// Move acronyms out of entries and expand those not defined in the text by the list of common abbreviations
+func splitAcronyms(entries []*models.DictionaryEntry) ([]*models.DictionaryEntry, []*models.DictionaryEntry) {
+	words := make([]*models.DictionaryEntry, 0, len(entries))
+	acronymEntries := make([]*models.DictionaryEntry, 0)
+	for _, entry := range entries {
+		if entry.Kind != models.EntryKindAcronym {
+			words = append(words, entry)
+			continue
+		}
+
+		switch {
+		case entry.Expansion != nil:
+			entry.ExpansionSource = models.ExpansionSourceText
+		case acronyms.Expand(entry.Word) != "":
+			expansion := acronyms.Expand(entry.Word)
+			entry.Expansion, entry.ExpansionSource = &expansion, models.ExpansionSourceList
+		}
+		acronymEntries = append(acronymEntries, entry)
+	}
+
+	return words, acronymEntries
+}
+
+// Sort entries in the given order, entries without keyness go last when sorted by keyness.
+// Entries are kept in the order of frequency as loaded by default.
+func sortEntries(entries []*models.DictionaryEntry, order string) {
+	switch order {
+	case models.SortKeyness:
+		sort.SliceStable(entries, func(i, j int) bool {
+			if entries[i].Keyness == nil || entries[j].Keyness == nil {
+				return entries[j].Keyness == nil && entries[i].Keyness != nil
+			}
+			return entries[i].Keyness.Score > entries[j].Keyness.Score
+		})
+	case models.SortWord:
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].Word < entries[j].Word })
+	}
+}
+
+// Mark entries with word states of the authenticated caller and hide known words unless disabled by params,
+// anonymous calls get all entries
+func (u *documentsUC) applyWordStates(
+	ctx context.Context, entries []*models.DictionaryEntry, params *models.DictionaryParams,
+) ([]*models.DictionaryEntry, error) {
+	user, err := utils.GetUserFromCtx(ctx)
+	if err != nil {
+		return entries, nil
+	}
+
+	hide := params == nil || params.HideKnown == nil || *params.HideKnown
+
+	return u.wordsUC.ApplyStates(ctx, user.UserID, entries, hide)
+}
+
+// Look up translations of entries concurrently, acronyms are translated by their expansions.
+// Entries without translation or over the limit of remote lookups of the context are left as is.
+func (u *documentsUC) translate(ctx context.Context, entries []*models.DictionaryEntry) {
+	jobs := make(chan *models.DictionaryEntry)
+
+	var wg sync.WaitGroup
+	for i := 0; i < max(u.cfg.Translation.Concurrency, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for entry := range jobs {
+				lemma := entry.Word
+				if entry.Expansion != nil {
+					lemma = strings.ToLower(*entry.Expansion)
+				}
+
+				t, err := u.translator.Lookup(ctx, lemma)
+				if err != nil {
+					if !errors.Is(err, translation.ErrNotFound) && !errors.Is(err, translation.ErrLimitReached) {
+						slog.Warn("translation lookup", slog.String("word", lemma), sl.Err(err))
+					}
+					continue
+				}
+				entry.Translation = t
+			}
+		}()
+	}
+
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- entry
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// Get page article without boilerplate, markup files like raw READMEs, PDF and EPUB files are parsed
+// by their format, other text is taken as is
+func pageArticle(page *fetcher.Page) (*extractor.Article, error) {
+	if page.IsHTML() {
+		return extractor.Extract(bytes.NewReader(page.Body))
+	}
+
+	article, err := parseFile(page.URL, page.ContentType, page.Body)
+	if errors.Is(err, ErrUnsupportedFile) {
+		return plainArticle(page.ContentType, page.Body), nil
+	}
+
+	return article, err
+}
+
+// Article of plain text in any charset with the whole text in one paragraph
+func plainArticle(contentType string, body []byte) *extractor.Article {
+	text := extractor.Block{Kind: extractor.BlockParagraph, Text: string(textnorm.ToUTF8(body, contentType))}
+
+	return &extractor.Article{Blocks: []extractor.Block{text}}
+}
+
+// Parse markup, PDF, EPUB, subtitles, Go source or OpenAPI file by its name or media type,
+// text files are transcoded to UTF-8 from their charset first
+func parseFile(name, contentType string, body []byte) (*extractor.Article, error) {
+	if format := docfile.FormatOf(name, contentType); format != "" {
+		return docfile.Parse(format, body)
+	}
+
+	body = textnorm.ToUTF8(body, contentType)
+	if format := markup.FormatOf(name); format != "" {
+		return markup.Parse(format, body)
+	}
+	if format := subtitles.FormatOf(name, contentType); format != "" {
+		return subtitles.Parse(format, body)
+	}
+	if godoc.IsSource(name) {
+		return godoc.Parse(name, body)
+	}
+	if openapi.IsCandidate(name, contentType) {
+		article, err := openapi.Parse(body)
+		if !errors.Is(err, openapi.ErrNotSpec) {
+			return article, err
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedFile, name)
+}
+
+// Split text into words keeping names which look like identifiers, "GitHub", whole
+func tokenize(text string, codeMode string) []tokenizer.Token {
+	return tokenizer.Tokenize(
+		text, tokenizer.Options{SplitIdentifiers: codeMode == models.CodeModeSplit, KeepWord: names.IsKnown},
+	)
+}
+
+// Count english words by lemma and phrases found among them, words found to be names or acronyms are marked
+// so, acronyms get expansions defined in the text. Returns entries with up to maxExamples sentences of each
+// word or phrase and total words count.
+func countWords(
+	text string, codeMode string, maxExamples int, phraseOptions phrases.Options, nameOptions names.Options,
+) ([]*models.DictionaryEntry, int) {
+	tokens := tokenize(text, codeMode)
+	terms := tokenizer.Terms(tokens)
+	foundNames := names.Find(text, tokens, nameOptions)
+	foundAcronyms := acronyms.Find(tokens)
+	expansions := make(map[string]string)
+	for _, definition := range acronyms.Definitions(text) {
+		expansions[definition.Lemma] = definition.Expansion
+	}
+
+	entries := make([]*models.DictionaryEntry, 0, len(terms))
+	byWord := make(map[string]*models.DictionaryEntry, len(terms))
+	total := 0
+	for _, term := range terms {
+		if len(term.Lemma) < 2 || len(term.Lemma) > maxWordLength {
+			continue
+		}
+		entry := &models.DictionaryEntry{
+			Word:      term.Lemma,
+			Kind:      models.EntryKindWord,
+			Frequency: term.Count,
+			FromCode:  term.CodeCount == term.Count,
+			Forms:     term.Forms,
+		}
+		expansion, defined := expansions[entry.Word]
+		switch {
+		// Names written in capitals like "PHP" stay names unless they can be expanded
+		case foundAcronyms[entry.Word] && (defined || acronyms.Expand(entry.Word) != "" || !foundNames[entry.Word]):
+			entry.Kind = models.EntryKindAcronym
+			if defined {
+				entry.Expansion = &expansion
+			}
+		case foundNames[entry.Word]:
+			entry.Kind = models.EntryKindName
+		}
+		entries = append(entries, entry)
+		byWord[entry.Word] = entry
+		total += term.Count
+	}
+
+	foundPhrases := phrases.Find([]phrases.Text{{Content: text, Tokens: tokens}}, phraseOptions)[0]
+	phraseTokens, kinds := tokensOfPhrases(foundPhrases)
+	for _, entry := range phraseEntries(phraseTokens, kinds) {
+		entries = append(entries, entry)
+		byWord[entry.Word] = entry
+	}
+
+	if maxExamples > 0 {
+		// Phrases are examined together with words so examples of both come from the first sentences
+		all := append(tokens, phraseTokens...)
+		sort.SliceStable(all, func(i, j int) bool { return all[i].Start < all[j].Start })
+		addExamples(text, all, byWord, maxExamples)
+	}
+
+	return entries, total
+}
+
+// Phrases as tokens which span all their words, so they can be counted and exemplified like words.
+// Returns tokens with kinds of phrase lemmas.
+func tokensOfPhrases(found []phrases.Phrase) ([]tokenizer.Token, map[string]string) {
+	tokens := make([]tokenizer.Token, 0, len(found))
+	kinds := make(map[string]string)
+	for _, phrase := range found {
+		if len(phrase.Lemma) > maxPhraseLength {
+			continue
+		}
+		tokens = append(
+			tokens, tokenizer.Token{Text: phrase.Text, Lemma: phrase.Lemma, Start: phrase.Start, End: phrase.End},
+		)
+		kinds[phrase.Lemma] = phrase.Kind
+	}
+
+	return tokens, kinds
+}
+
+// Dictionary entries of phrase tokens
+func phraseEntries(tokens []tokenizer.Token, kinds map[string]string) []*models.DictionaryEntry {
+	terms := tokenizer.Terms(tokens)
+
+	entries := make([]*models.DictionaryEntry, 0, len(terms))
+	for _, term := range terms {
+		kind := models.EntryKindCollocation
+		if kinds[term.Lemma] == phrases.KindPhrasalVerb {
+			kind = models.EntryKindPhrasalVerb
+		}
+
+		entries = append(
+			entries, &models.DictionaryEntry{
+				Word:      term.Lemma,
+				Kind:      kind,
+				Frequency: term.Count,
+				Forms:     term.Forms[:min(len(term.Forms), maxPhraseForms)],
+			},
+		)
+	}
+
+	return entries
+}
+
+// Find collocations significant across documents, which may be too rare in every document alone.
+// Entries list the documents using them.
+func (u *documentsUC) FindCollocations(
+	ctx context.Context, documents []*models.Document,
+) ([]*models.DictionaryEntry, error) {
+	texts := make([]phrases.Text, 0, len(documents))
+	for _, document := range documents {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		tokens := tokenize(document.Content, document.CodeMode)
+		texts = append(texts, phrases.Text{Content: document.Content, Tokens: tokens})
+	}
+
+	collocations := make([]phrases.Phrase, 0)
+	documentIDs := make(map[string][]uuid.UUID)
+	for i, found := range phrases.Find(texts, u.phraseOptions()) {
+		for _, phrase := range found {
+			if phrase.Kind != phrases.KindCollocation {
+				continue
+			}
+			collocations = append(collocations, phrase)
+
+			ids := documentIDs[phrase.Lemma]
+			if len(ids) == 0 || ids[len(ids)-1] != documents[i].DocumentID {
+				documentIDs[phrase.Lemma] = append(ids, documents[i].DocumentID)
+			}
+		}
+	}
+
+	tokens, kinds := tokensOfPhrases(collocations)
+	entries := phraseEntries(tokens, kinds)
+	for _, entry := range entries {
+		entry.DocumentIDs = documentIDs[entry.Word]
+	}
+
+	return entries, nil
+}
+
+// Options of phrase detection from the config
+func (u *documentsUC) phraseOptions() phrases.Options {
+	return phrases.Options{
+		MinCount: u.cfg.Documents.CollocationMinCount,
+		MinScore: u.cfg.Documents.CollocationMinScore,
+	}
+}
+
+// Options of name detection from the config
+func (u *documentsUC) nameOptions() names.Options {
+	return names.Options{MinCount: u.cfg.Documents.NameMinCount}
+}
+
+// Attach sentences with the first occurrences of every word, tokens must be in text order
+func addExamples(text string, tokens []tokenizer.Token, byWord map[string]*models.DictionaryEntry, maxExamples int) {
+	sentences, sections := tokenizer.Sentences(text)
+	if len(sentences) == 0 {
+		return
+	}
+
+	// Offsets are returned in characters, byte offsets are converted in one pass over the text
+	charStarts := make([]int, len(sentences))
+	charEnds := make([]int, len(sentences))
+	bytePos, charPos := 0, 0
+	for i, s := range sentences {
+		charPos += utf8.RuneCountInString(text[bytePos:s.Start])
+		charStarts[i] = charPos
+		charPos += utf8.RuneCountInString(text[s.Start:s.End])
+		charEnds[i] = charPos
+		bytePos = s.End
+	}
+
+	lastSentence := make(map[string]int, len(byWord))
+	for _, token := range tokens {
+		entry, ok := byWord[token.Lemma]
+		if !ok || len(entry.Examples) >= maxExamples {
+			continue
+		}
+
+		i := sort.Search(len(sentences), func(i int) bool { return sentences[i].End > token.Start })
+		if i == len(sentences) || sentences[i].Start > token.Start || sentences[i].Heading ||
+			len(sentences[i].Text) > maxExampleLength {
+			continue
+		}
+		// Word repeated in one sentence gives one example
+		if last, seen := lastSentence[entry.Word]; seen && last == i {
+			continue
+		}
+		lastSentence[entry.Word] = i
+
+		example := &models.WordExample{
+			Word: entry.Word, Text: sentences[i].Text, Start: charStarts[i], End: charEnds[i],
+		}
+		if section := sentences[i].Section; section >= 0 {
+			example.Heading = &sections[section].Heading
+			example.Anchor = &sections[section].Anchor
+		}
+		entry.Examples = append(entry.Examples, example)
+	}
+}
+
+// Set pages or chapters of examples by their offsets
+func setExampleLocations(entries []*models.DictionaryEntry, locations []extractor.Location) {
+	if len(locations) == 0 {
+		return
+	}
+
+	for _, entry := range entries {
+		for _, example := range entry.Examples {
+			i := sort.Search(len(locations), func(i int) bool { return locations[i].Start > example.Start })
+			if i > 0 {
+				label := locations[i-1].Label
+				if runes := []rune(label); len(runes) > maxLocationLength {
+					label = string(runes[:maxLocationLength])
+				}
+				example.Location = &label
+			}
+		}
+	}
+}
+
+// Link examples to sections of the source page
+func setExampleLinks(document *models.Document, entries []*models.DictionaryEntry) {
+	if document.SourceURL == nil {
+		return
+	}
+	base, _, _ := strings.Cut(*document.SourceURL, "#")
+
+	for _, entry := range entries {
+		for _, example := range entry.Examples {
+			if example.Anchor != nil && *example.Anchor != "" {
+				link := base + "#" + *example.Anchor
+				example.Link = &link
+			}
+		}
+	}
+}
+
+// Normalize part of speech of dictionary params to the form used by translations, check the sort order and
+// names mode and resolve the level of the caller into the minimal level
+func prepareParams(ctx context.Context, params *models.DictionaryParams) error {
+	if params == nil {
+		return nil
+	}
+
+	if params.PartOfSpeech != "" {
+		partOfSpeech := dictfile.PartOfSpeech(params.PartOfSpeech)
+		if partOfSpeech == "" {
+			return fmt.Errorf("%w: %s", ErrUnknownPartOfSpeech, params.PartOfSpeech)
+		}
+		params.PartOfSpeech = partOfSpeech
+	}
+
+	if params.MinLevel != "" {
+		params.MinLevel = strings.ToUpper(params.MinLevel)
+		if cefr.Index(params.MinLevel) < 0 {
+			return fmt.Errorf("%w: %s", ErrUnknownLevel, params.MinLevel)
+		}
+	}
+
+	switch params.Sort {
+	case "", models.SortFrequency, models.SortKeyness, models.SortWord:
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownSort, params.Sort)
+	}
+
+	switch params.Names {
+	case "", models.NamesSeparate, models.NamesDrop:
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownNamesMode, params.Names)
+	}
+
+	if params.AtMyLevel {
+		user, err := utils.GetUserFromCtx(ctx)
+		if err != nil || user.Level == nil {
+			return ErrLevelNotSet
+		}
+		if cefr.Index(*user.Level) > cefr.Index(params.MinLevel) {
+			params.MinLevel = *user.Level
+		}
+	}
+
+	return nil
+}
+
+// Entry has a translation of the given part of speech
+func hasPartOfSpeech(entry *models.DictionaryEntry, partOfSpeech string) bool {
+	if entry.Translation == nil {
+		return false
+	}
+
+	for _, sense := range entry.Translation.Senses {
+		if sense.PartOfSpeech == partOfSpeech {
+			return true
+		}
+	}
+
+	return false
+}
+
+func filter(entries []*models.DictionaryEntry, keep func(*models.DictionaryEntry) bool) []*models.DictionaryEntry {
+	result := make([]*models.DictionaryEntry, 0, len(entries))
+	for _, entry := range entries {
+		if keep(entry) {
+			result = append(result, entry)
+		}
+	}
+
+	return result
+}
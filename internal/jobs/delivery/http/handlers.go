@@ -0,0 +1,50 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/jobs"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils"
+	r "github.com/shlembo598/text-lexicon-go/pkg/utils/responses"
+)
+
+type jobsHandlers struct {
+	cfg    *config.Config
+	jobsUC jobs.UseCase
+}
+
+func NewJobsHandlers(cfg *config.Config, jobsUC jobs.UseCase) jobs.Handlers {
+	return &jobsHandlers{cfg: cfg, jobsUC: jobsUC}
+}
+
+// GetByID godoc
+// @Summary Get job by id
+// @Description get status of a background job, result holds the outcome of a finished job
+// @Tags Jobs
+// @Accept json
+// @Produce json
+// @Param id path string true "job_id"
+// @Success 200 {object} models.Job
+// @Failure 404 {object} httpErrors.RestError
+// @Router /jobs/{id} [get]
+func (h *jobsHandlers) GetByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		jobID, err := uuid.Parse(c.Param("job_id"))
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		job, err := h.jobsUC.GetByID(utils.GetRequestCtx(c), jobID)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, r.SuccessResponse(job))
+	}
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/jobs"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+// Use case returning the job of the given id only
+type fakeJobsUC struct {
+	jobs.UseCase
+	job *models.Job
+}
+
+func (f *fakeJobsUC) GetByID(_ context.Context, jobID uuid.UUID) (*models.Job, error) {
+	if f.job == nil || f.job.JobID != jobID {
+		return nil, fmt.Errorf("jobs.useCase.getByID: %w", sql.ErrNoRows)
+	}
+
+	return f.job, nil
+}
+
+func TestGetByID(t *testing.T) {
+	job := &models.Job{JobID: uuid.New(), Kind: models.JobKindImportDictionary, Status: "running", MaxAttempts: 3}
+	h := NewJobsHandlers(&config.Config{}, &fakeJobsUC{job: job})
+
+	tests := []struct {
+		name   string
+		jobID  string
+		status int
+	}{
+		{name: "job", jobID: job.JobID.String(), status: http.StatusOK},
+		{name: "unknown job", jobID: uuid.NewString(), status: http.StatusNotFound},
+		{name: "invalid id", jobID: "42", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.GET("/jobs/:job_id", h.GetByID())
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/jobs/"+tt.jobID, nil))
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Status string      `json:"status"`
+				Data   *models.Job `json:"data"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatal(err)
+			}
+			if response.Status != "success" || response.Data == nil || response.Data.JobID != job.JobID ||
+				response.Data.Status != job.Status {
+				t.Errorf("response = %s", rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/shlembo598/text-lexicon-go/internal/auth"
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/jobs"
+	"github.com/shlembo598/text-lexicon-go/internal/middleware"
+)
+
+func MapJobsRoutes(
+	jobsGroup *echo.Group, h jobs.Handlers, mw *middleware.MiddlewareManager, authUC auth.UseCase,
+	cfg *config.Config,
+) {
+	jobsGroup.Use(mw.OptionalAuthJWTMiddleware(authUC, cfg))
+	jobsGroup.GET("/:job_id", h.GetByID())
+}
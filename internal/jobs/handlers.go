@@ -0,0 +1,9 @@
+package jobs
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+type Handlers interface {
+	GetByID() echo.HandlerFunc
+}
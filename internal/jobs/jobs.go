@@ -0,0 +1,34 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+// Failure which is not retried, for example a page without text
+var ErrPermanent = errors.New("permanent job failure")
+
+// Job was taken over by another worker after its lock went stale
+var ErrLockLost = errors.New("job is locked by another worker")
+
+// Process job of one kind, the result is saved as json
+type Handler func(ctx context.Context, job *models.Job) (interface{}, error)
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() []error {
+	return []error{ErrPermanent, e.err}
+}
+
+// Mark error as permanent so the job fails without retries, the message is kept as is
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
@@ -0,0 +1,26 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+type Repository interface {
+	Create(ctx context.Context, job *models.Job) (*models.Job, error)
+	GetByID(ctx context.Context, jobID uuid.UUID) (*models.Job, error)
+	// Lock the next pending job, or a running job locked before staleBefore, for the worker.
+	// Returns sql.ErrNoRows when there is nothing to run.
+	Claim(ctx context.Context, kinds []string, worker string, staleBefore time.Time) (*models.Job, error)
+	// Refresh the lock of a running job. Updates of the job by a worker which does not hold its lock
+	// anymore return ErrLockLost.
+	Heartbeat(ctx context.Context, jobID uuid.UUID, worker string) error
+	Complete(ctx context.Context, jobID uuid.UUID, worker string, result []byte) error
+	// Save error of the attempt, the job is run again at retryAt or failed when retryAt is nil
+	Fail(ctx context.Context, jobID uuid.UUID, worker string, message string, retryAt *time.Time) error
+	// Return interrupted job to the queue without counting the attempt
+	Release(ctx context.Context, jobID uuid.UUID, worker string) error
+}
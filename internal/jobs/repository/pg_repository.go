@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/shlembo598/text-lexicon-go/internal/jobs"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+type jobsRepo struct {
+	db *sqlx.DB
+}
+
+func NewJobsRepository(db *sqlx.DB) jobs.Repository {
+	return &jobsRepo{db: db}
+}
+
+// Create pending job
+func (r *jobsRepo) Create(ctx context.Context, job *models.Job) (*models.Job, error) {
+	const op = "jobs.pg_repository.create"
+
+	query, args, err := createJobQuery(job)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	created := &models.Job{}
+	if err = r.db.GetContext(ctx, created, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.GetContext: %w", op, err)
+	}
+
+	return created, nil
+}
+
+// Get job by id
+func (r *jobsRepo) GetByID(ctx context.Context, jobID uuid.UUID) (*models.Job, error) {
+	const op = "jobs.pg_repository.getByID"
+
+	query, args, err := getJobQuery(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	job := &models.Job{}
+	if err = r.db.GetContext(ctx, job, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.GetContext: %w", op, err)
+	}
+
+	return job, nil
+}
+
+// Lock the next job of given kinds for the worker
+func (r *jobsRepo) Claim(
+	ctx context.Context, kinds []string, worker string, staleBefore time.Time,
+) (*models.Job, error) {
+	const op = "jobs.pg_repository.claim"
+
+	query, args, err := claimJobQuery(kinds, worker, staleBefore)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	job := &models.Job{}
+	if err = r.db.GetContext(ctx, job, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.GetContext: %w", op, err)
+	}
+
+	return job, nil
+}
+
+// Refresh the lock of a running job held by the worker
+func (r *jobsRepo) Heartbeat(ctx context.Context, jobID uuid.UUID, worker string) error {
+	const op = "jobs.pg_repository.heartbeat"
+
+	query, args, err := heartbeatJobQuery(jobID, worker)
+	if err != nil {
+		return fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	return r.execLocked(ctx, op, query, args)
+}
+
+// Mark job as done with its result
+func (r *jobsRepo) Complete(ctx context.Context, jobID uuid.UUID, worker string, result []byte) error {
+	const op = "jobs.pg_repository.complete"
+
+	query, args, err := completeJobQuery(jobID, worker, result)
+	if err != nil {
+		return fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	return r.execLocked(ctx, op, query, args)
+}
+
+// Save failed attempt, the job is retried at retryAt or failed for good when retryAt is nil
+func (r *jobsRepo) Fail(
+	ctx context.Context, jobID uuid.UUID, worker string, message string, retryAt *time.Time,
+) error {
+	const op = "jobs.pg_repository.fail"
+
+	query, args, err := failJobQuery(jobID, worker, message, retryAt)
+	if err != nil {
+		return fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	return r.execLocked(ctx, op, query, args)
+}
+
+// Return running job to the queue
+func (r *jobsRepo) Release(ctx context.Context, jobID uuid.UUID, worker string) error {
+	const op = "jobs.pg_repository.release"
+
+	query, args, err := releaseJobQuery(jobID, worker)
+	if err != nil {
+		return fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	return r.execLocked(ctx, op, query, args)
+}
+
+// Run an update of a job locked by a worker, no updated row means the lock went to another worker
+func (r *jobsRepo) execLocked(ctx context.Context, op string, query string, args []interface{}) error {
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s.ExecContext: %w", op, err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s.RowsAffected: %w", op, err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%s: %w", op, jobs.ErrLockLost)
+	}
+
+	return nil
+}
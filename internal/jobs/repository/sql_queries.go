@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+func createJobQuery(job *models.Job) (string, []interface{}, error) {
+	return sq.Insert("jobs").Columns("kind", "user_id", "payload", "max_attempts").Values(
+		job.Kind, job.UserID, string(job.Payload), job.MaxAttempts,
+	).Suffix("RETURNING *").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getJobQuery(jobID uuid.UUID) (string, []interface{}, error) {
+	return sq.Select("*").From("jobs").Where("job_id = ?", jobID).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+// Concurrent workers skip rows locked by each other, so every job is claimed once
+func claimJobQuery(kinds []string, worker string, staleBefore time.Time) (string, []interface{}, error) {
+	next := sq.Select("job_id").From("jobs").Where(
+		sq.Or{
+			sq.And{sq.Eq{"status": models.JobStatusPending}, sq.Expr("run_at <= NOW()")},
+			sq.And{sq.Eq{"status": models.JobStatusRunning}, sq.Lt{"locked_at": staleBefore}},
+		},
+	).Where(sq.Eq{"kind": kinds}).OrderBy("run_at").Limit(1).Suffix("FOR UPDATE SKIP LOCKED")
+
+	return sq.Update("jobs").
+		Set("status", models.JobStatusRunning).
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("locked_at", sq.Expr("NOW()")).
+		Set("locked_by", worker).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Expr("job_id = (?)", next)).
+		Suffix("RETURNING *").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+// Only the worker holding the lock updates a running job
+func heartbeatJobQuery(jobID uuid.UUID, worker string) (string, []interface{}, error) {
+	return sq.Update("jobs").Set("locked_at", sq.Expr("NOW()")).Where(
+		sq.Eq{"job_id": jobID, "status": models.JobStatusRunning, "locked_by": worker},
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func completeJobQuery(jobID uuid.UUID, worker string, result []byte) (string, []interface{}, error) {
+	return sq.Update("jobs").SetMap(
+		map[string]interface{}{
+			"status":     models.JobStatusDone,
+			"result":     string(result),
+			"error":      nil,
+			"locked_at":  nil,
+			"locked_by":  nil,
+			"updated_at": sq.Expr("NOW()"),
+		},
+	).Where(sq.Eq{"job_id": jobID, "locked_by": worker}).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func failJobQuery(
+	jobID uuid.UUID, worker string, message string, retryAt *time.Time,
+) (string, []interface{}, error) {
+	values := map[string]interface{}{
+		"status":     models.JobStatusFailed,
+		"error":      message,
+		"locked_at":  nil,
+		"locked_by":  nil,
+		"updated_at": sq.Expr("NOW()"),
+	}
+	if retryAt != nil {
+		values["status"] = models.JobStatusPending
+		values["run_at"] = *retryAt
+	}
+
+	return sq.Update("jobs").SetMap(values).Where(
+		sq.Eq{"job_id": jobID, "locked_by": worker},
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func releaseJobQuery(jobID uuid.UUID, worker string) (string, []interface{}, error) {
+	return sq.Update("jobs").SetMap(
+		map[string]interface{}{
+			"status":     models.JobStatusPending,
+			"attempts":   sq.Expr("GREATEST(attempts - 1, 0)"),
+			"locked_at":  nil,
+			"locked_by":  nil,
+			"updated_at": sq.Expr("NOW()"),
+		},
+	).Where(
+		sq.Eq{"job_id": jobID, "status": models.JobStatusRunning, "locked_by": worker},
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
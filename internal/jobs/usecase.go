@@ -0,0 +1,15 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+type UseCase interface {
+	// Add job to the queue, payload is saved as json
+	Enqueue(ctx context.Context, kind string, userID *uuid.UUID, payload interface{}) (*models.Job, error)
+	GetByID(ctx context.Context, jobID uuid.UUID) (*models.Job, error)
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/jobs"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils"
+)
+
+type jobsUC struct {
+	cfg      *config.Config
+	jobsRepo jobs.Repository
+}
+
+func NewJobsUseCase(cfg *config.Config, jobsRepo jobs.Repository) jobs.UseCase {
+	return &jobsUC{cfg: cfg, jobsRepo: jobsRepo}
+}
+
+// Add job to the queue, it is picked up by the next free worker
+func (u *jobsUC) Enqueue(
+	ctx context.Context, kind string, userID *uuid.UUID, payload interface{},
+) (*models.Job, error) {
+	const op = "jobs.useCase.enqueue"
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%s.Marshal: %w", op, err)
+	}
+
+	return u.jobsRepo.Create(
+		ctx, &models.Job{Kind: kind, UserID: userID, Payload: data, MaxAttempts: max(u.cfg.Jobs.MaxAttempts, 1)},
+	)
+}
+
+// Get job, jobs of other users are reported as not found
+func (u *jobsUC) GetByID(ctx context.Context, jobID uuid.UUID) (*models.Job, error) {
+	job, err := u.jobsRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.UserID != nil {
+		user, err := utils.GetUserFromCtx(ctx)
+		if err != nil || user.UserID != *job.UserID {
+			return nil, sql.ErrNoRows
+		}
+	}
+
+	return job, nil
+}
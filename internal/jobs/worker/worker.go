@@ -0,0 +1,231 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/jobs"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/pkg/logger/sl"
+)
+
+// Time given to queue updates after the job context is cancelled
+const updateTimeout = 5 * time.Second
+
+// Pool of workers which claim jobs from the queue and run their handlers
+type Pool struct {
+	cfg      *config.Config
+	jobsRepo jobs.Repository
+	handlers map[string]jobs.Handler
+	kinds    []string
+	timeouts map[string]time.Duration
+	name     string
+
+	// Stops claiming new jobs
+	stop context.Context
+	halt context.CancelFunc
+	// Cancels running jobs when shutdown timeout is over
+	run    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewPool(cfg *config.Config, jobsRepo jobs.Repository, handlers map[string]jobs.Handler) *Pool {
+	kinds := make([]string, 0, len(handlers))
+	for kind := range handlers {
+		kinds = append(kinds, kind)
+	}
+
+	host, _ := os.Hostname()
+	p := &Pool{
+		cfg: cfg, jobsRepo: jobsRepo, handlers: handlers, kinds: kinds, timeouts: make(map[string]time.Duration),
+		name: fmt.Sprintf("%s:%d", host, os.Getpid()),
+	}
+	p.stop, p.halt = context.WithCancel(context.Background())
+	p.run, p.cancel = context.WithCancel(context.Background())
+
+	return p
+}
+
+// Set run time limit of jobs of one kind instead of the configured default, must be called before Start
+func (p *Pool) SetTimeout(kind string, timeout time.Duration) {
+	p.timeouts[kind] = timeout
+}
+
+// Start workers in background
+func (p *Pool) Start() {
+	workers := max(p.cfg.Jobs.Workers, 1)
+	slog.Info("job workers started", slog.Int("workers", workers))
+
+	for i := 0; i < workers; i++ {
+		p.wg.Add(1)
+		go func(id int) {
+			defer p.wg.Done()
+			p.work(fmt.Sprintf("%s/%d", p.name, id))
+		}(i)
+	}
+}
+
+// Stop claiming jobs and wait for running ones. Jobs still running when ctx is done are cancelled
+// and returned to the queue for another worker.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.halt()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+func (p *Pool) work(name string) {
+	for {
+		if p.stop.Err() != nil {
+			return
+		}
+
+		job, err := p.jobsRepo.Claim(p.stop, p.kinds, name, time.Now().Add(-p.cfg.Jobs.LockTimeout))
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) && p.stop.Err() == nil {
+				slog.Error("job claim", slog.String("worker", name), sl.Err(err))
+			}
+
+			select {
+			case <-p.stop.Done():
+				return
+			case <-time.After(p.cfg.Jobs.PollInterval):
+			}
+			continue
+		}
+
+		p.process(job, name)
+	}
+}
+
+// Run job handler and save the outcome, failed attempts are retried with exponential backoff
+func (p *Pool) process(job *models.Job, worker string) {
+	log := slog.With(slog.String("job_id", job.JobID.String()), slog.String("kind", job.Kind))
+
+	var result interface{}
+	var err error
+	if job.Attempts > job.MaxAttempts {
+		// Abandoned by crashed workers too many times
+		err = jobs.Permanent(errors.New("attempts exhausted"))
+	} else {
+		result, err = p.handle(job, worker)
+	}
+
+	// Queue is updated even when the job was cancelled by shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), updateTimeout)
+	defer cancel()
+
+	switch {
+	case err == nil:
+		data, marshalErr := json.Marshal(result)
+		if marshalErr != nil {
+			err = marshalErr
+			break
+		}
+		if err = p.jobsRepo.Complete(ctx, job.JobID, worker, data); err != nil {
+			log.Error("job complete", sl.Err(err))
+			return
+		}
+		log.Info("job done", slog.Int("attempt", job.Attempts))
+		return
+	case p.run.Err() != nil:
+		if err = p.jobsRepo.Release(ctx, job.JobID, worker); err != nil {
+			log.Error("job release", sl.Err(err))
+		}
+		log.Info("job released on shutdown")
+		return
+	}
+
+	var retryAt *time.Time
+	if !errors.Is(err, jobs.ErrPermanent) && job.Attempts < job.MaxAttempts {
+		at := time.Now().Add(p.backoff(job.Attempts))
+		retryAt = &at
+	}
+
+	if failErr := p.jobsRepo.Fail(ctx, job.JobID, worker, err.Error(), retryAt); failErr != nil {
+		log.Error("job fail", sl.Err(failErr))
+	}
+	log.Warn("job attempt failed", slog.Int("attempt", job.Attempts), slog.Bool("retry", retryAt != nil), sl.Err(err))
+}
+
+func (p *Pool) handle(job *models.Job, worker string) (result interface{}, err error) {
+	handler, ok := p.handlers[job.Kind]
+	if !ok {
+		return nil, jobs.Permanent(fmt.Errorf("no handler for job kind %s", job.Kind))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job handler panic: %v", r)
+		}
+	}()
+
+	timeout, ok := p.timeouts[job.Kind]
+	if !ok {
+		timeout = p.cfg.Jobs.Timeout
+	}
+
+	ctx, cancel := context.WithTimeout(p.run, timeout)
+	defer cancel()
+	go p.heartbeat(ctx, cancel, job.JobID, worker)
+
+	return handler(ctx, job)
+}
+
+// Refresh the lock of a running job so long jobs are not taken as abandoned, the job is cancelled
+// when another worker took it over anyway
+func (p *Pool) heartbeat(ctx context.Context, cancel context.CancelFunc, jobID uuid.UUID, worker string) {
+	ticker := time.NewTicker(max(p.cfg.Jobs.LockTimeout/3, time.Second))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := p.jobsRepo.Heartbeat(ctx, jobID, worker)
+		switch {
+		case errors.Is(err, jobs.ErrLockLost):
+			slog.Warn("job lock lost", slog.String("job_id", jobID.String()), slog.String("worker", worker))
+			cancel()
+			return
+		case err != nil && ctx.Err() == nil:
+			slog.Error("job heartbeat", slog.String("job_id", jobID.String()), sl.Err(err))
+		}
+	}
+}
+
+// Delay before the next attempt: backoff doubled with every failed attempt
+func (p *Pool) backoff(attempt int) time.Duration {
+	delay := p.cfg.Jobs.Backoff
+	for i := 1; i < attempt && delay < p.cfg.Jobs.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, p.cfg.Jobs.MaxBackoff)
+}
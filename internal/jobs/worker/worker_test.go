@@ -0,0 +1,244 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/jobs"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+const testKind = "test.run"
+
+// Queue recording the outcome of processed jobs
+type fakeJobsRepo struct {
+	jobs.Repository
+	heartbeatErr error
+
+	mu         sync.Mutex
+	outcome    string
+	result     string
+	message    string
+	retryAt    *time.Time
+	heartbeats int
+}
+
+func (f *fakeJobsRepo) Complete(_ context.Context, _ uuid.UUID, _ string, result []byte) error {
+	f.outcome, f.result = "complete", string(result)
+	return nil
+}
+
+func (f *fakeJobsRepo) Fail(_ context.Context, _ uuid.UUID, _ string, message string, retryAt *time.Time) error {
+	f.outcome, f.message, f.retryAt = "fail", message, retryAt
+	return nil
+}
+
+func (f *fakeJobsRepo) Release(context.Context, uuid.UUID, string) error {
+	f.outcome = "release"
+	return nil
+}
+
+func (f *fakeJobsRepo) Heartbeat(context.Context, uuid.UUID, string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.heartbeats++
+
+	return f.heartbeatErr
+}
+
+func testConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.Jobs.Backoff = 5 * time.Second
+	cfg.Jobs.MaxBackoff = time.Minute
+	cfg.Jobs.Timeout = time.Minute
+	cfg.Jobs.LockTimeout = 10 * time.Minute
+
+	return cfg
+}
+
+func TestBackoff(t *testing.T) {
+	p := NewPool(testConfig(), &fakeJobsRepo{}, nil)
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 5 * time.Second},
+		{attempt: 2, want: 10 * time.Second},
+		{attempt: 3, want: 20 * time.Second},
+		{attempt: 4, want: 40 * time.Second},
+		{attempt: 5, want: time.Minute},
+		{attempt: 50, want: time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := p.backoff(tt.attempt); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestProcess(t *testing.T) {
+	failure := errors.New("connection refused")
+
+	tests := []struct {
+		name     string
+		kind     string
+		attempts int
+		handler  jobs.Handler
+		outcome  string
+		// Delay of the retry, no retry when zero
+		retry   time.Duration
+		result  string
+		message string
+	}{
+		{
+			name: "done", attempts: 1, outcome: "complete", result: `{"word_count":3}`,
+			handler: func(context.Context, *models.Job) (interface{}, error) {
+				return map[string]int{"word_count": 3}, nil
+			},
+		},
+		{
+			name: "retried with backoff", attempts: 2, outcome: "fail", retry: 10 * time.Second,
+			message: failure.Error(),
+			handler: func(context.Context, *models.Job) (interface{}, error) {
+				return nil, failure
+			},
+		},
+		{
+			name: "last attempt", attempts: 3, outcome: "fail", message: failure.Error(),
+			handler: func(context.Context, *models.Job) (interface{}, error) {
+				return nil, failure
+			},
+		},
+		{
+			name: "permanent failure", attempts: 1, outcome: "fail", message: "page without text",
+			handler: func(context.Context, *models.Job) (interface{}, error) {
+				return nil, jobs.Permanent(errors.New("page without text"))
+			},
+		},
+		{
+			name: "panic", attempts: 1, outcome: "fail", retry: 5 * time.Second,
+			message: "job handler panic: nil map",
+			handler: func(context.Context, *models.Job) (interface{}, error) {
+				panic("nil map")
+			},
+		},
+		{
+			name: "unknown kind", kind: "test.unknown", attempts: 1, outcome: "fail",
+			message: "no handler for job kind test.unknown",
+		},
+		{
+			name: "attempts exhausted by crashed workers", attempts: 4, outcome: "fail", message: "attempts exhausted",
+			handler: func(context.Context, *models.Job) (interface{}, error) {
+				t.Error("handler of an exhausted job is run")
+				return nil, nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeJobsRepo{}
+			p := NewPool(testConfig(), repo, map[string]jobs.Handler{testKind: tt.handler})
+
+			kind := testKind
+			if tt.kind != "" {
+				kind = tt.kind
+			}
+			started := time.Now()
+			p.process(&models.Job{JobID: uuid.New(), Kind: kind, Attempts: tt.attempts, MaxAttempts: 3}, "test/0")
+
+			if repo.outcome != tt.outcome || repo.result != tt.result || repo.message != tt.message {
+				t.Errorf(
+					"process() = %s %q %q, want %s %q %q",
+					repo.outcome, repo.result, repo.message, tt.outcome, tt.result, tt.message,
+				)
+			}
+			switch {
+			case tt.retry == 0 && repo.retryAt != nil:
+				t.Errorf("process() retry at %v, want no retry", repo.retryAt)
+			case tt.retry != 0 && repo.retryAt == nil:
+				t.Errorf("process() no retry, want retry in %v", tt.retry)
+			case tt.retry != 0 && repo.retryAt.Sub(started) < tt.retry || tt.retry != 0 &&
+				repo.retryAt.Sub(started) > tt.retry+time.Second:
+				t.Errorf("process() retry in %v, want %v", repo.retryAt.Sub(started), tt.retry)
+			}
+		})
+	}
+}
+
+func TestProcessShutdown(t *testing.T) {
+	repo := &fakeJobsRepo{}
+	p := NewPool(testConfig(), repo, map[string]jobs.Handler{
+		testKind: func(ctx context.Context, _ *models.Job) (interface{}, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	})
+	// Shutdown timeout is over
+	p.cancel()
+
+	p.process(&models.Job{JobID: uuid.New(), Kind: testKind, Attempts: 1, MaxAttempts: 3}, "test/0")
+
+	if repo.outcome != "release" {
+		t.Errorf("process() = %s, want release", repo.outcome)
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	if testing.Short() {
+		t.Skip("heartbeats are a second apart")
+	}
+
+	tests := []struct {
+		name         string
+		heartbeatErr error
+		outcome      string
+	}{
+		{name: "lock refreshed", outcome: "complete"},
+		{name: "lock lost", heartbeatErr: jobs.ErrLockLost, outcome: "fail"},
+		{name: "heartbeat failed", heartbeatErr: errors.New("connection refused"), outcome: "complete"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testConfig()
+			// Heartbeats run at most once a second
+			cfg.Jobs.LockTimeout = time.Second
+
+			repo := &fakeJobsRepo{heartbeatErr: tt.heartbeatErr}
+			p := NewPool(cfg, repo, map[string]jobs.Handler{
+				// Long job which stops when it is cancelled
+				testKind: func(ctx context.Context, _ *models.Job) (interface{}, error) {
+					select {
+					case <-ctx.Done():
+						return nil, ctx.Err()
+					case <-time.After(1500 * time.Millisecond):
+						return "done", nil
+					}
+				},
+			})
+
+			p.process(&models.Job{JobID: uuid.New(), Kind: testKind, Attempts: 1, MaxAttempts: 3}, "test/0")
+
+			repo.mu.Lock()
+			heartbeats := repo.heartbeats
+			repo.mu.Unlock()
+			if heartbeats != 1 {
+				t.Errorf("heartbeats = %d, want 1", heartbeats)
+			}
+			if repo.outcome != tt.outcome {
+				t.Errorf("process() = %s, want %s", repo.outcome, tt.outcome)
+			}
+		})
+	}
+}
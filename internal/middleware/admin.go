@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils/httpErrors"
+)
+
+// Admin access by user email from config, must go after AuthJWTMiddleware
+func (mw *MiddlewareManager) AdminMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		user, ok := c.Get("user").(*models.User)
+		if !ok {
+			slog.Error("admin middleware", slog.String("user", "user is not set in context"))
+
+			return c.JSON(http.StatusUnauthorized, httpErrors.NewUnauthorizedError(httpErrors.Unauthorized))
+		}
+
+		isAdmin := slices.ContainsFunc(
+			mw.cfg.Server.AdminEmails, func(email string) bool {
+				return strings.EqualFold(email, user.Email)
+			},
+		)
+		if !isAdmin {
+			slog.Error("admin middleware", slog.String("user_id", user.UserID.String()))
+
+			return c.JSON(http.StatusForbidden, httpErrors.NewForbiddenError(httpErrors.PermissionDenied))
+		}
+
+		return next(c)
+	}
+}
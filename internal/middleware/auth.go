@@ -99,3 +99,20 @@ func (mw *MiddlewareManager) validateJWTToken(
 	}
 	return nil
 }
+
+// JWT auth which lets anonymous requests through, the user is set only when Authorization header is given
+func (mw *MiddlewareManager) OptionalAuthJWTMiddleware(authUC auth.UseCase, cfg *config.Config) echo.MiddlewareFunc {
+	required := mw.AuthJWTMiddleware(authUC, cfg)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		authenticated := required(next)
+
+		return func(c echo.Context) error {
+			if c.Request().Header.Get("Authorization") == "" {
+				return next(c)
+			}
+
+			return authenticated(c)
+		}
+	}
+}
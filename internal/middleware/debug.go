@@ -19,7 +19,7 @@ func (mw *MiddlewareManager) DebugMiddleware(next echo.HandlerFunc) echo.Handler
 			}
 			slog.Info(
 				"Request dump",
-				fmt.Sprintf("\nbegin :--------------\n\n%s\n\nend :--------------", dump),
+				slog.String("dump", fmt.Sprintf("\nbegin :--------------\n\n%s\n\nend :--------------", dump)),
 			)
 		}
 		return next(c)
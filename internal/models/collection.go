@@ -0,0 +1,118 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Named group of documents with a combined dictionary
+type Collection struct {
+	CollectionID uuid.UUID `json:"collection_id" db:"collection_id"`
+	UserID       uuid.UUID `json:"user_id" db:"user_id"`
+	Name         string    `json:"name" db:"name" validate:"required,lte=250"`
+	// Root page or sitemap of a crawled site
+	SourceURL *string   `json:"source_url,omitempty" db:"source_url"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	// Documents in the order they were added
+	Documents []*Document `json:"documents,omitempty" db:"-"`
+}
+
+// Combined dictionary of collection documents, frequencies are summed across documents
+type CollectionDictionary struct {
+	Collection *Collection        `json:"collection"`
+	Entries    []*DictionaryEntry `json:"entries"`
+	// Proper nouns and product names left out of entries, they are not translated
+	Names []*DictionaryEntry `json:"names,omitempty"`
+	// Glossary of acronyms left out of entries
+	Acronyms []*DictionaryEntry `json:"acronyms,omitempty"`
+	// Share of words above the level of the caller, only for callers with a level
+	AboveLevelShare *float64 `json:"above_level_share,omitempty"`
+}
+
+// Collection document where a dictionary word is used
+type WordDocument struct {
+	Word       string    `db:"word"`
+	DocumentID uuid.UUID `db:"document_id"`
+}
+
+// Site crawl which fills a collection
+type CrawlParams struct {
+	CollectionID uuid.UUID `json:"collection_id"`
+	URL          string    `json:"url"`
+	// Path prefix of crawled pages, the directory of the root page by default
+	Prefix   string `json:"prefix,omitempty"`
+	MaxDepth *int   `json:"max_depth,omitempty"`
+	MaxPages int    `json:"max_pages,omitempty"`
+	CodeMode string `json:"code_mode,omitempty"`
+}
+
+// Collection with the job crawling its pages
+type CollectionCrawl struct {
+	Collection *Collection `json:"collection"`
+	Job        *Job        `json:"job"`
+}
+
+// Import of documentation files of a directory or an archive which fills a collection
+type ImportParams struct {
+	CollectionID uuid.UUID `json:"collection_id"`
+	// Glob patterns of imported and left out files, defaults of the tree reader when empty
+	Include  []string `json:"include,omitempty"`
+	Exclude  []string `json:"exclude,omitempty"`
+	CodeMode string   `json:"code_mode,omitempty"`
+	// Files matching include and exclude patterns ordered by path
+	Files []*ImportFile `json:"files"`
+	// Files left out before the import, duplicates and files which are too large
+	Skipped []*SkippedFile `json:"skipped,omitempty"`
+}
+
+// File of an imported directory or archive
+type ImportFile struct {
+	// Slash separated path relative to the root of the tree
+	Path string `json:"path"`
+	// Hex sha256 of the content, later files with the same content are skipped
+	Hash string `json:"hash"`
+	// Content of the file, kept out of job payloads and stored apart by the hash
+	Body []byte `json:"-"`
+}
+
+// File of an import which did not become a document
+type SkippedFile struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// Document created from a file of an import and the words it added to the collection
+type ImportedFile struct {
+	Path       string    `json:"path"`
+	Hash       string    `json:"hash"`
+	DocumentID uuid.UUID `json:"document_id"`
+	WordCount  int       `json:"word_count"`
+	// Entries not found in files before this one in path order, most frequent first and at most the summary limit
+	NewWords []string `json:"new_words"`
+	// Number of all new entries of the file
+	NewWordsCount int `json:"new_words_count"`
+}
+
+// Outcome of an import: documents created from files and files left out
+type ImportSummary struct {
+	CollectionID uuid.UUID       `json:"collection_id"`
+	Files        []*ImportedFile `json:"files"`
+	Skipped      []*SkippedFile  `json:"skipped"`
+}
+
+// Collection with the job importing its files
+type CollectionImport struct {
+	Collection *Collection `json:"collection"`
+	Job        *Job        `json:"job"`
+}
+
+func (c *Collection) PrepareCreate() {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.SourceURL != nil {
+		sourceURL := strings.TrimSpace(*c.SourceURL)
+		c.SourceURL = &sourceURL
+	}
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	DictionaryFormatStarDict = "stardict"
+	DictionaryFormatDSL      = "dsl"
+	DictionaryFormatTEI      = "tei"
+)
+
+const (
+	DictionaryStatusImporting = "importing"
+	DictionaryStatusDone      = "done"
+	DictionaryStatusFailed    = "failed"
+)
+
+// Bilingual dictionary imported into the offline translation tables
+type ImportedDictionary struct {
+	DictionaryID   int        `json:"dictionary_id" db:"dictionary_id"`
+	Name           string     `json:"name" db:"name"`
+	Title          *string    `json:"title,omitempty" db:"title"`
+	Format         string     `json:"format" db:"format"`
+	SourceLanguage *string    `json:"source_language,omitempty" db:"source_language"`
+	TargetLanguage *string    `json:"target_language,omitempty" db:"target_language"`
+	Checksum       string     `json:"checksum" db:"checksum"`
+	HeadwordsCount int        `json:"headwords_count" db:"headwords_count"`
+	Status         string     `json:"status" db:"status"`
+	Error          *string    `json:"error,omitempty" db:"error"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	ImportedAt     *time.Time `json:"imported_at,omitempty" db:"imported_at"`
+}
+
+// Dictionary file to import
+type DictionaryImport struct {
+	// Path to .ifo of a StarDict dictionary, .dsl or .tei file, compressed files are accepted
+	Path   string `json:"path"`
+	Format string `json:"format" validate:"omitempty,oneof=stardict dsl tei"`
+	// Unique name, re-import with the same name replaces the dictionary
+	Name string `json:"name" validate:"omitempty,lte=250"`
+	// Import even if the files were not changed since the last import
+	Force bool `json:"force"`
+}
+
+// Fill format and name from the file name when they are not set
+func (d *DictionaryImport) PrepareImport() {
+	d.Name = strings.TrimSpace(d.Name)
+
+	base := strings.ToLower(filepath.Base(d.Path))
+	base = strings.TrimSuffix(strings.TrimSuffix(base, ".dz"), ".gz")
+	ext := filepath.Ext(base)
+
+	if d.Format == "" {
+		switch ext {
+		case ".ifo":
+			d.Format = DictionaryFormatStarDict
+		case ".dsl":
+			d.Format = DictionaryFormatDSL
+		case ".tei", ".xml":
+			d.Format = DictionaryFormatTEI
+		}
+	}
+
+	if d.Name == "" {
+		d.Name = strings.TrimSuffix(base, ext)
+	}
+}
@@ -0,0 +1,207 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Code blocks and identifiers are left out of the dictionary
+	CodeModeSkip = "skip"
+	// Identifiers are split into words which are marked as found in code
+	CodeModeSplit = "split"
+)
+
+type Document struct {
+	DocumentID uuid.UUID  `json:"document_id" db:"document_id" validate:"omitempty"`
+	UserID     *uuid.UUID `json:"user_id,omitempty" db:"user_id"`
+	SourceURL  *string    `json:"source_url,omitempty" db:"source_url" validate:"omitempty,url"`
+	// Path of the file in an imported directory or archive
+	SourcePath *string `json:"source_path,omitempty" db:"source_path"`
+	Title      string  `json:"title" db:"title" validate:"omitempty,lte=250"`
+	Content    string  `json:"-" db:"content"`
+	CodeMode   string  `json:"code_mode" db:"code_mode" validate:"omitempty,oneof=skip split"`
+	WordCount  int     `json:"word_count" db:"word_count"`
+	// Lowest CEFR level whose words cover 95% of the text
+	Level *string `json:"level,omitempty" db:"level"`
+	// Mean CEFR level of words from 1 for A1 to 6 for C2
+	Difficulty *float64  `json:"difficulty,omitempty" db:"difficulty"`
+	CreatedAt  time.Time `json:"created_at,omitempty" db:"created_at"`
+}
+
+const (
+	EntryKindWord = "word"
+	// Verb with particles: "set up", "fall back to"
+	EntryKindPhrasalVerb = "phrasal_verb"
+	// Words used together more often than by chance: "race condition", "dead letter queue"
+	EntryKindCollocation = "collocation"
+	// Probable proper noun or product name: "Kubernetes", "GitHub"
+	EntryKindName = "name"
+	// Word written in capitals: "TLS", "CRD"
+	EntryKindAcronym = "acronym"
+)
+
+const (
+	// Expansion of an acronym is defined in the text: "Transport Layer Security (TLS)"
+	ExpansionSourceText = "text"
+	// Expansion of an acronym is taken from the list of common abbreviations
+	ExpansionSourceList = "list"
+)
+
+type DictionaryEntry struct {
+	// Lemma of a word or lemmas of phrase words joined by spaces
+	Word      string   `json:"word" db:"word"`
+	Kind      string   `json:"kind" db:"kind"`
+	Frequency int      `json:"frequency" db:"frequency"`
+	FromCode  bool     `json:"from_code" db:"from_code"`
+	Forms     []string `json:"forms" db:"-"`
+	// Long form of an acronym, translations of acronyms are translations of their expansions
+	Expansion       *string `json:"expansion,omitempty" db:"expansion"`
+	ExpansionSource string  `json:"expansion_source,omitempty" db:"-"`
+	// CEFR level estimated by the frequency of the word in general english
+	Level string `json:"level" db:"-"`
+	// Thousand of most frequent english words the word belongs to, 0 for rare words
+	Band int `json:"band" db:"-"`
+	// How characteristic of the text the word is compared to general english and other documents of the caller
+	Keyness     *Keyness     `json:"keyness,omitempty" db:"-"`
+	Translation *Translation `json:"translation,omitempty" db:"-"`
+	// State of the word in the vocabulary of the caller
+	State string `json:"state,omitempty" db:"-"`
+	// Sentences of the document where the word is used
+	Examples []*WordExample `json:"examples,omitempty" db:"-"`
+	// Documents of a collection where the word is used
+	DocumentIDs []uuid.UUID `json:"document_ids,omitempty" db:"-"`
+}
+
+// Keyness scores of a dictionary word, signed log-likelihoods which are positive for words used in the text
+// more often than in the reference corpus and above 15.13 for differences significant at the 0.0001 level
+type Keyness struct {
+	// Mean of the scores below, the key for sorting dictionaries by keyness
+	Score float64 `json:"score"`
+	// Score against general english
+	General float64 `json:"general"`
+	// Score against other documents of the caller, only for callers with other documents
+	Personal *float64 `json:"personal,omitempty"`
+	// Occurrences per million words in the text
+	TextPerMillion float64 `json:"text_per_million"`
+	// Occurrences per million words of general english estimated by the frequency rank of the word
+	GeneralPerMillion float64 `json:"general_per_million"`
+	// Occurrences per million words in other documents of the caller
+	PersonalPerMillion *float64 `json:"personal_per_million,omitempty"`
+}
+
+// Word counts of other documents of a user used as a reference corpus for keyness
+type ReferenceCorpus struct {
+	// Occurrences of words by lemma
+	Counts map[string]int
+	// Words in all documents of the corpus
+	Total int
+}
+
+// Document sentence with a dictionary word
+type WordExample struct {
+	Word string `json:"-" db:"word"`
+	Text string `json:"text" db:"text"`
+	// Character offsets of the sentence in the document content
+	Start int `json:"start" db:"start_offset"`
+	End   int `json:"end" db:"end_offset"`
+	// Heading of the document section with the sentence
+	Heading *string `json:"heading,omitempty" db:"heading"`
+	Anchor  *string `json:"-" db:"anchor"`
+	// Part of the document with the sentence: page of a PDF or chapter of an EPUB, symbol of Go doc comments,
+	// operation or schema of an API spec, start of the subtitle cue:
+	// "page 12", "chapter 3", "echo.Context.Bind", "getPet", "00:01:23.456"
+	Location *string `json:"location,omitempty" db:"location"`
+	// Link to the section of the source page
+	Link *string `json:"link,omitempty" db:"-"`
+}
+
+// Surface form of a dictionary word as seen in the document
+type WordForm struct {
+	Word string `db:"word"`
+	Form string `db:"form"`
+}
+
+type Dictionary struct {
+	Document *Document          `json:"document"`
+	Entries  []*DictionaryEntry `json:"entries"`
+	// Proper nouns and product names left out of entries, they are not translated
+	Names []*DictionaryEntry `json:"names,omitempty"`
+	// Glossary of acronyms left out of entries
+	Acronyms []*DictionaryEntry `json:"acronyms,omitempty"`
+	// Share of words above the level of the caller, only for callers with a level
+	AboveLevelShare *float64 `json:"above_level_share,omitempty"`
+}
+
+// Dictionary entries split into vocabulary, names and acronyms
+type FilteredEntries struct {
+	Entries  []*DictionaryEntry
+	Names    []*DictionaryEntry
+	Acronyms []*DictionaryEntry
+}
+
+// Options of dictionary generation
+type DictionaryParams struct {
+	// Leave out words the caller marked as known or ignored, on by default for authenticated calls
+	HideKnown *bool
+	// Leave out words used less often than this
+	MinFrequency int
+	// Keep only words with a translation of this part of speech
+	PartOfSpeech string
+	// Keep only words at or above this CEFR level
+	MinLevel string
+	// Keep only words at or above the level of the caller
+	AtMyLevel bool
+	// Order of entries, by frequency by default
+	Sort string
+	// Keep names in a separate section or drop them, separate by default
+	Names string
+}
+
+const (
+	NamesSeparate = "separate"
+	NamesDrop     = "drop"
+)
+
+const (
+	SortFrequency = "frequency"
+	SortKeyness   = "keyness"
+	SortWord      = "word"
+)
+
+const (
+	ExportFormatCSV      = "csv"
+	ExportFormatTSV      = "tsv"
+	ExportFormatMarkdown = "md"
+	ExportFormatAnki     = "apkg"
+)
+
+// Dictionary file ready for download
+type DictionaryExport struct {
+	FileName    string
+	ContentType string
+	Body        []byte
+}
+
+// Longest title in characters, the size of the title column
+const maxTitleLength = 256
+
+func (d *Document) PrepareCreate() error {
+	d.Title = strings.TrimSpace(d.Title)
+	// Titles of pages and files are not limited by requests
+	if runes := []rune(d.Title); len(runes) > maxTitleLength {
+		d.Title = strings.TrimSpace(string(runes[:maxTitleLength]))
+	}
+	if d.CodeMode == "" {
+		d.CodeMode = CodeModeSkip
+	}
+
+	if d.SourceURL != nil {
+		sourceURL := strings.TrimSpace(*d.SourceURL)
+		d.SourceURL = &sourceURL
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	JobStatusPending = "pending"
+	JobStatusRunning = "running"
+	JobStatusDone    = "done"
+	JobStatusFailed  = "failed"
+)
+
+const (
+	// Fetch, tokenize and save a document
+	JobKindCreateDocument = "document.create"
+	// Crawl a site into a collection
+	JobKindCrawlCollection = "collection.crawl"
+	// Import files of a directory or an archive into a collection
+	JobKindImportCollection = "collection.import"
+	// Import a dictionary from the server import directory
+	JobKindImportDictionary = "dictionary.import"
+)
+
+// Background task processed by workers
+type Job struct {
+	JobID       uuid.UUID       `json:"job_id" db:"job_id"`
+	Kind        string          `json:"kind" db:"kind"`
+	UserID      *uuid.UUID      `json:"-" db:"user_id"`
+	Payload     json.RawMessage `json:"-" db:"payload"`
+	Status      string          `json:"status" db:"status"`
+	Attempts    int             `json:"attempts" db:"attempts"`
+	MaxAttempts int             `json:"max_attempts" db:"max_attempts"`
+	// Outcome of a finished job, for example the created document
+	Result *json.RawMessage `json:"result,omitempty" db:"result" swaggertype:"object"`
+	// Error of the last attempt
+	Error     *string    `json:"error,omitempty" db:"error"`
+	RunAt     time.Time  `json:"run_at" db:"run_at"`
+	LockedAt  *time.Time `json:"-" db:"locked_at"`
+	LockedBy  *string    `json:"-" db:"locked_by"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Review state of a word the user is learning
+type ReviewCard struct {
+	UserID     uuid.UUID `json:"-" db:"user_id"`
+	Word       string    `json:"word" db:"word"`
+	Ease       float64   `json:"ease" db:"ease"`
+	Stability  float64   `json:"stability" db:"stability"`
+	Difficulty float64   `json:"difficulty" db:"difficulty"`
+	// Days between the last and the next review
+	Interval       float64      `json:"interval" db:"interval_days"`
+	Repetitions    int          `json:"repetitions" db:"repetitions"`
+	Lapses         int          `json:"lapses" db:"lapses"`
+	DueAt          time.Time    `json:"due_at" db:"due_at"`
+	LastReviewedAt *time.Time   `json:"last_reviewed_at,omitempty" db:"last_reviewed_at"`
+	CreatedAt      time.Time    `json:"created_at" db:"created_at"`
+	Translation    *Translation `json:"translation,omitempty" db:"-"`
+}
+
+// Answer to a review card
+type ReviewLog struct {
+	ReviewID   int64     `json:"review_id" db:"review_id"`
+	UserID     uuid.UUID `json:"-" db:"user_id"`
+	Word       string    `json:"word" db:"word"`
+	Algorithm  string    `json:"algorithm" db:"algorithm"`
+	Grade      int       `json:"grade" db:"grade"`
+	Interval   float64   `json:"interval" db:"interval_days"`
+	ReviewedAt time.Time `json:"reviewed_at" db:"reviewed_at"`
+}
+
+// Review preferences of the user
+type ReviewSettings struct {
+	UserID    uuid.UUID `json:"-" db:"user_id"`
+	Algorithm string    `json:"algorithm" db:"algorithm"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
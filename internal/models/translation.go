@@ -0,0 +1,23 @@
+package models
+
+// Translations of a lemma found by a translation provider
+type Translation struct {
+	Transcription string   `json:"transcription,omitempty"`
+	Provider      string   `json:"provider"`
+	Senses        []*Sense `json:"senses"`
+}
+
+// Meaning of a word with its translations
+type Sense struct {
+	SenseID      int64      `json:"-" db:"sense_id"`
+	PartOfSpeech string     `json:"part_of_speech,omitempty" db:"part_of_speech"`
+	Translations []string   `json:"translations" db:"-"`
+	Examples     []*Example `json:"examples,omitempty" db:"-"`
+}
+
+// Usage example of a sense
+type Example struct {
+	SenseID     int64  `json:"-" db:"sense_id"`
+	Text        string `json:"text" db:"text"`
+	Translation string `json:"translation,omitempty" db:"translation"`
+}
@@ -16,6 +16,8 @@ type User struct {
 	Password  string    `json:"password,omitempty" db:"password"  validate:"omitempty,required,gte=6"`
 	Avatar    []byte    `json:"avatar,omitempty" db:"avatar"`
 	Country   *string   `json:"country,omitempty" db:"country"  validate:"omitempty,lte=24"`
+	// Declared CEFR level of english
+	Level     *string   `json:"level,omitempty" db:"level"  validate:"omitempty,oneof=A1 A2 B1 B2 C1 C2"`
 	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at" `
 	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at" `
 	LoginDate time.Time `json:"login_date" db:"login_date" `
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Word is known and hidden from dictionaries
+	WordStateKnown = "known"
+	// Word is being learned and stays in dictionaries
+	WordStateLearning = "learning"
+	// Word is not worth learning and is hidden from dictionaries
+	WordStateIgnored = "ignored"
+	// Word is a proper noun or a product name and goes to the names of dictionaries
+	WordStateName = "name"
+)
+
+// Word state in the user vocabulary
+type UserWord struct {
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	Word      string    `json:"word" db:"word"`
+	State     string    `json:"state" db:"state"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Check if words in this state are left out of dictionaries
+func IsHiddenWordState(state string) bool {
+	return state == WordStateKnown || state == WordStateIgnored
+}
+
+// Normalize word to the form stored in dictionaries
+func NormalizeWord(word string) string {
+	return strings.ToLower(strings.TrimSpace(word))
+}
@@ -0,0 +1,174 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/internal/reviews"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils/httpErrors"
+	r "github.com/shlembo598/text-lexicon-go/pkg/utils/responses"
+)
+
+type reviewsHandlers struct {
+	cfg       *config.Config
+	reviewsUC reviews.UseCase
+}
+
+func NewReviewsHandlers(cfg *config.Config, reviewsUC reviews.UseCase) reviews.Handlers {
+	return &reviewsHandlers{cfg: cfg, reviewsUC: reviewsUC}
+}
+
+// GetDue godoc
+// @Summary Get due cards
+// @Description get review cards of learning words which are due now, earliest first,
+// @Description cards are created for words marked as learning
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param limit query int false "number of cards, 100 at most"
+// @Success 200 {array} models.ReviewCard
+// @Failure 401 {object} httpErrors.RestError
+// @Router /reviews/due [get]
+func (h *reviewsHandlers) GetDue() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		limit := 0
+		if value := c.QueryParam("limit"); value != "" {
+			var err error
+			if limit, err = strconv.Atoi(value); err != nil {
+				err = httpErrors.NewBadRequestError(err)
+				utils.LogResponseError(c, err)
+				return c.JSON(r.ErrorResponse(err))
+			}
+		}
+
+		ctx := utils.GetRequestCtx(c)
+		user, err := utils.GetUserFromCtx(ctx)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(httpErrors.NewUnauthorizedError(err)))
+		}
+
+		cards, err := h.reviewsUC.GetDue(ctx, user.UserID, limit)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, r.SuccessResponse(cards))
+	}
+}
+
+// Review godoc
+// @Summary Answer a card
+// @Description grade the answer to a card: 1 again, 2 hard, 3 good, 4 easy, returns the card with its next due date
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.ReviewCard
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Router /reviews [post]
+func (h *reviewsHandlers) Review() echo.HandlerFunc {
+	type ReviewCard struct {
+		Word  string `json:"word" validate:"required,lte=128"`
+		Grade int    `json:"grade" validate:"required,min=1,max=4"`
+	}
+
+	return func(c echo.Context) error {
+		request := &ReviewCard{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		ctx := utils.GetRequestCtx(c)
+		user, err := utils.GetUserFromCtx(ctx)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(httpErrors.NewUnauthorizedError(err)))
+		}
+
+		card, err := h.reviewsUC.Review(ctx, user.UserID, request.Word, request.Grade)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, r.SuccessResponse(card))
+	}
+}
+
+// GetSettings godoc
+// @Summary Get review settings
+// @Description get scheduling algorithm of current user
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.ReviewSettings
+// @Failure 401 {object} httpErrors.RestError
+// @Router /reviews/settings [get]
+func (h *reviewsHandlers) GetSettings() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := utils.GetRequestCtx(c)
+		user, err := utils.GetUserFromCtx(ctx)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(httpErrors.NewUnauthorizedError(err)))
+		}
+
+		settings, err := h.reviewsUC.GetSettings(ctx, user.UserID)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, r.SuccessResponse(settings))
+	}
+}
+
+// UpdateSettings godoc
+// @Summary Update review settings
+// @Description choose scheduling algorithm: sm2 or fsrs, existing cards keep their review history
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.ReviewSettings
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Router /reviews/settings [put]
+func (h *reviewsHandlers) UpdateSettings() echo.HandlerFunc {
+	type UpdateSettings struct {
+		Algorithm string `json:"algorithm" validate:"required,oneof=sm2 fsrs"`
+	}
+
+	return func(c echo.Context) error {
+		request := &UpdateSettings{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		ctx := utils.GetRequestCtx(c)
+		user, err := utils.GetUserFromCtx(ctx)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(httpErrors.NewUnauthorizedError(err)))
+		}
+
+		settings, err := h.reviewsUC.UpdateSettings(
+			ctx, &models.ReviewSettings{UserID: user.UserID, Algorithm: request.Algorithm},
+		)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, r.SuccessResponse(settings))
+	}
+}
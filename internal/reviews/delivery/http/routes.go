@@ -0,0 +1,21 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/shlembo598/text-lexicon-go/internal/auth"
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/middleware"
+	"github.com/shlembo598/text-lexicon-go/internal/reviews"
+)
+
+func MapReviewsRoutes(
+	reviewsGroup *echo.Group, h reviews.Handlers, mw *middleware.MiddlewareManager, authUC auth.UseCase,
+	cfg *config.Config,
+) {
+	reviewsGroup.Use(mw.AuthJWTMiddleware(authUC, cfg))
+	reviewsGroup.GET("/due", h.GetDue())
+	reviewsGroup.POST("", h.Review())
+	reviewsGroup.GET("/settings", h.GetSettings())
+	reviewsGroup.PUT("/settings", h.UpdateSettings())
+}
@@ -0,0 +1,12 @@
+package reviews
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+type Handlers interface {
+	GetDue() echo.HandlerFunc
+	Review() echo.HandlerFunc
+	GetSettings() echo.HandlerFunc
+	UpdateSettings() echo.HandlerFunc
+}
@@ -0,0 +1,24 @@
+package reviews
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+type Repository interface {
+	// Create cards for learning words of the user which have none, returns number of created cards
+	CreateCards(ctx context.Context, userID uuid.UUID) (int64, error)
+	// Get cards of learning words due before given time, earliest first
+	GetDue(ctx context.Context, userID uuid.UUID, before time.Time, limit int) ([]*models.ReviewCard, error)
+	// Update card by schedule in one transaction with the review log
+	Review(
+		ctx context.Context, userID uuid.UUID, word string, log *models.ReviewLog,
+		schedule func(card *models.ReviewCard) error,
+	) (*models.ReviewCard, error)
+	GetSettings(ctx context.Context, userID uuid.UUID) (*models.ReviewSettings, error)
+	UpsertSettings(ctx context.Context, settings *models.ReviewSettings) (*models.ReviewSettings, error)
+}
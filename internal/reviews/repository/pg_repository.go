@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/internal/reviews"
+)
+
+type reviewsRepo struct {
+	db *sqlx.DB
+}
+
+func NewReviewsRepository(db *sqlx.DB) reviews.Repository {
+	return &reviewsRepo{db: db}
+}
+
+// Create cards for learning words without cards
+func (r *reviewsRepo) CreateCards(ctx context.Context, userID uuid.UUID) (int64, error) {
+	const op = "reviews.pg_repository.createCards"
+
+	query, args, err := createCardsQuery(userID)
+	if err != nil {
+		return 0, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%s.ExecContext: %w", op, err)
+	}
+
+	created, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s.RowsAffected: %w", op, err)
+	}
+
+	return created, nil
+}
+
+// Get due cards of learning words, cards of words marked known or ignored later are skipped
+func (r *reviewsRepo) GetDue(
+	ctx context.Context, userID uuid.UUID, before time.Time, limit int,
+) ([]*models.ReviewCard, error) {
+	const op = "reviews.pg_repository.getDue"
+
+	query, args, err := getDueQuery(userID, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	result := make([]*models.ReviewCard, 0)
+	if err = r.db.SelectContext(ctx, &result, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.SelectContext: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// Lock the card, update it by schedule and save the review log, concurrent answers to one card
+// are applied one after another
+func (r *reviewsRepo) Review(
+	ctx context.Context, userID uuid.UUID, word string, log *models.ReviewLog,
+	schedule func(card *models.ReviewCard) error,
+) (*models.ReviewCard, error) {
+	const op = "reviews.pg_repository.review"
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s.BeginTxx: %w", op, err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	query, args, err := getCardForUpdateQuery(userID, word)
+	if err != nil {
+		return nil, fmt.Errorf("%s.getCardQuery: %w", op, err)
+	}
+
+	card := &models.ReviewCard{}
+	if err = tx.GetContext(ctx, card, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.GetContext: %w", op, err)
+	}
+
+	if err = schedule(card); err != nil {
+		return nil, fmt.Errorf("%s.schedule: %w", op, err)
+	}
+
+	query, args, err = updateCardQuery(card)
+	if err != nil {
+		return nil, fmt.Errorf("%s.updateCardQuery: %w", op, err)
+	}
+
+	updated := &models.ReviewCard{}
+	if err = tx.GetContext(ctx, updated, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.GetContext: %w", op, err)
+	}
+
+	log.UserID, log.Word, log.Interval = userID, word, updated.Interval
+	query, args, err = createLogQuery(log)
+	if err != nil {
+		return nil, fmt.Errorf("%s.createLogQuery: %w", op, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.ExecContext: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s.Commit: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+// Get review settings of the user, sql.ErrNoRows when the user has none
+func (r *reviewsRepo) GetSettings(ctx context.Context, userID uuid.UUID) (*models.ReviewSettings, error) {
+	const op = "reviews.pg_repository.getSettings"
+
+	query, args, err := getSettingsQuery(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	settings := &models.ReviewSettings{}
+	if err = r.db.GetContext(ctx, settings, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.GetContext: %w", op, err)
+	}
+
+	return settings, nil
+}
+
+// Save review settings of the user
+func (r *reviewsRepo) UpsertSettings(
+	ctx context.Context, settings *models.ReviewSettings,
+) (*models.ReviewSettings, error) {
+	const op = "reviews.pg_repository.upsertSettings"
+
+	query, args, err := upsertSettingsQuery(settings)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	result := &models.ReviewSettings{}
+	if err = r.db.GetContext(ctx, result, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.GetContext: %w", op, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+func createCardsQuery(userID uuid.UUID) (string, []interface{}, error) {
+	learning := sq.Select("user_id", "word").From("user_words").Where(
+		sq.Eq{"user_id": userID, "state": models.WordStateLearning},
+	)
+
+	return sq.Insert("review_cards").Columns("user_id", "word").Select(learning).Suffix(
+		"ON CONFLICT (user_id, word) DO NOTHING",
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getDueQuery(userID uuid.UUID, before time.Time, limit int) (string, []interface{}, error) {
+	return sq.Select("c.*").From("review_cards c").Join(
+		"user_words w ON w.user_id = c.user_id AND w.word = c.word",
+	).Where(
+		sq.Eq{"c.user_id": userID, "w.state": models.WordStateLearning},
+	).Where(
+		sq.LtOrEq{"c.due_at": before},
+	).OrderBy("c.due_at", "c.word").Limit(uint64(limit)).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getCardForUpdateQuery(userID uuid.UUID, word string) (string, []interface{}, error) {
+	return sq.Select("*").From("review_cards").Where(
+		sq.Eq{"user_id": userID, "word": word},
+	).Suffix("FOR UPDATE").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func updateCardQuery(card *models.ReviewCard) (string, []interface{}, error) {
+	return sq.Update("review_cards").SetMap(
+		map[string]interface{}{
+			"ease":             card.Ease,
+			"stability":        card.Stability,
+			"difficulty":       card.Difficulty,
+			"interval_days":    card.Interval,
+			"repetitions":      card.Repetitions,
+			"lapses":           card.Lapses,
+			"due_at":           card.DueAt,
+			"last_reviewed_at": card.LastReviewedAt,
+		},
+	).Where(
+		sq.Eq{"user_id": card.UserID, "word": card.Word},
+	).Suffix("RETURNING *").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func createLogQuery(log *models.ReviewLog) (string, []interface{}, error) {
+	return sq.Insert("review_logs").Columns(
+		"user_id", "word", "algorithm", "grade", "interval_days", "reviewed_at",
+	).Values(
+		log.UserID, log.Word, log.Algorithm, log.Grade, log.Interval, log.ReviewedAt,
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getSettingsQuery(userID uuid.UUID) (string, []interface{}, error) {
+	return sq.Select("*").From("review_settings").Where("user_id = ?", userID).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func upsertSettingsQuery(settings *models.ReviewSettings) (string, []interface{}, error) {
+	return sq.Insert("review_settings").Columns("user_id", "algorithm", "updated_at").Values(
+		settings.UserID, settings.Algorithm, time.Now(),
+	).Suffix(
+		"ON CONFLICT (user_id) DO UPDATE SET algorithm = EXCLUDED.algorithm, updated_at = EXCLUDED.updated_at " +
+			"RETURNING *",
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
@@ -0,0 +1,18 @@
+package reviews
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+type UseCase interface {
+	// Get cards due now, cards are created for new learning words first
+	GetDue(ctx context.Context, userID uuid.UUID, limit int) ([]*models.ReviewCard, error)
+	// Grade the answer to a card and schedule its next review
+	Review(ctx context.Context, userID uuid.UUID, word string, grade int) (*models.ReviewCard, error)
+	GetSettings(ctx context.Context, userID uuid.UUID) (*models.ReviewSettings, error)
+	UpdateSettings(ctx context.Context, settings *models.ReviewSettings) (*models.ReviewSettings, error)
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/internal/reviews"
+	"github.com/shlembo598/text-lexicon-go/internal/translation"
+	"github.com/shlembo598/text-lexicon-go/pkg/logger/sl"
+	"github.com/shlembo598/text-lexicon-go/pkg/srs"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils/httpErrors"
+)
+
+type reviewsUC struct {
+	cfg         *config.Config
+	reviewsRepo reviews.Repository
+	translator  translation.Provider
+}
+
+func NewReviewsUseCase(
+	cfg *config.Config, reviewsRepo reviews.Repository, translator translation.Provider,
+) reviews.UseCase {
+	return &reviewsUC{cfg: cfg, reviewsRepo: reviewsRepo, translator: translator}
+}
+
+// Get cards due now with translations, words marked as learning since the last call get new cards
+func (u *reviewsUC) GetDue(ctx context.Context, userID uuid.UUID, limit int) ([]*models.ReviewCard, error) {
+	if limit <= 0 || limit > u.cfg.Reviews.MaxDueCards {
+		limit = u.cfg.Reviews.MaxDueCards
+	}
+
+	if _, err := u.reviewsRepo.CreateCards(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	cards, err := u.reviewsRepo.GetDue(ctx, userID, time.Now(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = translation.WithLookupLimit(ctx, u.cfg.Translation.MaxRemoteLookups)
+	for _, card := range cards {
+		t, err := u.translator.Lookup(ctx, card.Word)
+		if err != nil {
+			if !errors.Is(err, translation.ErrNotFound) && !errors.Is(err, translation.ErrLimitReached) {
+				slog.Warn("translation lookup", slog.String("word", card.Word), sl.Err(err))
+			}
+			continue
+		}
+		card.Translation = t
+	}
+
+	return cards, nil
+}
+
+// Schedule the next review of a card with the algorithm chosen by the user
+func (u *reviewsUC) Review(
+	ctx context.Context, userID uuid.UUID, word string, grade int,
+) (*models.ReviewCard, error) {
+	const op = "reviews.useCase.review"
+
+	if !srs.Grade(grade).Valid() {
+		return nil, httpErrors.NewBadRequestError(fmt.Errorf("%s: %w", op, srs.ErrInvalidGrade))
+	}
+
+	settings, err := u.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduler, err := srs.New(settings.Algorithm, u.cfg.Reviews.Retention)
+	if err != nil {
+		return nil, fmt.Errorf("%s.New: %w", op, err)
+	}
+
+	now := time.Now()
+	log := &models.ReviewLog{Algorithm: settings.Algorithm, Grade: grade, ReviewedAt: now}
+
+	return u.reviewsRepo.Review(
+		ctx, userID, models.NormalizeWord(word), log, func(card *models.ReviewCard) error {
+			next, err := scheduler.Schedule(cardState(card), srs.Grade(grade), now)
+			if err != nil {
+				return err
+			}
+			setCardState(card, next)
+
+			return nil
+		},
+	)
+}
+
+// Get review settings of the user, users without settings get the configured algorithm
+func (u *reviewsUC) GetSettings(ctx context.Context, userID uuid.UUID) (*models.ReviewSettings, error) {
+	settings, err := u.reviewsRepo.GetSettings(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &models.ReviewSettings{UserID: userID, Algorithm: u.cfg.Reviews.Algorithm}, nil
+	}
+
+	return settings, err
+}
+
+// Change review settings, cards keep their state and are scheduled by the new algorithm from the next answer
+func (u *reviewsUC) UpdateSettings(
+	ctx context.Context, settings *models.ReviewSettings,
+) (*models.ReviewSettings, error) {
+	const op = "reviews.useCase.updateSettings"
+
+	if _, err := srs.New(settings.Algorithm, u.cfg.Reviews.Retention); err != nil {
+		return nil, httpErrors.NewBadRequestError(fmt.Errorf("%s: %w", op, err))
+	}
+
+	return u.reviewsRepo.UpsertSettings(ctx, settings)
+}
+
+func cardState(card *models.ReviewCard) srs.Card {
+	return srs.Card{
+		Ease:        card.Ease,
+		Stability:   card.Stability,
+		Difficulty:  card.Difficulty,
+		Interval:    card.Interval,
+		Repetitions: card.Repetitions,
+		Lapses:      card.Lapses,
+		Due:         card.DueAt,
+		LastReview:  card.LastReviewedAt,
+	}
+}
+
+func setCardState(card *models.ReviewCard, state srs.Card) {
+	card.Ease = state.Ease
+	card.Stability = state.Stability
+	card.Difficulty = state.Difficulty
+	card.Interval = state.Interval
+	card.Repetitions = state.Repetitions
+	card.Lapses = state.Lapses
+	card.DueAt = state.Due
+	card.LastReviewedAt = state.LastReview
+}
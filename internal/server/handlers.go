@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
 	"strings"
@@ -13,18 +14,86 @@ import (
 	authHttp "github.com/shlembo598/text-lexicon-go/internal/auth/delivery/http"
 	authRepository "github.com/shlembo598/text-lexicon-go/internal/auth/repository"
 	authUseCase "github.com/shlembo598/text-lexicon-go/internal/auth/usecase"
+	collectionsHttp "github.com/shlembo598/text-lexicon-go/internal/collections/delivery/http"
+	collectionsRepository "github.com/shlembo598/text-lexicon-go/internal/collections/repository"
+	collectionsUseCase "github.com/shlembo598/text-lexicon-go/internal/collections/usecase"
+	dictionariesHttp "github.com/shlembo598/text-lexicon-go/internal/dictionaries/delivery/http"
+	dictionariesRepository "github.com/shlembo598/text-lexicon-go/internal/dictionaries/repository"
+	dictionariesUseCase "github.com/shlembo598/text-lexicon-go/internal/dictionaries/usecase"
+	documentsHttp "github.com/shlembo598/text-lexicon-go/internal/documents/delivery/http"
+	documentsRepository "github.com/shlembo598/text-lexicon-go/internal/documents/repository"
+	documentsUseCase "github.com/shlembo598/text-lexicon-go/internal/documents/usecase"
+	"github.com/shlembo598/text-lexicon-go/internal/jobs"
+	jobsHttp "github.com/shlembo598/text-lexicon-go/internal/jobs/delivery/http"
+	jobsRepository "github.com/shlembo598/text-lexicon-go/internal/jobs/repository"
+	jobsUseCase "github.com/shlembo598/text-lexicon-go/internal/jobs/usecase"
+	jobsWorker "github.com/shlembo598/text-lexicon-go/internal/jobs/worker"
 	apiMiddlewares "github.com/shlembo598/text-lexicon-go/internal/middleware"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	reviewsHttp "github.com/shlembo598/text-lexicon-go/internal/reviews/delivery/http"
+	reviewsRepository "github.com/shlembo598/text-lexicon-go/internal/reviews/repository"
+	reviewsUseCase "github.com/shlembo598/text-lexicon-go/internal/reviews/usecase"
+	wordsHttp "github.com/shlembo598/text-lexicon-go/internal/words/delivery/http"
+	wordsRepository "github.com/shlembo598/text-lexicon-go/internal/words/repository"
+	wordsUseCase "github.com/shlembo598/text-lexicon-go/internal/words/usecase"
+	"github.com/shlembo598/text-lexicon-go/pkg/fetcher"
+	"github.com/shlembo598/text-lexicon-go/pkg/logger/sl"
 )
 
 func (s *Server) MapHandlers(e *echo.Echo) error {
 	// Init repositories
 	authRepo := authRepository.NewAuthRepository(s.db)
+	documentsRepo := documentsRepository.NewDocumentsRepository(s.db)
+	dictionariesRepo := dictionariesRepository.NewDictionariesRepository(s.cfg, s.db)
+	wordsRepo := wordsRepository.NewWordsRepository(s.db)
+	reviewsRepo := reviewsRepository.NewReviewsRepository(s.db)
+	jobsRepo := jobsRepository.NewJobsRepository(s.db)
+	collectionsRepo := collectionsRepository.NewCollectionsRepository(s.db)
+
+	// Init clients
+	pageFetcher := fetcher.NewHTTPFetcher(&http.Client{Timeout: s.cfg.Documents.FetchTimeout}, s.cfg)
+	translationProvider, err := s.newTranslationProvider()
+	if err != nil {
+		return err
+	}
 
 	// Init useCases
 	authUC := authUseCase.NewAuthUserCase(s.cfg, authRepo)
+	wordsUC := wordsUseCase.NewWordsUseCase(s.cfg, wordsRepo)
+	jobsUC := jobsUseCase.NewJobsUseCase(s.cfg, jobsRepo)
+	documentsUC := documentsUseCase.NewDocumentsUseCase(
+		s.cfg, documentsRepo, pageFetcher, translationProvider, wordsUC, jobsUC,
+	)
+	dictionariesUC := dictionariesUseCase.NewDictionariesUseCase(s.cfg, dictionariesRepo, jobsUC, translationProvider)
+	reviewsUC := reviewsUseCase.NewReviewsUseCase(s.cfg, reviewsRepo, translationProvider)
+	collectionsUC := collectionsUseCase.NewCollectionsUseCase(s.cfg, collectionsRepo, documentsUC, jobsUC, pageFetcher)
 
 	// Init handlers
 	authHandlers := authHttp.NewAuthHandlers(s.cfg, authUC)
+	documentsHandlers := documentsHttp.NewDocumentsHandlers(s.cfg, documentsUC)
+	dictionariesHandlers := dictionariesHttp.NewDictionariesHandlers(s.cfg, dictionariesUC)
+	wordsHandlers := wordsHttp.NewWordsHandlers(s.cfg, wordsUC)
+	reviewsHandlers := reviewsHttp.NewReviewsHandlers(s.cfg, reviewsUC)
+	jobsHandlers := jobsHttp.NewJobsHandlers(s.cfg, jobsUC)
+	collectionsHandlers := collectionsHttp.NewCollectionsHandlers(s.cfg, collectionsUC)
+
+	// Init job workers, started by Run
+	s.workers = jobsWorker.NewPool(
+		s.cfg, jobsRepo, map[string]jobs.Handler{
+			models.JobKindCreateDocument:   documentsUC.Process,
+			models.JobKindCrawlCollection:  collectionsUC.ProcessCrawl,
+			models.JobKindImportCollection: collectionsUC.ProcessImport,
+			models.JobKindImportDictionary: dictionariesUC.ProcessImport,
+		},
+	)
+	s.workers.SetTimeout(models.JobKindCrawlCollection, s.cfg.Crawler.Timeout)
+	s.workers.SetTimeout(models.JobKindImportCollection, s.cfg.Imports.Timeout)
+	s.workers.SetTimeout(models.JobKindImportDictionary, s.cfg.Dictionaries.ImportTimeout)
+
+	// Imports stopped along with the process before they were jobs keep their dictionaries importing
+	if err = dictionariesUC.FailInterrupted(context.Background()); err != nil {
+		slog.Error("dictionary imports check", sl.Err(err))
+	}
 
 	// Init middleware
 	mw := apiMiddlewares.NewMiddlewareManager(authUC, s.cfg, []string{"*"})
@@ -65,7 +134,17 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 		),
 	)
 	e.Use(middleware.Secure())
-	e.Use(middleware.BodyLimit("2M"))
+	e.Use(
+		middleware.BodyLimitWithConfig(
+			middleware.BodyLimitConfig{
+				Limit: "2M",
+				// File uploads have larger limits set on their routes
+				Skipper: func(c echo.Context) bool {
+					return strings.HasSuffix(c.Path(), "/upload")
+				},
+			},
+		),
+	)
 	if s.cfg.Server.Debug {
 		e.Use(mw.DebugMiddleware)
 	}
@@ -74,8 +153,20 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 
 	health := v1.Group("/health")
 	authGroup := v1.Group("/auth")
+	collectionsGroup := v1.Group("/collections")
+	documentsGroup := v1.Group("/documents")
+	dictionariesGroup := v1.Group("/admin/dictionaries")
+	wordsGroup := v1.Group("/words")
+	reviewsGroup := v1.Group("/reviews")
+	jobsGroup := v1.Group("/jobs")
 
 	authHttp.MapAuthRoutes(authGroup, authHandlers, mw, authUC, s.cfg)
+	collectionsHttp.MapCollectionsRoutes(collectionsGroup, collectionsHandlers, mw, authUC, s.cfg)
+	documentsHttp.MapDocumentsRoutes(documentsGroup, documentsHandlers, mw, authUC, s.cfg)
+	dictionariesHttp.MapDictionariesRoutes(dictionariesGroup, dictionariesHandlers, mw, authUC, s.cfg)
+	wordsHttp.MapWordsRoutes(wordsGroup, wordsHandlers, mw, authUC, s.cfg)
+	reviewsHttp.MapReviewsRoutes(reviewsGroup, reviewsHandlers, mw, authUC, s.cfg)
+	jobsHttp.MapJobsRoutes(jobsGroup, jobsHandlers, mw, authUC, s.cfg)
 
 	health.GET(
 		"", func(c echo.Context) error {
@@ -13,6 +13,7 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/jobs/worker"
 	"github.com/shlembo598/text-lexicon-go/pkg/logger/sl"
 )
 
@@ -21,9 +22,10 @@ const (
 )
 
 type Server struct {
-	echo *echo.Echo
-	cfg  *config.Config
-	db   *sqlx.DB
+	echo    *echo.Echo
+	cfg     *config.Config
+	db      *sqlx.DB
+	workers *worker.Pool
 }
 
 func NewServer(cfg *config.Config, db *sqlx.DB) *Server {
@@ -46,6 +48,8 @@ func (s *Server) Run() error {
 		IdleTimeout:  s.cfg.Server.IdleTimeout,
 	}
 
+	s.workers.Start()
+
 	go func() {
 		slog.Info("server is listening on PORT", slog.String("port", s.cfg.Server.Port))
 		if err := s.echo.StartServer(server); err != nil {
@@ -70,6 +74,18 @@ func (s *Server) Run() error {
 	ctx, shutdown := context.WithTimeout(context.Background(), ctxTimeout*time.Second)
 	defer shutdown()
 
+	if err := s.echo.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	// Running jobs get their own time to finish, unfinished ones are returned to the queue
+	workersCtx, stopWorkers := context.WithTimeout(context.Background(), s.cfg.Jobs.ShutdownTimeout)
+	defer stopWorkers()
+
+	if err := s.workers.Shutdown(workersCtx); err != nil {
+		slog.Warn("job workers did not finish in time", sl.Err(err))
+	}
+
 	slog.Info("server Exited Properly")
-	return s.echo.Shutdown(ctx)
+	return nil
 }
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/shlembo598/text-lexicon-go/internal/translation"
+	"github.com/shlembo598/text-lexicon-go/internal/translation/libretranslate"
+	"github.com/shlembo598/text-lexicon-go/internal/translation/offline"
+	translationRepository "github.com/shlembo598/text-lexicon-go/internal/translation/repository"
+)
+
+var ErrUnknownTranslationProvider = errors.New("unknown translation provider")
+
+// Build translation providers chain in the order given by config
+func (s *Server) newTranslationProvider() (translation.Cache, error) {
+	const op = "server.newTranslationProvider"
+
+	providers := make([]translation.Provider, 0, len(s.cfg.Translation.Providers))
+	for _, name := range s.cfg.Translation.Providers {
+		switch name {
+		case translation.ProviderOffline:
+			providers = append(
+				providers, offline.NewOfflineProvider(translationRepository.NewTranslationRepository(s.db)),
+			)
+		case translation.ProviderHTTP:
+			providers = append(
+				providers, libretranslate.NewLibreTranslateProvider(
+					&http.Client{Timeout: s.cfg.Translation.HTTP.Timeout}, s.cfg,
+				),
+			)
+		default:
+			return nil, fmt.Errorf("%s: %w: %s", op, ErrUnknownTranslationProvider, name)
+		}
+	}
+
+	return translation.NewCache(
+		translation.NewChain(providers...), s.cfg.Translation.CacheSize, s.cfg.Translation.MissTTL,
+	), nil
+}
@@ -0,0 +1,74 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+// Provider caching results of another one
+type Cache interface {
+	Provider
+	// Drop cached results, for example when an imported dictionary knows more words
+	Clear()
+}
+
+type cache struct {
+	provider Provider
+	size     int
+	missTTL  time.Duration
+
+	mu      sync.RWMutex
+	results map[string]cached
+}
+
+// Result of a lookup, misses expire so words are found once a dictionary knows them
+type cached struct {
+	translation *models.Translation
+	expires     time.Time
+}
+
+// In-memory cache of provider results, misses are cached for missTTL so unknown words are not looked up
+// on every request. The cache is cleared when it grows over size entries.
+func NewCache(provider Provider, size int, missTTL time.Duration) Cache {
+	return &cache{provider: provider, size: size, missTTL: missTTL, results: make(map[string]cached)}
+}
+
+func (c *cache) Name() string {
+	return c.provider.Name()
+}
+
+func (c *cache) Lookup(ctx context.Context, lemma string) (*models.Translation, error) {
+	c.mu.RLock()
+	result, ok := c.results[lemma]
+	c.mu.RUnlock()
+	if ok && result.translation != nil {
+		return result.translation, nil
+	}
+	if ok && time.Now().Before(result.expires) {
+		return nil, ErrNotFound
+	}
+
+	t, err := c.provider.Lookup(ctx, lemma)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if len(c.results) >= c.size {
+		c.results = make(map[string]cached)
+	}
+	c.results[lemma] = cached{translation: t, expires: time.Now().Add(c.missTTL)}
+	c.mu.Unlock()
+
+	return t, err
+}
+
+func (c *cache) Clear() {
+	c.mu.Lock()
+	c.results = make(map[string]cached)
+	c.mu.Unlock()
+}
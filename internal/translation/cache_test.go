@@ -0,0 +1,89 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCacheLookup(t *testing.T) {
+	failure := errors.New("connection refused")
+
+	tests := []struct {
+		name     string
+		provider *fakeProvider
+		missTTL  time.Duration
+		lemma    string
+		// Provider lookups after looking the lemma up twice
+		lookups int
+		err     error
+	}{
+		{
+			name:     "translation is cached",
+			provider: &fakeProvider{known: map[string]string{"queue": "очередь"}},
+			lemma:    "queue", lookups: 1,
+		},
+		{
+			name: "miss is cached", provider: &fakeProvider{}, missTTL: time.Hour,
+			lemma: "queue", lookups: 1, err: ErrNotFound,
+		},
+		{
+			name: "expired miss is looked up again", provider: &fakeProvider{},
+			lemma: "queue", lookups: 2, err: ErrNotFound,
+		},
+		{
+			name: "failure is not cached", provider: &fakeProvider{err: failure}, missTTL: time.Hour,
+			lemma: "queue", lookups: 2, err: failure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCache(tt.provider, 10, tt.missTTL)
+			for i := 0; i < 2; i++ {
+				if _, err := c.Lookup(context.Background(), tt.lemma); !errors.Is(err, tt.err) {
+					t.Fatalf("Lookup() error = %v, want %v", err, tt.err)
+				}
+			}
+			if tt.provider.lookups != tt.lookups {
+				t.Errorf("provider lookups = %d, want %d", tt.provider.lookups, tt.lookups)
+			}
+		})
+	}
+}
+
+func TestCacheClear(t *testing.T) {
+	provider := &fakeProvider{}
+	c := NewCache(provider, 10, time.Hour)
+
+	if _, err := c.Lookup(context.Background(), "queue"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Lookup() error = %v, want %v", err, ErrNotFound)
+	}
+	// An imported dictionary knows the word now
+	provider.known = map[string]string{"queue": "очередь"}
+	c.Clear()
+
+	got, err := c.Lookup(context.Background(), "queue")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if got.Senses[0].Translations[0] != "очередь" {
+		t.Errorf("Lookup() = %+v, want the imported translation", got.Senses[0])
+	}
+}
+
+func TestCacheSize(t *testing.T) {
+	provider := &fakeProvider{known: map[string]string{"queue": "очередь", "job": "задача", "worker": "рабочий"}}
+	c := NewCache(provider, 2, time.Hour)
+
+	for _, lemma := range []string{"queue", "job", "worker", "queue"} {
+		if _, err := c.Lookup(context.Background(), lemma); err != nil {
+			t.Fatalf("Lookup(%q) error = %v", lemma, err)
+		}
+	}
+	// The third lemma clears the full cache, so the first one is looked up again
+	if provider.lookups != 4 {
+		t.Errorf("provider lookups = %d, want 4", provider.lookups)
+	}
+}
@@ -0,0 +1,58 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/pkg/logger/sl"
+)
+
+type chain struct {
+	providers []Provider
+}
+
+// Chain of providers, the first one which knows the lemma wins
+func NewChain(providers ...Provider) Provider {
+	return &chain{providers: providers}
+}
+
+func (c *chain) Name() string {
+	names := make([]string, 0, len(c.providers))
+	for _, p := range c.providers {
+		names = append(names, p.Name())
+	}
+
+	return strings.Join(names, ",")
+}
+
+// Lookup lemma in every provider in order, failing providers are skipped
+func (c *chain) Lookup(ctx context.Context, lemma string) (*models.Translation, error) {
+	const op = "translation.chain.lookup"
+
+	var lastErr error
+	for _, p := range c.providers {
+		t, err := p.Lookup(ctx, lemma)
+		if err == nil {
+			return t, nil
+		}
+
+		if errors.Is(err, ErrLimitReached) {
+			lastErr = err
+			continue
+		}
+		if !errors.Is(err, ErrNotFound) {
+			slog.Warn("translation provider failed", slog.String("provider", p.Name()), sl.Err(err))
+			lastErr = err
+		}
+	}
+
+	if lastErr != nil {
+		return nil, fmt.Errorf("%s: %w", op, lastErr)
+	}
+
+	return nil, fmt.Errorf("%s: %w", op, ErrNotFound)
+}
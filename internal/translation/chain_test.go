@@ -0,0 +1,108 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+// Provider knowing a fixed set of lemmas, failing with err for other ones when err is set
+type fakeProvider struct {
+	name    string
+	known   map[string]string
+	err     error
+	lookups int
+}
+
+func (f *fakeProvider) Name() string {
+	return f.name
+}
+
+func (f *fakeProvider) Lookup(_ context.Context, lemma string) (*models.Translation, error) {
+	f.lookups++
+	if translation, ok := f.known[lemma]; ok {
+		return &models.Translation{
+			Provider: f.name, Senses: []*models.Sense{{Translations: []string{translation}}},
+		}, nil
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return nil, ErrNotFound
+}
+
+func TestChainLookup(t *testing.T) {
+	failure := errors.New("connection refused")
+
+	tests := []struct {
+		name      string
+		providers []Provider
+		lemma     string
+		want      string
+		err       error
+	}{
+		{
+			name: "first provider wins",
+			providers: []Provider{
+				&fakeProvider{name: "offline", known: map[string]string{"queue": "очередь"}},
+				&fakeProvider{name: "http", known: map[string]string{"queue": "хвост"}},
+			},
+			lemma: "queue", want: "offline",
+		},
+		{
+			name: "next provider knows the lemma",
+			providers: []Provider{
+				&fakeProvider{name: "offline"},
+				&fakeProvider{name: "http", known: map[string]string{"queue": "очередь"}},
+			},
+			lemma: "queue", want: "http",
+		},
+		{
+			name: "failing provider is skipped",
+			providers: []Provider{
+				&fakeProvider{name: "http", err: failure},
+				&fakeProvider{name: "offline", known: map[string]string{"queue": "очередь"}},
+			},
+			lemma: "queue", want: "offline",
+		},
+		{
+			name:      "unknown lemma",
+			providers: []Provider{&fakeProvider{name: "offline"}, &fakeProvider{name: "http"}},
+			lemma:     "queue", err: ErrNotFound,
+		},
+		{
+			name:      "failure is reported over a miss",
+			providers: []Provider{&fakeProvider{name: "http", err: failure}, &fakeProvider{name: "offline"}},
+			lemma:     "queue", err: failure,
+		},
+		{
+			name: "limit is reported over a miss",
+			providers: []Provider{
+				&fakeProvider{name: "offline"}, &fakeProvider{name: "http", err: ErrLimitReached},
+			},
+			lemma: "queue", err: ErrLimitReached,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewChain(tt.providers...).Lookup(context.Background(), tt.lemma)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Lookup() error = %v, want %v", err, tt.err)
+			}
+			if tt.err == nil && got.Provider != tt.want {
+				t.Errorf("Lookup() provider = %q, want %q", got.Provider, tt.want)
+			}
+		})
+	}
+}
+
+func TestChainName(t *testing.T) {
+	chain := NewChain(&fakeProvider{name: "offline"}, &fakeProvider{name: "http"})
+	if got := chain.Name(); got != "offline,http" {
+		t.Errorf("Name() = %q, want %q", got, "offline,http")
+	}
+}
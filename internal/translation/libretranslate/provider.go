@@ -0,0 +1,112 @@
+package libretranslate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/internal/translation"
+)
+
+// Number of alternative translations requested besides the main one
+const alternatives = 3
+
+var ErrUnexpectedStatus = errors.New("unexpected response status")
+
+type libreTranslateProvider struct {
+	client *http.Client
+	cfg    *config.Config
+}
+
+// Provider for LibreTranslate compatible http api, client is injected so it can be pointed to a local stand-in
+func NewLibreTranslateProvider(client *http.Client, cfg *config.Config) translation.Provider {
+	return &libreTranslateProvider{client: client, cfg: cfg}
+}
+
+type translateRequest struct {
+	Q            string `json:"q"`
+	Source       string `json:"source"`
+	Target       string `json:"target"`
+	Format       string `json:"format"`
+	Alternatives int    `json:"alternatives,omitempty"`
+	APIKey       string `json:"api_key,omitempty"`
+}
+
+type translateResponse struct {
+	TranslatedText string   `json:"translatedText"`
+	Alternatives   []string `json:"alternatives"`
+	Error          string   `json:"error"`
+}
+
+func (p *libreTranslateProvider) Name() string {
+	return translation.ProviderHTTP
+}
+
+func (p *libreTranslateProvider) Lookup(ctx context.Context, lemma string) (*models.Translation, error) {
+	const op = "translation.libretranslate.lookup"
+
+	if !translation.TakeLookup(ctx) {
+		return nil, fmt.Errorf("%s: %w", op, translation.ErrLimitReached)
+	}
+
+	body, err := json.Marshal(
+		&translateRequest{
+			Q:            lemma,
+			Source:       p.cfg.Translation.SourceLanguage,
+			Target:       p.cfg.Translation.TargetLanguage,
+			Format:       "text",
+			Alternatives: alternatives,
+			APIKey:       p.cfg.Translation.HTTP.APIKey,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s.Marshal: %w", op, err)
+	}
+
+	url := strings.TrimSuffix(p.cfg.Translation.HTTP.URL, "/") + "/translate"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%s.NewRequest: %w", op, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s.Do: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	result := &translateResponse{}
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, fmt.Errorf("%s.Decode: %w", op, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w: %d %s", op, ErrUnexpectedStatus, resp.StatusCode, result.Error)
+	}
+
+	translations := make([]string, 0, 1+len(result.Alternatives))
+	seen := make(map[string]bool)
+	for _, t := range append([]string{result.TranslatedText}, result.Alternatives...) {
+		t = strings.TrimSpace(t)
+		// Machine translation echoes words it does not know
+		if t == "" || strings.EqualFold(t, lemma) || seen[t] {
+			continue
+		}
+		seen[t] = true
+		translations = append(translations, t)
+	}
+	if len(translations) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, translation.ErrNotFound)
+	}
+
+	return &models.Translation{
+		Provider: translation.ProviderHTTP,
+		Senses:   []*models.Sense{{Translations: translations}},
+	}, nil
+}
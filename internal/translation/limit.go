@@ -0,0 +1,33 @@
+package translation
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type limitKey struct{}
+
+// Context allowing at most n lookups of remote providers, lookups over the limit fail with ErrLimitReached.
+// Requests translating many words stay within their timeout this way. A context with a limit keeps it, so
+// the limit is set once per request.
+func WithLookupLimit(ctx context.Context, n int) context.Context {
+	if _, ok := ctx.Value(limitKey{}).(*atomic.Int64); ok {
+		return ctx
+	}
+
+	remaining := &atomic.Int64{}
+	remaining.Store(int64(n))
+
+	return context.WithValue(ctx, limitKey{}, remaining)
+}
+
+// Take one lookup of the context limit, false when the limit is reached. Contexts without a limit allow any
+// number of lookups.
+func TakeLookup(ctx context.Context) bool {
+	remaining, ok := ctx.Value(limitKey{}).(*atomic.Int64)
+	if !ok {
+		return true
+	}
+
+	return remaining.Add(-1) >= 0
+}
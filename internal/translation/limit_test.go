@@ -0,0 +1,47 @@
+package translation
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTakeLookup(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		// Results of taking lookups one after another
+		want []bool
+	}{
+		{name: "no limit", ctx: context.Background(), want: []bool{true, true, true}},
+		{name: "limit", ctx: WithLookupLimit(context.Background(), 2), want: []bool{true, true, false, false}},
+		{name: "zero limit", ctx: WithLookupLimit(context.Background(), 0), want: []bool{false}},
+		{
+			name: "limit is set once",
+			ctx:  WithLookupLimit(WithLookupLimit(context.Background(), 1), 5),
+			want: []bool{true, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, want := range tt.want {
+				if got := TakeLookup(tt.ctx); got != want {
+					t.Errorf("TakeLookup() #%d = %v, want %v", i+1, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLookupLimitIsShared(t *testing.T) {
+	ctx := WithLookupLimit(context.Background(), 2)
+	// Translations of entries and acronyms of one request take from the same limit
+	entries, acronyms := WithLookupLimit(ctx, 2), WithLookupLimit(ctx, 2)
+
+	if !TakeLookup(entries) || !TakeLookup(acronyms) {
+		t.Fatal("TakeLookup() = false within the limit")
+	}
+	if TakeLookup(entries) || TakeLookup(acronyms) {
+		t.Error("TakeLookup() = true over the shared limit")
+	}
+}
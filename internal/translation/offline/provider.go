@@ -0,0 +1,25 @@
+package offline
+
+import (
+	"context"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/internal/translation"
+)
+
+type offlineProvider struct {
+	translationRepo translation.Repository
+}
+
+// Provider backed by dictionaries imported into postgres
+func NewOfflineProvider(translationRepo translation.Repository) translation.Provider {
+	return &offlineProvider{translationRepo: translationRepo}
+}
+
+func (p *offlineProvider) Name() string {
+	return translation.ProviderOffline
+}
+
+func (p *offlineProvider) Lookup(ctx context.Context, lemma string) (*models.Translation, error) {
+	return p.translationRepo.FindByHeadword(ctx, lemma)
+}
@@ -0,0 +1,11 @@
+package translation
+
+import (
+	"context"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+type Repository interface {
+	FindByHeadword(ctx context.Context, headword string) (*models.Translation, error)
+}
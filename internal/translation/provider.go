@@ -0,0 +1,25 @@
+package translation
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+const (
+	ProviderOffline = "offline"
+	ProviderHTTP    = "http"
+)
+
+var (
+	ErrNotFound = errors.New("translation not found")
+	// Remote lookups allowed by the context are used up
+	ErrLimitReached = errors.New("translation lookup limit reached")
+)
+
+// Provider looks up translations of an english lemma
+type Provider interface {
+	Name() string
+	Lookup(ctx context.Context, lemma string) (*models.Translation, error)
+}
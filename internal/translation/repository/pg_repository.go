@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/internal/translation"
+)
+
+// Separator of translation variants stored in one sense
+const translationsSeparator = ";"
+
+type translationRepo struct {
+	db *sqlx.DB
+}
+
+func NewTranslationRepository(db *sqlx.DB) translation.Repository {
+	return &translationRepo{db: db}
+}
+
+type headwordRow struct {
+	HeadwordID    int64  `db:"headword_id"`
+	Transcription string `db:"transcription"`
+}
+
+type senseRow struct {
+	SenseID      int64  `db:"sense_id"`
+	PartOfSpeech string `db:"part_of_speech"`
+	Translations string `db:"translations"`
+}
+
+// Find senses of a headword in all imported dictionaries
+func (r *translationRepo) FindByHeadword(ctx context.Context, headword string) (*models.Translation, error) {
+	const op = "translation.pg_repository.findByHeadword"
+
+	query, args, err := findHeadwordsQuery(headword)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	headwords := make([]*headwordRow, 0)
+	if err = r.db.SelectContext(ctx, &headwords, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.SelectContext: %w", op, err)
+	}
+	if len(headwords) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, translation.ErrNotFound)
+	}
+
+	result := &models.Translation{Provider: translation.ProviderOffline, Senses: make([]*models.Sense, 0)}
+	headwordIDs := make([]int64, 0, len(headwords))
+	for _, h := range headwords {
+		headwordIDs = append(headwordIDs, h.HeadwordID)
+		if result.Transcription == "" {
+			result.Transcription = h.Transcription
+		}
+	}
+
+	query, args, err = findSensesQuery(headwordIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s.sensesQuery: %w", op, err)
+	}
+
+	senses := make([]*senseRow, 0)
+	if err = r.db.SelectContext(ctx, &senses, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.SelectContext: %w", op, err)
+	}
+	if len(senses) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, translation.ErrNotFound)
+	}
+
+	bySenseID := make(map[int64]*models.Sense, len(senses))
+	senseIDs := make([]int64, 0, len(senses))
+	for _, s := range senses {
+		sense := &models.Sense{
+			SenseID:      s.SenseID,
+			PartOfSpeech: s.PartOfSpeech,
+			Translations: splitTranslations(s.Translations),
+			Examples:     make([]*models.Example, 0),
+		}
+		result.Senses = append(result.Senses, sense)
+		bySenseID[s.SenseID] = sense
+		senseIDs = append(senseIDs, s.SenseID)
+	}
+
+	query, args, err = findExamplesQuery(senseIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s.examplesQuery: %w", op, err)
+	}
+
+	examples := make([]*models.Example, 0)
+	if err = r.db.SelectContext(ctx, &examples, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.SelectContext: %w", op, err)
+	}
+	for _, e := range examples {
+		if sense, ok := bySenseID[e.SenseID]; ok {
+			sense.Examples = append(sense.Examples, e)
+		}
+	}
+
+	return result, nil
+}
+
+func splitTranslations(s string) []string {
+	result := make([]string, 0)
+	for _, t := range strings.Split(s, translationsSeparator) {
+		if t = strings.TrimSpace(t); t != "" {
+			result = append(result, t)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	sq "github.com/Masterminds/squirrel"
+)
+
+func findHeadwordsQuery(headword string) (string, []interface{}, error) {
+	return sq.Select("headword_id", "COALESCE(transcription, '') AS transcription").From(
+		"dictionary_headwords",
+	).Where("LOWER(headword) = LOWER(?)", headword).OrderBy("headword_id").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func findSensesQuery(headwordIDs []int64) (string, []interface{}, error) {
+	return sq.Select(
+		"sense_id", "COALESCE(part_of_speech, '') AS part_of_speech", "translations",
+	).From("dictionary_senses").Where(
+		sq.Eq{"headword_id": headwordIDs},
+	).OrderBy("headword_id", "position").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func findExamplesQuery(senseIDs []int64) (string, []interface{}, error) {
+	return sq.Select(
+		"sense_id", "text", "COALESCE(translation, '') AS translation",
+	).From("dictionary_examples").Where(
+		sq.Eq{"sense_id": senseIDs},
+	).OrderBy("example_id").PlaceholderFormat(sq.Dollar).ToSql()
+}
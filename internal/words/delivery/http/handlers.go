@@ -0,0 +1,144 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/internal/words"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils/httpErrors"
+	r "github.com/shlembo598/text-lexicon-go/pkg/utils/responses"
+)
+
+var ErrInvalidState = errors.New("state must be one of known, learning, ignored, name")
+
+type wordsHandlers struct {
+	cfg     *config.Config
+	wordsUC words.UseCase
+}
+
+func NewWordsHandlers(cfg *config.Config, wordsUC words.UseCase) words.Handlers {
+	return &wordsHandlers{cfg: cfg, wordsUC: wordsUC}
+}
+
+// Mark godoc
+// @Summary Mark words
+// @Description set state of words in the vocabulary of current user, known and ignored words
+// @Description are hidden from generated dictionaries, names go to the names of dictionaries
+// @Tags Words
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.UserWord
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Router /words [put]
+func (h *wordsHandlers) Mark() echo.HandlerFunc {
+	type MarkWords struct {
+		Words []string `json:"words" validate:"required,min=1,max=10000,dive,required,lte=128"`
+		State string   `json:"state" validate:"required,oneof=known learning ignored name"`
+	}
+
+	return func(c echo.Context) error {
+		request := &MarkWords{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		ctx := utils.GetRequestCtx(c)
+		user, err := utils.GetUserFromCtx(ctx)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(httpErrors.NewUnauthorizedError(err)))
+		}
+
+		result, err := h.wordsUC.Mark(ctx, user.UserID, request.Words, request.State)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, r.SuccessResponse(result))
+	}
+}
+
+// Unmark godoc
+// @Summary Unmark words
+// @Description remove words from the vocabulary of current user, returns number of removed words
+// @Tags Words
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string]int64
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Router /words [delete]
+func (h *wordsHandlers) Unmark() echo.HandlerFunc {
+	type UnmarkWords struct {
+		Words []string `json:"words" validate:"required,min=1,max=10000,dive,required,lte=128"`
+	}
+
+	return func(c echo.Context) error {
+		request := &UnmarkWords{}
+		if err := utils.ReadRequest(c, request); err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		ctx := utils.GetRequestCtx(c)
+		user, err := utils.GetUserFromCtx(ctx)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(httpErrors.NewUnauthorizedError(err)))
+		}
+
+		deleted, err := h.wordsUC.Unmark(ctx, user.UserID, request.Words)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, r.SuccessResponse(map[string]int64{"deleted": deleted}))
+	}
+}
+
+// GetAll godoc
+// @Summary Get words
+// @Description get vocabulary of current user, optionally filtered by state
+// @Tags Words
+// @Accept json
+// @Produce json
+// @Param state query string false "known, learning, ignored or name"
+// @Success 200 {array} models.UserWord
+// @Failure 401 {object} httpErrors.RestError
+// @Router /words [get]
+func (h *wordsHandlers) GetAll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		state := c.QueryParam("state")
+		switch state {
+		case "", models.WordStateKnown, models.WordStateLearning, models.WordStateIgnored, models.WordStateName:
+		default:
+			err := httpErrors.NewBadRequestError(ErrInvalidState)
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		ctx := utils.GetRequestCtx(c)
+		user, err := utils.GetUserFromCtx(ctx)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(httpErrors.NewUnauthorizedError(err)))
+		}
+
+		result, err := h.wordsUC.GetAll(ctx, user.UserID, state)
+		if err != nil {
+			utils.LogResponseError(c, err)
+			return c.JSON(r.ErrorResponse(err))
+		}
+
+		return c.JSON(http.StatusOK, r.SuccessResponse(result))
+	}
+}
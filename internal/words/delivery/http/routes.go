@@ -0,0 +1,20 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/shlembo598/text-lexicon-go/internal/auth"
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/middleware"
+	"github.com/shlembo598/text-lexicon-go/internal/words"
+)
+
+func MapWordsRoutes(
+	wordsGroup *echo.Group, h words.Handlers, mw *middleware.MiddlewareManager, authUC auth.UseCase,
+	cfg *config.Config,
+) {
+	wordsGroup.Use(mw.AuthJWTMiddleware(authUC, cfg))
+	wordsGroup.GET("", h.GetAll())
+	wordsGroup.PUT("", h.Mark())
+	wordsGroup.DELETE("", h.Unmark())
+}
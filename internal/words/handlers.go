@@ -0,0 +1,11 @@
+package words
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+type Handlers interface {
+	Mark() echo.HandlerFunc
+	Unmark() echo.HandlerFunc
+	GetAll() echo.HandlerFunc
+}
@@ -0,0 +1,17 @@
+package words
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+type Repository interface {
+	Upsert(ctx context.Context, userID uuid.UUID, words []string, state string) ([]*models.UserWord, error)
+	Delete(ctx context.Context, userID uuid.UUID, words []string) (int64, error)
+	GetAll(ctx context.Context, userID uuid.UUID, state string) ([]*models.UserWord, error)
+	// Get states of given words, words without state are not returned
+	GetStates(ctx context.Context, userID uuid.UUID, words []string) (map[string]string, error)
+}
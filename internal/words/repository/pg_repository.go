@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/internal/words"
+)
+
+// Postgres caps a statement at 65535 bind parameters, so words are processed in chunks
+const wordsChunkSize = 1000
+
+type wordsRepo struct {
+	db *sqlx.DB
+}
+
+func NewWordsRepository(db *sqlx.DB) words.Repository {
+	return &wordsRepo{db: db}
+}
+
+// Set state of words
+func (r *wordsRepo) Upsert(
+	ctx context.Context, userID uuid.UUID, words []string, state string,
+) ([]*models.UserWord, error) {
+	const op = "words.pg_repository.upsert"
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s.BeginTxx: %w", op, err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	result := make([]*models.UserWord, 0, len(words))
+	for start := 0; start < len(words); start += wordsChunkSize {
+		query, args, err := upsertWordsQuery(userID, words[start:min(start+wordsChunkSize, len(words))], state)
+		if err != nil {
+			return nil, fmt.Errorf("%s.query: %w", op, err)
+		}
+
+		chunk := make([]*models.UserWord, 0)
+		if err = tx.SelectContext(ctx, &chunk, query, args...); err != nil {
+			return nil, fmt.Errorf("%s.SelectContext: %w", op, err)
+		}
+		result = append(result, chunk...)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s.Commit: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// Remove words from user vocabulary, returns number of removed words
+func (r *wordsRepo) Delete(ctx context.Context, userID uuid.UUID, words []string) (int64, error) {
+	const op = "words.pg_repository.delete"
+
+	var deleted int64
+	for start := 0; start < len(words); start += wordsChunkSize {
+		query, args, err := deleteWordsQuery(userID, words[start:min(start+wordsChunkSize, len(words))])
+		if err != nil {
+			return 0, fmt.Errorf("%s.query: %w", op, err)
+		}
+
+		result, err := r.db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return 0, fmt.Errorf("%s.ExecContext: %w", op, err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("%s.RowsAffected: %w", op, err)
+		}
+		deleted += rows
+	}
+
+	return deleted, nil
+}
+
+// Get user words in given state, all words when state is empty
+func (r *wordsRepo) GetAll(ctx context.Context, userID uuid.UUID, state string) ([]*models.UserWord, error) {
+	const op = "words.pg_repository.getAll"
+
+	query, args, err := getWordsQuery(userID, state)
+	if err != nil {
+		return nil, fmt.Errorf("%s.query: %w", op, err)
+	}
+
+	result := make([]*models.UserWord, 0)
+	if err = r.db.SelectContext(ctx, &result, query, args...); err != nil {
+		return nil, fmt.Errorf("%s.SelectContext: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// Get states of given words
+func (r *wordsRepo) GetStates(ctx context.Context, userID uuid.UUID, words []string) (map[string]string, error) {
+	const op = "words.pg_repository.getStates"
+
+	result := make(map[string]string)
+	for start := 0; start < len(words); start += wordsChunkSize {
+		query, args, err := getStatesQuery(userID, words[start:min(start+wordsChunkSize, len(words))])
+		if err != nil {
+			return nil, fmt.Errorf("%s.query: %w", op, err)
+		}
+
+		rows := make([]*models.UserWord, 0)
+		if err = r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+			return nil, fmt.Errorf("%s.SelectContext: %w", op, err)
+		}
+		for _, row := range rows {
+			result[row.Word] = row.State
+		}
+	}
+
+	return result, nil
+}
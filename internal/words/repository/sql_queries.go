@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+func upsertWordsQuery(userID uuid.UUID, words []string, state string) (string, []interface{}, error) {
+	now := time.Now()
+
+	query := sq.Insert("user_words").Columns("user_id", "word", "state", "updated_at")
+	for _, word := range words {
+		query = query.Values(userID, word, state, now)
+	}
+
+	return query.Suffix(
+		"ON CONFLICT (user_id, word) DO UPDATE SET state = EXCLUDED.state, updated_at = EXCLUDED.updated_at " +
+			"RETURNING *",
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func deleteWordsQuery(userID uuid.UUID, words []string) (string, []interface{}, error) {
+	return sq.Delete("user_words").Where(
+		sq.Eq{"user_id": userID, "word": words},
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getWordsQuery(userID uuid.UUID, state string) (string, []interface{}, error) {
+	query := sq.Select("user_id", "word", "state", "updated_at").From("user_words").Where("user_id = ?", userID)
+	if state != "" {
+		query = query.Where("state = ?", state)
+	}
+
+	return query.OrderBy("word").PlaceholderFormat(sq.Dollar).ToSql()
+}
+
+func getStatesQuery(userID uuid.UUID, words []string) (string, []interface{}, error) {
+	return sq.Select("word", "state").From("user_words").Where(
+		sq.Eq{"user_id": userID, "word": words},
+	).PlaceholderFormat(sq.Dollar).ToSql()
+}
@@ -0,0 +1,19 @@
+package words
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+)
+
+type UseCase interface {
+	Mark(ctx context.Context, userID uuid.UUID, words []string, state string) ([]*models.UserWord, error)
+	Unmark(ctx context.Context, userID uuid.UUID, words []string) (int64, error)
+	GetAll(ctx context.Context, userID uuid.UUID, state string) ([]*models.UserWord, error)
+	// Set word states of the user on entries, known and ignored words are removed when hide is set
+	ApplyStates(
+		ctx context.Context, userID uuid.UUID, entries []*models.DictionaryEntry, hide bool,
+	) ([]*models.DictionaryEntry, error)
+}
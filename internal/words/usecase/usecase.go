@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/internal/models"
+	"github.com/shlembo598/text-lexicon-go/internal/words"
+	"github.com/shlembo598/text-lexicon-go/pkg/tokenizer"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils/httpErrors"
+)
+
+var ErrNoWords = errors.New("no words given")
+
+type wordsUC struct {
+	cfg       *config.Config
+	wordsRepo words.Repository
+}
+
+func NewWordsUseCase(cfg *config.Config, wordsRepo words.Repository) words.UseCase {
+	return &wordsUC{cfg: cfg, wordsRepo: wordsRepo}
+}
+
+// Set state of words, inflected forms are stored as lemmas so "servers" marks "server"
+func (u *wordsUC) Mark(
+	ctx context.Context, userID uuid.UUID, words []string, state string,
+) ([]*models.UserWord, error) {
+	const op = "words.useCase.mark"
+
+	lemmas := normalizeWords(words)
+	if len(lemmas) == 0 {
+		return nil, httpErrors.NewBadRequestError(fmt.Errorf("%s: %w", op, ErrNoWords))
+	}
+
+	return u.wordsRepo.Upsert(ctx, userID, lemmas, state)
+}
+
+// Remove words from user vocabulary
+func (u *wordsUC) Unmark(ctx context.Context, userID uuid.UUID, words []string) (int64, error) {
+	const op = "words.useCase.unmark"
+
+	lemmas := normalizeWords(words)
+	if len(lemmas) == 0 {
+		return 0, httpErrors.NewBadRequestError(fmt.Errorf("%s: %w", op, ErrNoWords))
+	}
+
+	return u.wordsRepo.Delete(ctx, userID, lemmas)
+}
+
+// Get user words in given state, all words when state is empty
+func (u *wordsUC) GetAll(ctx context.Context, userID uuid.UUID, state string) ([]*models.UserWord, error) {
+	return u.wordsRepo.GetAll(ctx, userID, state)
+}
+
+// Set word states of the user on entries, known and ignored words are removed when hide is set
+func (u *wordsUC) ApplyStates(
+	ctx context.Context, userID uuid.UUID, entries []*models.DictionaryEntry, hide bool,
+) ([]*models.DictionaryEntry, error) {
+	if len(entries) == 0 {
+		return entries, nil
+	}
+
+	dictionaryWords := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		dictionaryWords = append(dictionaryWords, entry.Word)
+	}
+
+	states, err := u.wordsRepo.GetStates(ctx, userID, dictionaryWords)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.DictionaryEntry, 0, len(entries))
+	for _, entry := range entries {
+		entry.State = states[entry.Word]
+		if hide && models.IsHiddenWordState(entry.State) {
+			continue
+		}
+		result = append(result, entry)
+	}
+
+	return result, nil
+}
+
+// Lemmas of given words without duplicates, phrases are only lowercased
+func normalizeWords(words []string) []string {
+	seen := make(map[string]bool, len(words))
+	result := make([]string, 0, len(words))
+
+	for _, word := range words {
+		word = models.NormalizeWord(word)
+		if word == "" {
+			continue
+		}
+		if !strings.ContainsRune(word, ' ') {
+			word = tokenizer.Lemma(word)
+		}
+
+		if !seen[word] {
+			seen[word] = true
+			result = append(result, word)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE documents
+(
+    document_id UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    user_id     UUID                     REFERENCES users (user_id) ON DELETE SET NULL,
+    source_url  TEXT,
+    title       VARCHAR(256)             NOT NULL DEFAULT '',
+    content     TEXT                     NOT NULL,
+    word_count  INTEGER                  NOT NULL DEFAULT 0 CHECK ( word_count >= 0 ),
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX documents_user_id_idx ON documents (user_id);
+
+CREATE TABLE document_words
+(
+    document_id UUID         NOT NULL REFERENCES documents (document_id) ON DELETE CASCADE,
+    word        VARCHAR(128) NOT NULL CHECK ( word <> '' ),
+    frequency   INTEGER      NOT NULL CHECK ( frequency > 0 ),
+    PRIMARY KEY (document_id, word)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS document_words CASCADE;
+DROP TABLE IF EXISTS documents CASCADE;
+-- +goose StatementEnd
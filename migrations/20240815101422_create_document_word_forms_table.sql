@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE document_word_forms
+(
+    document_id UUID         NOT NULL,
+    word        VARCHAR(128) NOT NULL,
+    form        VARCHAR(128) NOT NULL CHECK ( form <> '' ),
+    position    INTEGER      NOT NULL DEFAULT 0,
+    PRIMARY KEY (document_id, word, form),
+    FOREIGN KEY (document_id, word) REFERENCES document_words (document_id, word) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS document_word_forms CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE documents
+    ADD COLUMN code_mode VARCHAR(16) NOT NULL DEFAULT 'skip' CHECK ( code_mode IN ('skip', 'split') );
+
+ALTER TABLE document_words
+    ADD COLUMN from_code BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE document_words
+    DROP COLUMN IF EXISTS from_code;
+
+ALTER TABLE documents
+    DROP COLUMN IF EXISTS code_mode;
+-- +goose StatementEnd
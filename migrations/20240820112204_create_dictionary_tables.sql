@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE dictionary_headwords
+(
+    headword_id   BIGSERIAL PRIMARY KEY,
+    headword      VARCHAR(128)             NOT NULL CHECK ( headword <> '' ),
+    transcription VARCHAR(128),
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX dictionary_headwords_lower_headword_idx ON dictionary_headwords (LOWER(headword));
+
+CREATE TABLE dictionary_senses
+(
+    sense_id       BIGSERIAL PRIMARY KEY,
+    headword_id    BIGINT      NOT NULL REFERENCES dictionary_headwords (headword_id) ON DELETE CASCADE,
+    part_of_speech VARCHAR(32),
+    translations   TEXT        NOT NULL CHECK ( translations <> '' ),
+    position       INTEGER     NOT NULL DEFAULT 0
+);
+
+CREATE INDEX dictionary_senses_headword_id_idx ON dictionary_senses (headword_id);
+
+CREATE TABLE dictionary_examples
+(
+    example_id  BIGSERIAL PRIMARY KEY,
+    sense_id    BIGINT NOT NULL REFERENCES dictionary_senses (sense_id) ON DELETE CASCADE,
+    text        TEXT   NOT NULL CHECK ( text <> '' ),
+    translation TEXT
+);
+
+CREATE INDEX dictionary_examples_sense_id_idx ON dictionary_examples (sense_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS dictionary_examples CASCADE;
+DROP TABLE IF EXISTS dictionary_senses CASCADE;
+DROP TABLE IF EXISTS dictionary_headwords CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE dictionaries
+(
+    dictionary_id   SERIAL PRIMARY KEY,
+    name            VARCHAR(250)             NOT NULL UNIQUE CHECK ( name <> '' ),
+    title           VARCHAR(250),
+    format          VARCHAR(16)              NOT NULL,
+    source_language VARCHAR(16),
+    target_language VARCHAR(16),
+    checksum        VARCHAR(64)              NOT NULL,
+    headwords_count INTEGER                  NOT NULL DEFAULT 0,
+    status          VARCHAR(16)              NOT NULL DEFAULT 'importing',
+    error           TEXT,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    imported_at     TIMESTAMP WITH TIME ZONE
+);
+
+ALTER TABLE dictionary_headwords
+    ADD COLUMN dictionary_id INTEGER REFERENCES dictionaries (dictionary_id) ON DELETE CASCADE;
+
+CREATE INDEX dictionary_headwords_dictionary_id_idx ON dictionary_headwords (dictionary_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE dictionary_headwords
+    DROP COLUMN IF EXISTS dictionary_id;
+DROP TABLE IF EXISTS dictionaries CASCADE;
+-- +goose StatementEnd
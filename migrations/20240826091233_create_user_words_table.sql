@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE user_words
+(
+    user_id    UUID                     NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    word       VARCHAR(128)             NOT NULL CHECK ( word <> '' ),
+    state      VARCHAR(16)              NOT NULL CHECK ( state IN ('known', 'learning', 'ignored') ),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, word)
+);
+
+CREATE INDEX user_words_user_id_state_idx ON user_words (user_id, state);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_words CASCADE;
+-- +goose StatementEnd
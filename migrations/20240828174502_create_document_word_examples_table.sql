@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE document_word_examples
+(
+    document_id  UUID         NOT NULL,
+    word         VARCHAR(128) NOT NULL,
+    position     INTEGER      NOT NULL DEFAULT 0,
+    text         TEXT         NOT NULL CHECK ( text <> '' ),
+    start_offset INTEGER      NOT NULL CHECK ( start_offset >= 0 ),
+    end_offset   INTEGER      NOT NULL CHECK ( end_offset > start_offset ),
+    heading      TEXT,
+    anchor       TEXT,
+    PRIMARY KEY (document_id, word, position),
+    FOREIGN KEY (document_id, word) REFERENCES document_words (document_id, word) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS document_word_examples CASCADE;
+-- +goose StatementEnd
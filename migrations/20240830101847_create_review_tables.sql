@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE review_cards
+(
+    user_id          UUID                     NOT NULL,
+    word             VARCHAR(128)             NOT NULL,
+    ease             DOUBLE PRECISION         NOT NULL DEFAULT 0,
+    stability        DOUBLE PRECISION         NOT NULL DEFAULT 0,
+    difficulty       DOUBLE PRECISION         NOT NULL DEFAULT 0,
+    interval_days    DOUBLE PRECISION         NOT NULL DEFAULT 0,
+    repetitions      INTEGER                  NOT NULL DEFAULT 0,
+    lapses           INTEGER                  NOT NULL DEFAULT 0,
+    due_at           TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, word),
+    FOREIGN KEY (user_id, word) REFERENCES user_words (user_id, word) ON DELETE CASCADE
+);
+
+CREATE INDEX review_cards_user_id_due_at_idx ON review_cards (user_id, due_at);
+
+CREATE TABLE review_logs
+(
+    review_id     BIGSERIAL PRIMARY KEY,
+    user_id       UUID                     NOT NULL,
+    word          VARCHAR(128)             NOT NULL,
+    algorithm     VARCHAR(16)              NOT NULL,
+    grade         SMALLINT                 NOT NULL CHECK ( grade BETWEEN 1 AND 4 ),
+    interval_days DOUBLE PRECISION         NOT NULL,
+    reviewed_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id, word) REFERENCES review_cards (user_id, word) ON DELETE CASCADE
+);
+
+CREATE INDEX review_logs_user_id_word_idx ON review_logs (user_id, word);
+
+CREATE TABLE review_settings
+(
+    user_id    UUID PRIMARY KEY         NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    algorithm  VARCHAR(16)              NOT NULL CHECK ( algorithm IN ('sm2', 'fsrs') ),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS review_settings CASCADE;
+DROP TABLE IF EXISTS review_logs CASCADE;
+DROP TABLE IF EXISTS review_cards CASCADE;
+-- +goose StatementEnd
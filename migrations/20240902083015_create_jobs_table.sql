@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE jobs
+(
+    job_id       UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    kind         VARCHAR(64)              NOT NULL,
+    user_id      UUID                     REFERENCES users (user_id) ON DELETE SET NULL,
+    payload      JSONB                    NOT NULL DEFAULT '{}',
+    status       VARCHAR(16)              NOT NULL DEFAULT 'pending'
+        CHECK ( status IN ('pending', 'running', 'done', 'failed') ),
+    attempts     INTEGER                  NOT NULL DEFAULT 0,
+    max_attempts INTEGER                  NOT NULL DEFAULT 3 CHECK ( max_attempts > 0 ),
+    result       JSONB,
+    error        TEXT,
+    run_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    locked_at    TIMESTAMP WITH TIME ZONE,
+    locked_by    VARCHAR(128),
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Workers only look for pending jobs and running jobs of crashed workers
+CREATE INDEX jobs_status_run_at_idx ON jobs (status, run_at) WHERE status IN ('pending', 'running');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS jobs CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE queued_document_contents
+(
+    content_id UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    content    TEXT                     NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS queued_document_contents CASCADE;
+-- +goose StatementEnd
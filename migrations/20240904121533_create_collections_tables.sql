@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE collections
+(
+    collection_id UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    user_id       UUID                     NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    name          VARCHAR(256)             NOT NULL CHECK ( name <> '' ),
+    source_url    TEXT,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX collections_user_id_idx ON collections (user_id);
+
+CREATE TABLE collection_documents
+(
+    collection_id UUID                     NOT NULL REFERENCES collections (collection_id) ON DELETE CASCADE,
+    document_id   UUID                     NOT NULL REFERENCES documents (document_id) ON DELETE CASCADE,
+    added_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (collection_id, document_id)
+);
+
+CREATE INDEX collection_documents_document_id_idx ON collection_documents (document_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS collection_documents CASCADE;
+DROP TABLE IF EXISTS collections CASCADE;
+-- +goose StatementEnd
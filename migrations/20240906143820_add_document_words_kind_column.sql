@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE document_words
+    ADD COLUMN kind VARCHAR(16) NOT NULL DEFAULT 'word' CHECK ( kind IN ('word', 'phrasal_verb', 'collocation') );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE document_words
+    DROP COLUMN IF EXISTS kind;
+-- +goose StatementEnd
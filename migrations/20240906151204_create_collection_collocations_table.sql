@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE collection_collocations
+(
+    collection_id UUID PRIMARY KEY REFERENCES collections (collection_id) ON DELETE CASCADE,
+    version       VARCHAR(64)              NOT NULL,
+    entries       JSONB                    NOT NULL,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS collection_collocations CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ADD COLUMN level VARCHAR(2) CHECK ( level IN ('A1', 'A2', 'B1', 'B2', 'C1', 'C2') );
+
+ALTER TABLE documents
+    ADD COLUMN level      VARCHAR(2) CHECK ( level IN ('A1', 'A2', 'B1', 'B2', 'C1', 'C2') ),
+    ADD COLUMN difficulty DOUBLE PRECISION;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE documents
+    DROP COLUMN IF EXISTS difficulty,
+    DROP COLUMN IF EXISTS level;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS level;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE document_words
+    DROP CONSTRAINT IF EXISTS document_words_kind_check,
+    ADD CONSTRAINT document_words_kind_check CHECK ( kind IN ('word', 'phrasal_verb', 'collocation', 'name') );
+
+ALTER TABLE user_words
+    DROP CONSTRAINT IF EXISTS user_words_state_check,
+    ADD CONSTRAINT user_words_state_check CHECK ( state IN ('known', 'learning', 'ignored', 'name') );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE document_words
+SET kind = 'word'
+WHERE kind = 'name';
+
+DELETE
+FROM user_words
+WHERE state = 'name';
+
+ALTER TABLE document_words
+    DROP CONSTRAINT IF EXISTS document_words_kind_check,
+    ADD CONSTRAINT document_words_kind_check CHECK ( kind IN ('word', 'phrasal_verb', 'collocation') );
+
+ALTER TABLE user_words
+    DROP CONSTRAINT IF EXISTS user_words_state_check,
+    ADD CONSTRAINT user_words_state_check CHECK ( state IN ('known', 'learning', 'ignored') );
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE document_words
+    ADD COLUMN expansion VARCHAR(256),
+    DROP CONSTRAINT IF EXISTS document_words_kind_check,
+    ADD CONSTRAINT document_words_kind_check CHECK (
+        kind IN ('word', 'phrasal_verb', 'collocation', 'name', 'acronym')
+        );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE document_words
+SET kind = 'word'
+WHERE kind = 'acronym';
+
+ALTER TABLE document_words
+    DROP COLUMN IF EXISTS expansion,
+    DROP CONSTRAINT IF EXISTS document_words_kind_check,
+    ADD CONSTRAINT document_words_kind_check CHECK ( kind IN ('word', 'phrasal_verb', 'collocation', 'name') );
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE document_word_examples
+    ADD COLUMN location VARCHAR(64);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE document_word_examples
+    DROP COLUMN IF EXISTS location;
+-- +goose StatementEnd
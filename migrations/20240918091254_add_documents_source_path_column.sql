@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE documents
+    ADD COLUMN source_path TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE documents
+    DROP COLUMN IF EXISTS source_path;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE collection_import_files
+(
+    collection_id UUID                     NOT NULL REFERENCES collections (collection_id) ON DELETE CASCADE,
+    hash          VARCHAR(64)              NOT NULL,
+    body          BYTEA                    NOT NULL,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (collection_id, hash)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS collection_import_files CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,208 @@
+package acronyms
+
+import (
+	"bufio"
+	_ "embed"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/tokenizer"
+)
+
+//go:embed abbreviations.txt
+var abbreviationsFile string
+
+// Lemmas of common abbreviations to their expansions
+var abbreviations = loadAbbreviations(abbreviationsFile)
+
+const (
+	// Longest abbreviation in letters
+	maxLength = 10
+	// Share of occurrences written in capitals which makes a word an abbreviation
+	minUpperShare = 0.9
+)
+
+var (
+	parenthesesRe = regexp.MustCompile(`\(([^()\n]{1,200})\)`)
+	// Expansions contain only latin words, hyphens, apostrophes and spaces
+	expansionRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9'’ -]*[A-Za-z0-9]$`)
+)
+
+// Abbreviation defined in a text
+type Definition struct {
+	// Lemma of the abbreviation, the same as the lemma of its tokens
+	Lemma string
+	// Long form as written in the text
+	Expansion string
+}
+
+func loadAbbreviations(data string) map[string]string {
+	result := make(map[string]string)
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		abbreviation, expansion, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		result[lemma(abbreviation)] = strings.TrimSpace(expansion)
+	}
+
+	return result
+}
+
+// Expansion of a common abbreviation by its lemma, empty for unknown ones
+func Expand(lemma string) string {
+	return abbreviations[lemma]
+}
+
+// Find lemmas of words written in capitals nearly every time they are used: "TLS", "CRDs"
+func Find(tokens []tokenizer.Token) map[string]bool {
+	upper := make(map[string]int)
+	total := make(map[string]int)
+	for _, token := range tokens {
+		total[token.Lemma]++
+		if IsAbbreviation(token.Text) {
+			upper[token.Lemma]++
+		}
+	}
+
+	result := make(map[string]bool)
+	for lemma, count := range upper {
+		if float64(count) >= minUpperShare*float64(total[lemma]) {
+			result[lemma] = true
+		}
+	}
+
+	return result
+}
+
+// Word of 2 to 10 letters written in capitals with an optional plural or possessive ending: "API", "URLs"
+func IsAbbreviation(word string) bool {
+	word = trimEnding(word)
+
+	letters := 0
+	for _, r := range word {
+		switch {
+		case unicode.IsLower(r):
+			return false
+		case unicode.IsLetter(r):
+			letters++
+		case !unicode.IsDigit(r):
+			return false
+		}
+	}
+
+	return letters > 1 && letters <= maxLength
+}
+
+// Find abbreviations defined in the text in the forms "Transport Layer Security (TLS)" and
+// "CRD (Custom Resource Definition)". Letters of the abbreviation must appear in the long form in order,
+// the first one at the start of its first word, as in the algorithm of Schwartz and Hearst.
+// The first definition of an abbreviation wins.
+func Definitions(text string) []Definition {
+	definitions := make([]Definition, 0)
+	seen := make(map[string]bool)
+
+	add := func(abbreviation, expansion string) {
+		l := lemma(abbreviation)
+		if seen[l] || !expansionRe.MatchString(expansion) {
+			return
+		}
+		seen[l] = true
+		definitions = append(definitions, Definition{Lemma: l, Expansion: expansion})
+	}
+
+	for _, match := range parenthesesRe.FindAllStringSubmatchIndex(text, -1) {
+		inside := strings.TrimSpace(text[match[2]:match[3]])
+		before := lineBefore(text, match[0])
+
+		if IsAbbreviation(inside) {
+			words := strings.Fields(before)
+			limit := min(len(trimEnding(inside))+5, 2*len(trimEnding(inside)))
+			if len(words) > limit {
+				words = words[len(words)-limit:]
+			}
+			if expansion := longForm(trimEnding(inside), strings.Join(words, " ")); expansion != "" {
+				add(inside, expansion)
+			}
+			continue
+		}
+
+		words := strings.Fields(before)
+		if len(words) == 0 || !IsAbbreviation(words[len(words)-1]) {
+			continue
+		}
+		abbreviation := trimEnding(words[len(words)-1])
+		candidate, _, _ := strings.Cut(inside, ",")
+		candidate, _, _ = strings.Cut(candidate, ";")
+		if expansion := longForm(abbreviation, strings.TrimSpace(candidate)); expansion != "" {
+			add(words[len(words)-1], expansion)
+		}
+	}
+
+	return definitions
+}
+
+// Shortest end of candidate which contains letters of the abbreviation in order, the first letter starting
+// a word. Empty when there is no such long form or it is not longer than the abbreviation.
+func longForm(abbreviation, candidate string) string {
+	short := []rune(strings.ToLower(abbreviation))
+	long := []rune(candidate)
+
+	l := len(long) - 1
+	for s := len(short) - 1; s >= 0; s-- {
+		c := short[s]
+		if !isWordRune(c) {
+			continue
+		}
+
+		for l >= 0 && (unicode.ToLower(long[l]) != c || s == 0 && l > 0 && isWordRune(long[l-1])) {
+			l--
+		}
+		if l < 0 {
+			return ""
+		}
+		l--
+	}
+
+	// The first letter starts a word so the long form starts right at it
+	result := strings.TrimSpace(string(long[l+1:]))
+	if len(strings.Fields(result)) < 2 || strings.Contains(strings.ToLower(result), string(short)) {
+		return ""
+	}
+
+	return result
+}
+
+// Text between the start of the line and the position, punctuation which ends a clause cuts it
+func lineBefore(text string, end int) string {
+	start := strings.LastIndexAny(text[:end], "\n.,;:!?()\"") + 1
+
+	return text[start:end]
+}
+
+// Drop plural and possessive endings of an abbreviation
+func trimEnding(word string) string {
+	for _, suffix := range []string{"'s", "’s", "s"} {
+		if trimmed := strings.TrimSuffix(word, suffix); trimmed != word {
+			return trimmed
+		}
+	}
+
+	return word
+}
+
+func lemma(abbreviation string) string {
+	return tokenizer.Lemma(strings.ToLower(trimEnding(abbreviation)))
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,299 @@
+package anki
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	// Pure Go SQLite driver, the collection is an SQLite database
+	_ "modernc.org/sqlite"
+)
+
+// Separator of note fields in the collection
+const fieldSeparator = "\x1f"
+
+// Note with front, back and example fields
+type Note struct {
+	Front   string
+	Back    string
+	Example string
+	Tags    []string
+}
+
+type Deck struct {
+	Name  string
+	Notes []Note
+}
+
+// Write deck as an .apkg file: zip archive with the collection.anki2 SQLite database of schema version 11
+// and an empty media map, it can be imported by Anki desktop and mobile apps
+func Write(ctx context.Context, w io.Writer, deck *Deck) error {
+	const op = "pkg.anki.write"
+
+	dir, err := os.MkdirTemp("", "apkg-*")
+	if err != nil {
+		return fmt.Errorf("%s.MkdirTemp: %w", op, err)
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	collectionPath := filepath.Join(dir, "collection.anki2")
+	if err = writeCollection(ctx, collectionPath, deck, time.Now()); err != nil {
+		return fmt.Errorf("%s.writeCollection: %w", op, err)
+	}
+
+	zw := zip.NewWriter(w)
+	if err = addFile(zw, "collection.anki2", collectionPath); err != nil {
+		return fmt.Errorf("%s.addFile: %w", op, err)
+	}
+
+	media, err := zw.Create("media")
+	if err != nil {
+		return fmt.Errorf("%s.Create: %w", op, err)
+	}
+	if _, err = media.Write([]byte("{}")); err != nil {
+		return fmt.Errorf("%s.Write: %w", op, err)
+	}
+
+	if err = zw.Close(); err != nil {
+		return fmt.Errorf("%s.Close: %w", op, err)
+	}
+
+	return nil
+}
+
+func addFile(zw *zip.Writer, name, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	dst, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(dst, f)
+
+	return err
+}
+
+func writeCollection(ctx context.Context, path string, deck *Deck, now time.Time) error {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err = tx.ExecContext(ctx, schema); err != nil {
+		return err
+	}
+
+	ms := now.UnixMilli()
+	deckID := ms
+	modelID := ms + 1
+
+	models, decks, err := collectionJSON(deck.Name, deckID, modelID, now)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO col (id, crt, mod, scm, ver, dty, usn, ls, conf, models, decks, dconf, tags)
+		VALUES (1, ?, ?, ?, 11, 0, 0, 0, ?, ?, ?, ?, '{}')`,
+		now.Unix(), ms, ms, defaultConf(deckID, modelID), models, decks, defaultDeckConf,
+	)
+	if err != nil {
+		return err
+	}
+
+	noteStmt, err := tx.PrepareContext(
+		ctx,
+		`INSERT INTO notes (id, guid, mid, mod, usn, tags, flds, sfld, csum, flags, data)
+		VALUES (?, ?, ?, ?, -1, ?, ?, ?, ?, 0, '')`,
+	)
+	if err != nil {
+		return err
+	}
+	cardStmt, err := tx.PrepareContext(
+		ctx,
+		`INSERT INTO cards (id, nid, did, ord, mod, usn, type, queue, due, ivl, factor, reps, lapses, left, odue,
+		odid, flags, data) VALUES (?, ?, ?, 0, ?, -1, 0, 0, ?, 0, 0, 0, 0, 0, 0, 0, 0, '')`,
+	)
+	if err != nil {
+		return err
+	}
+
+	for i, note := range deck.Notes {
+		noteID := ms + int64(i)
+		tags := ""
+		if len(note.Tags) > 0 {
+			tags = " " + strings.Join(note.Tags, " ") + " "
+		}
+
+		fields := strings.Join([]string{note.Front, note.Back, note.Example}, fieldSeparator)
+		_, err = noteStmt.ExecContext(
+			ctx, noteID, guid(deck.Name, note.Front), modelID, now.Unix(), tags, fields, note.Front, checksum(note.Front),
+		)
+		if err != nil {
+			return err
+		}
+
+		if _, err = cardStmt.ExecContext(ctx, noteID, noteID, deckID, now.Unix(), i+1); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Stable note id, so importing an updated deck updates existing notes instead of duplicating them
+func guid(deckName, front string) string {
+	sum := sha1.Sum([]byte(deckName + fieldSeparator + front))
+	return strconv.FormatUint(binary.BigEndian.Uint64(sum[:8]), 36)
+}
+
+// First 8 hex digits of sha1 of the sort field, used by Anki to find duplicates
+func checksum(field string) int64 {
+	sum := sha1.Sum([]byte(field))
+	value, _ := strconv.ParseInt(hex.EncodeToString(sum[:4]), 16, 64)
+
+	return value
+}
+
+func collectionJSON(deckName string, deckID, modelID int64, now time.Time) (string, string, error) {
+	field := func(name string, ord int) map[string]interface{} {
+		return map[string]interface{}{
+			"name": name, "ord": ord, "sticky": false, "rtl": false, "font": "Arial", "size": 20, "media": []string{},
+		}
+	}
+
+	model := map[string]interface{}{
+		"id":    modelID,
+		"name":  "Text Lexicon",
+		"type":  0,
+		"mod":   now.Unix(),
+		"usn":   -1,
+		"sortf": 0,
+		"did":   deckID,
+		"tags":  []string{},
+		"vers":  []int{},
+		"flds":  []interface{}{field("Front", 0), field("Back", 1), field("Example", 2)},
+		"tmpls": []interface{}{
+			map[string]interface{}{
+				"name":  "Card 1",
+				"ord":   0,
+				"qfmt":  "{{Front}}",
+				"afmt":  "{{FrontSide}}<hr id=answer>{{Back}}{{#Example}}<br><br><i>{{Example}}</i>{{/Example}}",
+				"did":   nil,
+				"bqfmt": "",
+				"bafmt": "",
+			},
+		},
+		"css": ".card { font-family: arial; font-size: 20px; text-align: center; color: black; " +
+			"background-color: white; }",
+		"latexPre":  "\\documentclass[12pt]{article}\n\\special{papersize=3in,5in}\n\\begin{document}\n",
+		"latexPost": "\\end{document}",
+		"latexsvg":  false,
+		"req":       []interface{}{[]interface{}{0, "all", []int{0}}},
+	}
+
+	deck := map[string]interface{}{
+		"id": deckID, "name": deckName, "desc": "", "mod": now.Unix(), "usn": -1, "dyn": 0, "conf": 1,
+		"collapsed": false, "browserCollapsed": false, "extendNew": 10, "extendRev": 50,
+		"newToday": []int{0, 0}, "revToday": []int{0, 0}, "lrnToday": []int{0, 0}, "timeToday": []int{0, 0},
+	}
+	defaultDeck := map[string]interface{}{
+		"id": 1, "name": "Default", "desc": "", "mod": now.Unix(), "usn": 0, "dyn": 0, "conf": 1,
+		"collapsed": false, "browserCollapsed": false, "extendNew": 10, "extendRev": 50,
+		"newToday": []int{0, 0}, "revToday": []int{0, 0}, "lrnToday": []int{0, 0}, "timeToday": []int{0, 0},
+	}
+
+	models, err := json.Marshal(map[string]interface{}{strconv.FormatInt(modelID, 10): model})
+	if err != nil {
+		return "", "", err
+	}
+	decks, err := json.Marshal(
+		map[string]interface{}{"1": defaultDeck, strconv.FormatInt(deckID, 10): deck},
+	)
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(models), string(decks), nil
+}
+
+func defaultConf(deckID, modelID int64) string {
+	return fmt.Sprintf(
+		`{"nextPos": 1, "estTimes": true, "activeDecks": [%d], "sortType": "noteFld", "timeLim": 0, `+
+			`"sortBackwards": false, "addToCur": true, "curDeck": %d, "newBury": true, "newSpread": 0, `+
+			`"dueCounts": true, "curModel": "%d", "collapseTime": 1200}`,
+		deckID, deckID, modelID,
+	)
+}
+
+const defaultDeckConf = `{"1": {"id": 1, "name": "Default", "mod": 0, "usn": 0, "maxTaken": 60, "autoplay": true,
+"timer": 0, "replayq": true, "dyn": false,
+"new": {"delays": [1, 10], "ints": [1, 4, 7], "initialFactor": 2500, "order": 1, "perDay": 20, "bury": true},
+"rev": {"perDay": 200, "ease4": 1.3, "fuzz": 0.05, "maxIvl": 36500, "ivlFct": 1, "bury": true, "minSpace": 1},
+"lapse": {"delays": [10], "mult": 0, "minInt": 1, "leechFails": 8, "leechAction": 0}}}`
+
+// Collection schema version 11 as created by Anki 2.1
+const schema = `
+CREATE TABLE col (
+    id integer primary key, crt integer not null, mod integer not null, scm integer not null,
+    ver integer not null, dty integer not null, usn integer not null, ls integer not null,
+    conf text not null, models text not null, decks text not null, dconf text not null, tags text not null
+);
+CREATE TABLE notes (
+    id integer primary key, guid text not null, mid integer not null, mod integer not null,
+    usn integer not null, tags text not null, flds text not null, sfld integer not null,
+    csum integer not null, flags integer not null, data text not null
+);
+CREATE TABLE cards (
+    id integer primary key, nid integer not null, did integer not null, ord integer not null,
+    mod integer not null, usn integer not null, type integer not null, queue integer not null,
+    due integer not null, ivl integer not null, factor integer not null, reps integer not null,
+    lapses integer not null, left integer not null, odue integer not null, odid integer not null,
+    flags integer not null, data text not null
+);
+CREATE TABLE revlog (
+    id integer primary key, cid integer not null, usn integer not null, ease integer not null,
+    ivl integer not null, lastIvl integer not null, factor integer not null, time integer not null,
+    type integer not null
+);
+CREATE TABLE graves (usn integer not null, oid integer not null, type integer not null);
+CREATE INDEX ix_notes_usn ON notes (usn);
+CREATE INDEX ix_cards_usn ON cards (usn);
+CREATE INDEX ix_revlog_usn ON revlog (usn);
+CREATE INDEX ix_cards_nid ON cards (nid);
+CREATE INDEX ix_cards_sched ON cards (did, queue, due);
+CREATE INDEX ix_revlog_cid ON revlog (cid);
+CREATE INDEX ix_notes_csum ON notes (csum);
+`
@@ -0,0 +1,150 @@
+package anki
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Open collection database of an .apkg file, the media map is checked to be empty
+func openPackage(t *testing.T, apkg []byte) *sql.DB {
+	t.Helper()
+
+	zr, err := zip.NewReader(bytes.NewReader(apkg), int64(len(apkg)))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if files[f.Name], err = io.ReadAll(r); err != nil {
+			t.Fatal(err)
+		}
+		_ = r.Close()
+	}
+	if media := string(files["media"]); media != "{}" {
+		t.Errorf("media = %q, want %q", media, "{}")
+	}
+
+	path := filepath.Join(t.TempDir(), "collection.anki2")
+	if err = os.WriteFile(path, files["collection.anki2"], 0o600); err != nil {
+		t.Fatal(err)
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	return db
+}
+
+func TestWrite(t *testing.T) {
+	deck := &Deck{
+		Name: "Go Documentation",
+		Notes: []Note{
+			{Front: "goroutine", Back: "горутина", Example: "Start a goroutine.", Tags: []string{"noun", "B2"}},
+			{Front: "defer", Back: "откладывать"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(context.Background(), &buf, deck); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	db := openPackage(t, buf.Bytes())
+
+	type note struct {
+		guid, tags, fields, sortField string
+		checksum                      int64
+	}
+	notes := make([]note, 0)
+	rows, err := db.Query("SELECT guid, tags, flds, sfld, csum FROM notes ORDER BY id")
+	if err != nil {
+		t.Fatalf("notes query error = %v", err)
+	}
+	for rows.Next() {
+		var n note
+		if err = rows.Scan(&n.guid, &n.tags, &n.fields, &n.sortField, &n.checksum); err != nil {
+			t.Fatal(err)
+		}
+		notes = append(notes, n)
+	}
+	_ = rows.Close()
+
+	want := []note{
+		{
+			guid: guid(deck.Name, "goroutine"), tags: " noun B2 ",
+			fields:    "goroutine\x1fгорутина\x1fStart a goroutine.",
+			sortField: "goroutine", checksum: checksum("goroutine"),
+		},
+		{
+			guid: guid(deck.Name, "defer"), fields: "defer\x1fоткладывать\x1f",
+			sortField: "defer", checksum: checksum("defer"),
+		},
+	}
+	if !reflect.DeepEqual(notes, want) {
+		t.Errorf("notes = %+v, want %+v", notes, want)
+	}
+
+	var cards, newCards int
+	if err = db.QueryRow("SELECT COUNT(*), COUNT(*) FILTER (WHERE type = 0 AND queue = 0) FROM cards").Scan(
+		&cards, &newCards,
+	); err != nil {
+		t.Fatalf("cards query error = %v", err)
+	}
+	if cards != 2 || newCards != 2 {
+		t.Errorf("cards = %d, new cards = %d, want 2 new cards", cards, newCards)
+	}
+
+	var decksJSON string
+	if err = db.QueryRow("SELECT decks FROM col").Scan(&decksJSON); err != nil {
+		t.Fatalf("col query error = %v", err)
+	}
+	decks := make(map[string]struct {
+		Name string `json:"name"`
+	})
+	if err = json.Unmarshal([]byte(decksJSON), &decks); err != nil {
+		t.Fatalf("decks json error = %v", err)
+	}
+	names := make(map[string]bool)
+	for _, d := range decks {
+		names[d.Name] = true
+	}
+	if !names["Default"] || !names[deck.Name] || len(names) != 2 {
+		t.Errorf("decks = %v, want Default and %q", names, deck.Name)
+	}
+}
+
+func TestGUID(t *testing.T) {
+	tests := []struct {
+		name        string
+		deck, front string
+		other       string
+		equal       bool
+	}{
+		{name: "same deck and word", deck: "Go", front: "goroutine", other: "Go", equal: true},
+		{name: "other deck", deck: "Go", front: "goroutine", other: "Rust"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := guid(tt.deck, tt.front) == guid(tt.other, tt.front); got != tt.equal {
+				t.Errorf("guid(%q) == guid(%q) is %v, want %v", tt.deck, tt.other, got, tt.equal)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package cefr
+
+import (
+	"bufio"
+	_ "embed"
+	"math"
+	"strings"
+)
+
+const (
+	A1 = "A1"
+	A2 = "A2"
+	B1 = "B1"
+	B2 = "B2"
+	C1 = "C1"
+	C2 = "C2"
+)
+
+// Words in one frequency band
+const BandSize = 1000
+
+// Share of word occurrences a reader has to know to understand a text without a dictionary
+const coverage = 0.95
+
+// Levels from the easiest
+var Levels = []string{A1, A2, B1, B2, C1, C2}
+
+// Largest frequency rank of words of every level from A1 to C1, rarer and unknown words are C2
+var levelRanks = []int{800, 1600, 3200, 5500, 8000}
+
+//go:embed frequency.txt
+var frequencyFile string
+
+// Lemma to its rank in the frequency list starting from 1
+var ranks = loadRanks(frequencyFile)
+
+func loadRanks(data string) map[string]int {
+	result := make(map[string]int)
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if _, ok := result[line]; !ok {
+			result[line] = len(result) + 1
+		}
+	}
+
+	return result
+}
+
+// Rank of a lemma in general English starting from 1 for the most frequent word, 0 for words out of the list
+func Rank(lemma string) int {
+	return ranks[lemma]
+}
+
+// Estimated occurrences of a lemma per million words of general english by Zipf's law from its rank.
+// Words out of the list are taken as twice as rare as the last word, a phrase is taken as common
+// as its rarest word.
+func PerMillion(lemma string) float64 {
+	// Harmonic number of the list size normalizes Zipf frequencies to sum up to one
+	harmonic := math.Log(float64(len(ranks))) + 0.5772
+
+	result := math.MaxFloat64
+	for _, word := range strings.Fields(lemma) {
+		rank := ranks[word]
+		if rank == 0 {
+			rank = 2 * len(ranks)
+		}
+		result = min(result, 1e6/(float64(rank)*harmonic))
+	}
+	if result == math.MaxFloat64 {
+		return 0
+	}
+
+	return result
+}
+
+// Frequency band of a lemma: 1 for the most frequent thousand words, 0 for words out of the list.
+// A phrase gets the band of its rarest word.
+func Band(lemma string) int {
+	band := 0
+	for _, word := range strings.Fields(lemma) {
+		rank := ranks[word]
+		if rank == 0 {
+			return 0
+		}
+		band = max(band, (rank-1)/BandSize+1)
+	}
+
+	return band
+}
+
+// Estimated level of a lemma by its frequency, a phrase gets the level of its hardest word
+func Level(lemma string) string {
+	index := 0
+	for _, word := range strings.Fields(lemma) {
+		index = max(index, rankLevel(ranks[word]))
+	}
+
+	return Levels[index]
+}
+
+// Index of the level, -1 for unknown levels
+func Index(level string) int {
+	for i, l := range Levels {
+		if l == level {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func rankLevel(rank int) int {
+	if rank == 0 {
+		return len(Levels) - 1
+	}
+	for i, maxRank := range levelRanks {
+		if rank <= maxRank {
+			return i
+		}
+	}
+
+	return len(Levels) - 1
+}
+
+// Estimate difficulty of a text from counts of its lemmas. Returns the lowest level whose words cover
+// 95% of word occurrences and the mean level from 1 for A1 to 6 for C2 weighted by counts.
+func Estimate(counts map[string]int) (string, float64) {
+	byLevel := make([]int, len(Levels))
+	total := 0
+	for lemma, count := range counts {
+		byLevel[Index(Level(lemma))] += count
+		total += count
+	}
+	if total == 0 {
+		return A1, 1
+	}
+
+	level, covered, sum := "", 0, 0
+	for i, count := range byLevel {
+		covered += count
+		sum += (i + 1) * count
+		if level == "" && float64(covered) >= coverage*float64(total) {
+			level = Levels[i]
+		}
+	}
+
+	return level, float64(sum) / float64(total)
+}
@@ -0,0 +1,258 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/fetcher"
+	"github.com/shlembo598/text-lexicon-go/pkg/logger/sl"
+)
+
+// Most sitemaps read from one sitemap index
+const maxSitemaps = 50
+
+var ErrInvalidRoot = errors.New("root url must be an absolute http or https url")
+
+type Options struct {
+	// Only pages with paths starting with the prefix are crawled, the directory of the root page by default
+	Prefix string
+	// Links followed from the root page or the sitemap pages, 0 crawls only the start pages
+	MaxDepth int
+	MaxPages int
+	// Shortest time between requests to one host, a longer robots.txt crawl-delay wins
+	Delay time.Duration
+	// Used to pick the robots.txt group
+	UserAgent string
+	// Largest sitemap after decompression
+	MaxSitemapSize int64
+}
+
+// Crawl outcome
+type Stats struct {
+	Pages   int `json:"pages"`
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+}
+
+// Visit is called for every fetched html or text page, an error stops the crawl
+type Visit func(ctx context.Context, page *fetcher.Page) error
+
+// Crawler walks a documentation site breadth first starting from a page or a sitemap. Only pages of the same
+// origin under the path prefix which are allowed by robots.txt are fetched.
+type Crawler struct {
+	fetcher fetcher.Fetcher
+	options Options
+	limiter *hostLimiter
+}
+
+func NewCrawler(fetcher fetcher.Fetcher, options Options) *Crawler {
+	return &Crawler{fetcher: fetcher, options: options, limiter: newHostLimiter()}
+}
+
+type queued struct {
+	url   *url.URL
+	depth int
+}
+
+// Crawl site from root url, pages which can not be fetched are counted as failed and skipped
+func (c *Crawler) Crawl(ctx context.Context, root string, visit Visit) (*Stats, error) {
+	const op = "pkg.crawler.crawl"
+
+	rootURL, err := url.Parse(root)
+	if err != nil || (rootURL.Scheme != "http" && rootURL.Scheme != "https") || rootURL.Host == "" {
+		return nil, fmt.Errorf("%s: %w: %s", op, ErrInvalidRoot, root)
+	}
+	rootURL = normalizeURL(rootURL)
+
+	prefix := c.options.Prefix
+	if prefix == "" {
+		if isSitemap(root) {
+			prefix = "/"
+		} else {
+			prefix = pathPrefix(rootURL)
+		}
+	}
+
+	rules := c.robots(ctx, rootURL)
+	delay := c.options.Delay
+	if rules != nil && rules.crawlDelay > delay {
+		delay = rules.crawlDelay
+	}
+
+	inScope := func(u *url.URL) bool {
+		return u.Scheme == rootURL.Scheme && u.Host == rootURL.Host && strings.HasPrefix(u.Path, prefix) &&
+			!skippedExtension(u) && rules.allowed(u.EscapedPath())
+	}
+
+	start := []*url.URL{rootURL}
+	if isSitemap(root) {
+		if start, err = c.sitemapPages(ctx, rootURL, delay); err != nil {
+			return nil, fmt.Errorf("%s.sitemapPages: %w", op, err)
+		}
+	}
+
+	stats := &Stats{}
+	seen := make(map[string]bool)
+	queue := make([]queued, 0, len(start))
+	for _, u := range start {
+		u = normalizeURL(u)
+		if !seen[u.String()] && inScope(u) {
+			seen[u.String()] = true
+			queue = append(queue, queued{url: u})
+		}
+	}
+
+	for len(queue) > 0 && (c.options.MaxPages <= 0 || stats.Pages < c.options.MaxPages) {
+		if err = ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		item := queue[0]
+		queue = queue[1:]
+
+		if err = c.limiter.wait(ctx, item.url.Host, delay); err != nil {
+			return stats, err
+		}
+		page, err := c.fetcher.Fetch(ctx, item.url.String())
+		if err != nil {
+			if ctx.Err() != nil {
+				return stats, ctx.Err()
+			}
+			slog.Warn("crawler fetch", slog.String("url", item.url.String()), sl.Err(err))
+			stats.Failed++
+			continue
+		}
+
+		// Redirects may lead out of the site
+		pageURL, err := url.Parse(page.URL)
+		if err != nil || !inScope(normalizeURL(pageURL)) {
+			stats.Skipped++
+			continue
+		}
+		if !page.IsHTML() && !isText(page) {
+			stats.Skipped++
+			continue
+		}
+
+		if err = visit(ctx, page); err != nil {
+			return stats, err
+		}
+		stats.Pages++
+
+		if !page.IsHTML() || item.depth >= c.options.MaxDepth {
+			continue
+		}
+		for _, link := range extractLinks(page.Body, pageURL) {
+			link = normalizeURL(link)
+			key := link.String()
+			if seen[key] || !inScope(link) {
+				continue
+			}
+			seen[key] = true
+			queue = append(queue, queued{url: link, depth: item.depth + 1})
+		}
+	}
+
+	return stats, nil
+}
+
+// Rules of robots.txt of the root host, missing robots.txt allows everything
+func (c *Crawler) robots(ctx context.Context, root *url.URL) *robots {
+	robotsURL := &url.URL{Scheme: root.Scheme, Host: root.Host, Path: "/robots.txt"}
+
+	page, err := c.fetcher.Fetch(ctx, robotsURL.String())
+	if err != nil {
+		return nil
+	}
+
+	return parseRobots(page.Body, c.options.UserAgent)
+}
+
+// Pages listed by a sitemap or a sitemap index with its nested sitemaps
+func (c *Crawler) sitemapPages(ctx context.Context, root *url.URL, delay time.Duration) ([]*url.URL, error) {
+	pages := make([]*url.URL, 0)
+	sitemaps := []string{root.String()}
+	seen := map[string]bool{root.String(): true}
+
+	for i := 0; i < len(sitemaps) && i < maxSitemaps; i++ {
+		if err := c.limiter.wait(ctx, root.Host, delay); err != nil {
+			return nil, err
+		}
+
+		page, err := c.fetcher.Fetch(ctx, sitemaps[i])
+		if err != nil {
+			// Only the root sitemap is required
+			if i == 0 {
+				return nil, err
+			}
+			slog.Warn("crawler sitemap", slog.String("url", sitemaps[i]), sl.Err(err))
+			continue
+		}
+
+		locations, nested, err := parseSitemap(page.Body, max(c.options.MaxSitemapSize, 1))
+		if err != nil {
+			if i == 0 {
+				return nil, err
+			}
+			continue
+		}
+
+		for _, loc := range locations {
+			if u, err := url.Parse(loc); err == nil {
+				pages = append(pages, u)
+			}
+		}
+		for _, loc := range nested {
+			if !seen[loc] {
+				seen[loc] = true
+				sitemaps = append(sitemaps, loc)
+			}
+		}
+	}
+
+	return pages, nil
+}
+
+func isText(page *fetcher.Page) bool {
+	return strings.HasPrefix(page.ContentType, "text/plain") || strings.HasPrefix(page.ContentType, "text/markdown") ||
+		(page.ContentType == "" && strings.HasPrefix(http.DetectContentType(page.Body), "text/plain"))
+}
+
+// Spaces requests to every host by a delay
+type hostLimiter struct {
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+func newHostLimiter() *hostLimiter {
+	return &hostLimiter{next: make(map[string]time.Time)}
+}
+
+// Wait for the turn of the host
+func (l *hostLimiter) wait(ctx context.Context, host string, delay time.Duration) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next[host]
+	if at.Before(now) {
+		at = now
+	}
+	l.next[host] = at.Add(delay)
+	l.mu.Unlock()
+
+	timer := time.NewTimer(at.Sub(now))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
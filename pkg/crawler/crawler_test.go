@@ -0,0 +1,169 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/pkg/fetcher"
+)
+
+// Documentation site with pages linking each other, other sites and pages closed by robots.txt
+func newSite(t *testing.T, robots string) *httptest.Server {
+	t.Helper()
+
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte("<p>Other site</p>"))
+	}))
+	t.Cleanup(other.Close)
+
+	pages := map[string]string{
+		"/docs/": `<a href="guide/">Guide</a> <a href="api">API</a> <a href="/blog/">Blog</a>
+			<a href="` + other.URL + `/docs/">Other</a> <a href="private/keys">Keys</a>
+			<a href="logo.png">Logo</a> <a href="api#queues">Queues</a> <a href="ads" rel="nofollow">Ads</a>`,
+		"/docs/guide/":       `<a href="../api">API</a> <a href="deep">Deep</a>`,
+		"/docs/guide/deep":   `<a href="deeper">Deeper</a>`,
+		"/docs/guide/deeper": `<p>Deeper</p>`,
+		"/docs/api":          `<p>API</p>`,
+		"/docs/private/keys": `<p>Keys</p>`,
+		"/docs/ads":          `<p>Ads</p>`,
+		"/blog/":             `<p>Blog</p>`,
+	}
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			if robots == "" {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write([]byte(robots))
+		case "/sitemap.xml":
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write([]byte(`<sitemapindex><sitemap><loc>` + server.URL + `/pages.xml.gz</loc></sitemap>
+				<sitemap><loc>` + server.URL + `/missing.xml</loc></sitemap></sitemapindex>`))
+		case "/pages.xml.gz":
+			_, _ = w.Write(gzipped(t, `<urlset><url><loc>`+server.URL+`/docs/api</loc></url>
+				<url><loc>`+server.URL+`/blog/</loc></url><url><loc>`+other.URL+`/docs/</loc></url></urlset>`))
+		case "/docs/moved":
+			http.Redirect(w, r, other.URL+"/docs/", http.StatusFound)
+		default:
+			body, ok := pages[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte("<html><body>" + body + "</body></html>"))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestCrawl(t *testing.T) {
+	tests := []struct {
+		name    string
+		robots  string
+		root    string
+		options Options
+		want    []string
+		stats   Stats
+	}{
+		{
+			name: "links under the root directory", root: "/docs/", options: Options{MaxDepth: 3},
+			want: []string{
+				"/docs/", "/docs/api", "/docs/guide/", "/docs/guide/deep", "/docs/guide/deeper", "/docs/private/keys",
+			},
+			stats: Stats{Pages: 6},
+		},
+		{
+			name:   "robots.txt rules of the agent",
+			robots: "User-agent: *\nDisallow: /\n\nUser-agent: text-lexicon-go\nDisallow: /docs/private/\nAllow: /\n",
+			root:   "/docs/", options: Options{MaxDepth: 3, UserAgent: "text-lexicon-go/1.0"},
+			want:  []string{"/docs/", "/docs/api", "/docs/guide/", "/docs/guide/deep", "/docs/guide/deeper"},
+			stats: Stats{Pages: 5},
+		},
+		{
+			name: "depth", root: "/docs/", options: Options{MaxDepth: 1},
+			want:  []string{"/docs/", "/docs/api", "/docs/guide/", "/docs/private/keys"},
+			stats: Stats{Pages: 4},
+		},
+		{
+			name: "page limit", root: "/docs/", options: Options{MaxDepth: 3, MaxPages: 2},
+			want: []string{"/docs/", "/docs/guide/"}, stats: Stats{Pages: 2},
+		},
+		{
+			name: "prefix", root: "/docs/guide/", options: Options{MaxDepth: 3},
+			want:  []string{"/docs/guide/", "/docs/guide/deep", "/docs/guide/deeper"},
+			stats: Stats{Pages: 3},
+		},
+		{
+			name: "sitemap index", root: "/sitemap.xml", options: Options{MaxSitemapSize: 1 << 20},
+			want: []string{"/blog/", "/docs/api"}, stats: Stats{Pages: 2},
+		},
+		{
+			name: "redirect to another origin", root: "/docs/moved", options: Options{MaxDepth: 3},
+			want: []string{}, stats: Stats{Skipped: 1},
+		},
+		{
+			name: "missing page", root: "/docs/missing", options: Options{MaxDepth: 3},
+			want: []string{}, stats: Stats{Failed: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			site := newSite(t, tt.robots)
+			cfg := &config.Config{}
+			cfg.Documents.MaxDocumentSize = 1 << 20
+			c := NewCrawler(fetcher.NewHTTPFetcher(site.Client(), cfg), tt.options)
+
+			visited := make([]string, 0)
+			visit := func(_ context.Context, page *fetcher.Page) error {
+				visited = append(visited, strings.TrimPrefix(page.URL, site.URL))
+				return nil
+			}
+			stats, err := c.Crawl(context.Background(), site.URL+tt.root, visit)
+			if err != nil {
+				t.Fatalf("Crawl() error = %v", err)
+			}
+
+			sort.Strings(visited)
+			if !reflect.DeepEqual(visited, tt.want) {
+				t.Errorf("Crawl() visited %v, want %v", visited, tt.want)
+			}
+			if *stats != tt.stats {
+				t.Errorf("Crawl() stats = %+v, want %+v", *stats, tt.stats)
+			}
+		})
+	}
+}
+
+func TestCrawlErrors(t *testing.T) {
+	site := newSite(t, "")
+	cfg := &config.Config{}
+	cfg.Documents.MaxDocumentSize = 1 << 20
+	c := NewCrawler(fetcher.NewHTTPFetcher(site.Client(), cfg), Options{MaxDepth: 3})
+
+	if _, err := c.Crawl(context.Background(), "ftp://example.com/docs/", nil); !errors.Is(err, ErrInvalidRoot) {
+		t.Errorf("Crawl() error = %v, want %v", err, ErrInvalidRoot)
+	}
+
+	stop := errors.New("stop")
+	stats, err := c.Crawl(context.Background(), site.URL+"/docs/", func(context.Context, *fetcher.Page) error {
+		return stop
+	})
+	if !errors.Is(err, stop) || stats.Pages != 0 {
+		t.Errorf("Crawl() = %+v, %v, want no pages and %v", stats, err, stop)
+	}
+}
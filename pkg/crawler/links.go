@@ -0,0 +1,111 @@
+package crawler
+
+import (
+	"bytes"
+	"net/url"
+	"path"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Extensions of files which are not documentation pages
+var skippedExtensions = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".svg": true, ".webp": true, ".ico": true,
+	".css": true, ".js": true, ".json": true, ".map": true, ".woff": true, ".woff2": true, ".ttf": true,
+	".pdf": true, ".zip": true, ".gz": true, ".tar": true, ".tgz": true, ".exe": true, ".dmg": true,
+	".mp3": true, ".mp4": true, ".webm": true, ".xml": true, ".rss": true, ".atom": true,
+}
+
+// Links of an html page resolved against the page url or its <base>, nofollow links are skipped
+func extractLinks(body []byte, pageURL *url.URL) []*url.URL {
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil
+	}
+
+	base := pageURL
+	links := make([]*url.URL, 0)
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.DataAtom {
+			case atom.Base:
+				if href := attr(n, "href"); href != "" {
+					if u, err := pageURL.Parse(href); err == nil {
+						base = u
+					}
+				}
+			case atom.A, atom.Area:
+				href := attr(n, "href")
+				rel := strings.ToLower(attr(n, "rel"))
+				if href != "" && !strings.Contains(rel, "nofollow") {
+					if u, err := base.Parse(href); err == nil {
+						links = append(links, u)
+					}
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	return links
+}
+
+func attr(n *html.Node, name string) string {
+	for _, a := range n.Attr {
+		if a.Key == name {
+			return strings.TrimSpace(a.Val)
+		}
+	}
+
+	return ""
+}
+
+// Url without fragment and default port, with cleaned path, so one page has one key
+func normalizeURL(u *url.URL) *url.URL {
+	n := *u
+	n.Fragment = ""
+	n.RawFragment = ""
+	n.Scheme = strings.ToLower(n.Scheme)
+	n.Host = strings.ToLower(n.Host)
+	if (n.Scheme == "http" && n.Port() == "80") || (n.Scheme == "https" && n.Port() == "443") {
+		n.Host = n.Hostname()
+	}
+
+	if n.Path == "" {
+		n.Path = "/"
+	} else {
+		cleaned := path.Clean(n.Path)
+		if strings.HasSuffix(n.Path, "/") && cleaned != "/" {
+			cleaned += "/"
+		}
+		n.Path = cleaned
+	}
+	n.RawPath = ""
+
+	return &n
+}
+
+// Directory of the url path used as the default crawl prefix: "/docs/guide/intro" gives "/docs/guide/"
+func pathPrefix(u *url.URL) string {
+	if strings.HasSuffix(u.Path, "/") {
+		return u.Path
+	}
+
+	dir := path.Dir(u.Path)
+	if dir == "/" || dir == "." {
+		return "/"
+	}
+
+	return dir + "/"
+}
+
+func skippedExtension(u *url.URL) bool {
+	return skippedExtensions[strings.ToLower(path.Ext(u.Path))]
+}
@@ -0,0 +1,137 @@
+package crawler
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rules of robots.txt which apply to the crawler
+type robots struct {
+	rules      []robotsRule
+	crawlDelay time.Duration
+	sitemaps   []string
+}
+
+type robotsRule struct {
+	allow   bool
+	pattern string
+}
+
+// Parse robots.txt and keep the group of the user agent, or the "*" group when there is no own group
+func parseRobots(data []byte, userAgent string) *robots {
+	agent := strings.ToLower(userAgent)
+	if name, _, ok := strings.Cut(agent, "/"); ok {
+		agent = name
+	}
+
+	type group struct {
+		agents []string
+		rules  []robotsRule
+		delay  time.Duration
+	}
+	groups := make([]*group, 0)
+	result := &robots{}
+
+	var current *group
+	// Consecutive user-agent lines start one group
+	inAgents := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if !inAgents || current == nil {
+				current = &group{}
+				groups = append(groups, current)
+			}
+			current.agents = append(current.agents, strings.ToLower(value))
+			inAgents = true
+			continue
+		case "allow", "disallow":
+			// Empty disallow allows everything
+			if current != nil && value != "" {
+				current.rules = append(current.rules, robotsRule{allow: key == "allow", pattern: value})
+			}
+		case "crawl-delay":
+			if seconds, err := strconv.ParseFloat(value, 64); err == nil && current != nil {
+				current.delay = time.Duration(seconds * float64(time.Second))
+			}
+		case "sitemap":
+			result.sitemaps = append(result.sitemaps, value)
+		}
+		inAgents = false
+	}
+
+	var matched *group
+	for _, g := range groups {
+		for _, name := range g.agents {
+			if name != "*" && agent != "" && strings.Contains(agent, name) {
+				matched = g
+			}
+			if name == "*" && matched == nil {
+				matched = g
+			}
+		}
+	}
+	if matched != nil {
+		result.rules = matched.rules
+		result.crawlDelay = matched.delay
+	}
+
+	return result
+}
+
+// Check if path is allowed: the longest matching rule wins, allow wins a tie
+func (r *robots) allowed(path string) bool {
+	if r == nil {
+		return true
+	}
+
+	allowed, length := true, -1
+	for _, rule := range r.rules {
+		if !matchRobotsPattern(rule.pattern, path) {
+			continue
+		}
+		if len(rule.pattern) > length || (len(rule.pattern) == length && rule.allow) {
+			allowed, length = rule.allow, len(rule.pattern)
+		}
+	}
+
+	return allowed
+}
+
+// Match robots.txt path pattern with "*" wildcards and "$" end anchor
+func matchRobotsPattern(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+
+	for i, part := range parts[1:] {
+		last := i == len(parts)-2
+		if last && anchored {
+			return strings.HasSuffix(rest, part)
+		}
+		index := strings.Index(rest, part)
+		if index < 0 {
+			return false
+		}
+		rest = rest[index+len(part):]
+	}
+
+	return !anchored || rest == ""
+}
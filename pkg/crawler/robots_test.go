@@ -0,0 +1,102 @@
+package crawler
+
+import (
+	"testing"
+	"time"
+)
+
+const robotsTxt = `# Documentation site
+User-agent: *
+Disallow: /
+Allow: /docs/
+
+User-agent: text-lexicon-go
+User-agent: other-bot
+Disallow: /docs/private/
+Disallow: /*.pdf$
+Allow: /docs/private/public*
+Crawl-delay: 1.5
+
+Sitemap: https://example.com/sitemap.xml
+`
+
+func TestParseRobots(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		delay     time.Duration
+		allowed   map[string]bool
+	}{
+		{
+			name: "own group", userAgent: "text-lexicon-go/1.0", delay: 1500 * time.Millisecond,
+			allowed: map[string]bool{
+				"/":                          true,
+				"/docs/intro":                true,
+				"/docs/private/keys":         false,
+				"/docs/private/public-notes": true,
+				"/docs/manual.pdf":           false,
+				"/docs/manual.pdf.html":      true,
+			},
+		},
+		{
+			name: "wildcard group", userAgent: "unknown-bot",
+			allowed: map[string]bool{
+				"/":                  false,
+				"/blog/":             false,
+				"/docs/intro":        true,
+				"/docs/private/keys": true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := parseRobots([]byte(robotsTxt), tt.userAgent)
+			if r.crawlDelay != tt.delay {
+				t.Errorf("crawlDelay = %v, want %v", r.crawlDelay, tt.delay)
+			}
+			if len(r.sitemaps) != 1 || r.sitemaps[0] != "https://example.com/sitemap.xml" {
+				t.Errorf("sitemaps = %v, want the site sitemap", r.sitemaps)
+			}
+			for path, want := range tt.allowed {
+				if got := r.allowed(path); got != want {
+					t.Errorf("allowed(%q) = %v, want %v", path, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRobotsAllowed(t *testing.T) {
+	var missing *robots
+	if !missing.allowed("/docs/") {
+		t.Error("allowed() = false without robots.txt")
+	}
+
+	empty := parseRobots([]byte("User-agent: *\nDisallow:\n"), "text-lexicon-go")
+	if !empty.allowed("/docs/") {
+		t.Error("allowed() = false with an empty disallow")
+	}
+}
+
+func TestMatchRobotsPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "/docs/", path: "/docs/intro", want: true},
+		{pattern: "/docs/", path: "/doc", want: false},
+		{pattern: "/*.pdf", path: "/docs/manual.pdf", want: true},
+		{pattern: "/*.pdf$", path: "/docs/manual.pdf?page=1", want: false},
+		{pattern: "/docs/*/edit", path: "/docs/guide/edit/page", want: true},
+		{pattern: "/docs$", path: "/docs", want: true},
+		{pattern: "/docs$", path: "/docs/", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := matchRobotsPattern(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("matchRobotsPattern(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package crawler
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// Sitemap or sitemap index, only locations are used
+type sitemap struct {
+	URLs     []sitemapLocation `xml:"url"`
+	Sitemaps []sitemapLocation `xml:"sitemap"`
+}
+
+type sitemapLocation struct {
+	Loc string `xml:"loc"`
+}
+
+// Check if url points to a sitemap
+func isSitemap(rawURL string) bool {
+	path, _, _ := strings.Cut(rawURL, "?")
+	path = strings.ToLower(path)
+
+	return strings.HasSuffix(path, ".xml") || strings.HasSuffix(path, ".xml.gz")
+}
+
+// Parse sitemap, gzip compressed sitemaps are unpacked, returns page urls and urls of nested sitemaps
+func parseSitemap(data []byte, maxSize int64) ([]string, []string, error) {
+	if len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b {
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, nil, err
+		}
+		if data, err = io.ReadAll(io.LimitReader(r, maxSize)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	s := &sitemap{}
+	if err := xml.Unmarshal(data, s); err != nil {
+		return nil, nil, err
+	}
+
+	pages := make([]string, 0, len(s.URLs))
+	for _, u := range s.URLs {
+		if loc := strings.TrimSpace(u.Loc); loc != "" {
+			pages = append(pages, loc)
+		}
+	}
+
+	nested := make([]string, 0, len(s.Sitemaps))
+	for _, u := range s.Sitemaps {
+		if loc := strings.TrimSpace(u.Loc); loc != "" {
+			nested = append(nested, loc)
+		}
+	}
+
+	return pages, nested, nil
+}
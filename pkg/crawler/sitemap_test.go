@@ -0,0 +1,90 @@
+package crawler
+
+import (
+	"bytes"
+	"compress/gzip"
+	"reflect"
+	"testing"
+)
+
+func gzipped(t *testing.T, data string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(data)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestParseSitemap(t *testing.T) {
+	const urlset = `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc> https://example.com/docs/ </loc><lastmod>2024-09-01</lastmod></url>
+  <url><loc>https://example.com/docs/api</loc></url>
+  <url><loc></loc></url>
+</urlset>`
+	const index = `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>https://example.com/docs.xml.gz</loc></sitemap>
+</sitemapindex>`
+
+	tests := []struct {
+		name    string
+		data    []byte
+		maxSize int64
+		pages   []string
+		nested  []string
+		wantErr bool
+	}{
+		{
+			name: "urlset", data: []byte(urlset), maxSize: 1 << 20,
+			pages: []string{"https://example.com/docs/", "https://example.com/docs/api"}, nested: []string{},
+		},
+		{
+			name: "gzip", data: gzipped(t, urlset), maxSize: 1 << 20,
+			pages: []string{"https://example.com/docs/", "https://example.com/docs/api"}, nested: []string{},
+		},
+		{
+			name: "index", data: []byte(index), maxSize: 1 << 20,
+			pages: []string{}, nested: []string{"https://example.com/docs.xml.gz"},
+		},
+		{name: "gzip over the size limit", data: gzipped(t, urlset), maxSize: 64, wantErr: true},
+		{name: "not xml", data: []byte("User-agent: *"), maxSize: 1 << 20, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages, nested, err := parseSitemap(tt.data, tt.maxSize)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSitemap() error = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(pages, tt.pages) || !reflect.DeepEqual(nested, tt.nested) {
+				t.Errorf("parseSitemap() = %v, %v, want %v, %v", pages, nested, tt.pages, tt.nested)
+			}
+		})
+	}
+}
+
+func TestIsSitemap(t *testing.T) {
+	tests := map[string]bool{
+		"https://example.com/sitemap.xml":         true,
+		"https://example.com/SITEMAP.XML?lang=en": true,
+		"https://example.com/sitemap.xml.gz":      true,
+		"https://example.com/docs/":               false,
+		"https://example.com/docs?format=xml":     false,
+	}
+
+	for rawURL, want := range tests {
+		if got := isSitemap(rawURL); got != want {
+			t.Errorf("isSitemap(%q) = %v, want %v", rawURL, got, want)
+		}
+	}
+}
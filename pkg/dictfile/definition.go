@@ -0,0 +1,131 @@
+package dictfile
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	transcriptionRe = regexp.MustCompile(`^\s*(?:\[([^\]]+)\]|/([^/]+)/)`)
+	// Leading part of speech label: "n.", "adj", "гл."
+	posLabelRe = regexp.MustCompile(`^([\p{L}]+)(\.?)(?:\s+|$)`)
+	// Sense numbers: "1.", "2)", "а)"
+	senseNumberRe = regexp.MustCompile(`^(?:\d+|[a-zа-я])[.)]\s*`)
+	// Example with translation: "an apple a day — яблоко в день"
+	exampleRe = regexp.MustCompile(`^(.+?)\s+[—–-]\s+(.+)$`)
+
+	markupTranscriptionRe = regexp.MustCompile(`(?is)<tr>(.*?)</tr>`)
+	markupExampleRe       = regexp.MustCompile(`(?is)<ex>(.*?)</ex>`)
+	markupLabelRe         = regexp.MustCompile(`(?is)<(abbr|abr|pos|gr)>(.*?)</(?:abbr|abr|pos|gr)>`)
+	markupBreakRe         = regexp.MustCompile(`(?i)<br\s*/?>|</(?:p|div|li|def|blockquote)>`)
+	markupTagRe           = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// Marker of example lines in text converted from markup
+const exampleMarker = "\x00ex "
+
+// ParseDefinition guesses structure of a plain text dictionary article:
+// a leading transcription in brackets or slashes, part of speech labels, numbered senses and examples.
+// Every line which is not a label or an example becomes a sense with comma separated translations.
+func ParseDefinition(text string) (string, []Sense) {
+	transcription := ""
+	senses := make([]Sense, 0)
+	pos := ""
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+
+		if transcription == "" {
+			if m := transcriptionRe.FindStringSubmatch(line); m != nil {
+				transcription = m[1] + m[2]
+				line = strings.TrimSpace(line[len(m[0]):])
+			}
+		}
+
+		if strings.HasPrefix(line, exampleMarker) || (len(senses) > 0 && isExampleLine(line)) {
+			addExample(senses, strings.TrimPrefix(line, exampleMarker))
+			continue
+		}
+
+		if m := posLabelRe.FindStringSubmatch(line); m != nil && isPartOfSpeechLabel(m[1], m[2], line) {
+			pos = PartOfSpeech(m[1])
+			line = strings.TrimSpace(line[len(m[0]):])
+		}
+
+		line = strings.TrimSpace(senseNumberRe.ReplaceAllString(line, ""))
+		if line == "" {
+			continue
+		}
+
+		senses = append(senses, Sense{PartOfSpeech: pos, Translations: SplitTranslations(line)})
+	}
+
+	return transcription, senses
+}
+
+// MarkupText converts html, xdxf or pango markup of a definition to plain text for ParseDefinition,
+// xdxf transcriptions are returned separately and examples are kept on their own lines
+func MarkupText(markup string) (string, string) {
+	transcription := ""
+	if m := markupTranscriptionRe.FindStringSubmatch(markup); m != nil {
+		transcription = cleanText(m[1])
+		markup = strings.Replace(markup, m[0], "", 1)
+	}
+
+	markup = markupExampleRe.ReplaceAllStringFunc(
+		markup, func(ex string) string {
+			return "\n" + exampleMarker + cleanText(markupExampleRe.FindStringSubmatch(ex)[1]) + "\n"
+		},
+	)
+	// Part of speech labels get their own line, other labels like "colloq." are not translations
+	markup = markupLabelRe.ReplaceAllStringFunc(
+		markup, func(label string) string {
+			text := cleanText(markupLabelRe.FindStringSubmatch(label)[2])
+			if PartOfSpeech(text) != "" {
+				return "\n" + strings.TrimSuffix(text, ".") + ".\n"
+			}
+			return ""
+		},
+	)
+	markup = markupBreakRe.ReplaceAllString(markup, "\n")
+
+	return transcription, html.UnescapeString(markupTagRe.ReplaceAllString(markup, ""))
+}
+
+// Short labels are only taken with a period or on their own line, so "a" in "a lot" stays a word
+func isPartOfSpeechLabel(label, period, line string) bool {
+	if PartOfSpeech(label) == "" {
+		return false
+	}
+
+	return period != "" || len([]rune(label)) > 2 || strings.TrimSpace(line) == label
+}
+
+// Example is an english phrase followed by a translation
+func isExampleLine(line string) bool {
+	m := exampleRe.FindStringSubmatch(line)
+	return m != nil && !HasCyrillic(m[1]) && HasCyrillic(m[2]) && strings.Contains(strings.TrimSpace(m[1]), " ")
+}
+
+// Attach example to the last sense
+func addExample(senses []Sense, line string) {
+	if len(senses) == 0 {
+		return
+	}
+
+	example := Example{Text: strings.TrimSpace(line)}
+	if m := exampleRe.FindStringSubmatch(example.Text); m != nil {
+		example = Example{Text: strings.TrimSpace(m[1]), Translation: strings.TrimSpace(m[2])}
+	}
+	if example.Text == "" {
+		return
+	}
+
+	last := &senses[len(senses)-1]
+	last.Examples = append(last.Examples, example)
+}
+
+func cleanText(markup string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(markupTagRe.ReplaceAllString(markup, ""))), " ")
+}
@@ -0,0 +1,310 @@
+package dsl
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/dictfile"
+)
+
+// Longest line of a dictionary file, articles with longer lines are rejected
+const maxLineSize = 1 << 20
+
+var (
+	headerRe = regexp.MustCompile(`^#(\w+)\s+"?([^"]*)"?`)
+	// Tag with optional attribute: [m1], [/m], [c red], [lang id=1033]
+	tagRe       = regexp.MustCompile(`\[(/?)([a-z*'!]+)\d*(?:\s[^\]]*)?\]`)
+	commentRe   = regexp.MustCompile(`\{\{.*?\}\}`)
+	linkRe      = regexp.MustCompile(`<<(.*?)>>`)
+	unsortedRe  = regexp.MustCompile(`\{([^}]*)\}`)
+	optionalRe  = regexp.MustCompile(`\([^)]*\)`)
+	senseNumber = regexp.MustCompile(`^\s*(?:\d+|[a-zа-я])[.)]\s*`)
+	exampleSep  = regexp.MustCompile(`\s+[—–-]\s+`)
+	// Transcriptions, labels, comments, sounds and links which are not translations
+	nonTranslationRe = regexp.MustCompile(`\[(t|p|com|s|url|\*)(?:\s[^\]]*)?\].*?\[/(?:t|p|com|s|url|\*)\]`)
+)
+
+// Read ABBYY Lingvo DSL dictionary, UTF-16 files with byte order mark and UTF-8 files are supported,
+// .dsl.dz is read through gzip. The file is processed line by line, only one article is kept in memory.
+func Read(path string, handler dictfile.Handler) (*dictfile.Info, error) {
+	const op = "pkg.dictfile.dsl.read"
+
+	f, err := dictfile.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s.Open: %w", op, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	info := &dictfile.Info{}
+	headwords := make([]string, 0)
+	body := make([]string, 0)
+
+	flush := func() error {
+		defer func() {
+			headwords, body = headwords[:0], body[:0]
+		}()
+
+		if len(headwords) == 0 {
+			return nil
+		}
+		transcription, senses := parseBody(body)
+
+		for _, headword := range headwords {
+			entry := &dictfile.Entry{Headword: headword, Transcription: transcription, Senses: cloneSenses(senses)}
+			if !entry.Normalize() {
+				continue
+			}
+			if err := handler(entry); err != nil {
+				return err
+			}
+			info.WordCount++
+		}
+
+		return nil
+	}
+
+	scanner := bufio.NewScanner(transform.NewReader(f, unicode.BOMOverride(unicode.UTF8.NewDecoder())))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		// Invalid bytes are replaced by the decoder, legacy 8-bit files are not supported
+		if lineNumber == 1 && strings.ContainsRune(line, utf8.RuneError) {
+			return nil, fmt.Errorf("%s: %w: unsupported encoding", op, dictfile.ErrInvalidFormat)
+		}
+
+		switch {
+		case strings.HasPrefix(line, "#"):
+			parseHeader(info, line)
+		case strings.TrimSpace(line) == "":
+			continue
+		case line[0] == ' ' || line[0] == '\t':
+			body = append(body, line)
+		default:
+			// Several headword lines in a row share one article
+			if len(body) > 0 {
+				if err = flush(); err != nil {
+					return nil, fmt.Errorf("%s.handler: %w", op, err)
+				}
+			}
+			if headword := cleanHeadword(line); headword != "" {
+				headwords = append(headwords, headword)
+			}
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s.Scan: %w", op, err)
+	}
+
+	if err = flush(); err != nil {
+		return nil, fmt.Errorf("%s.handler: %w", op, err)
+	}
+
+	return info, nil
+}
+
+func parseHeader(info *dictfile.Info, line string) {
+	m := headerRe.FindStringSubmatch(line)
+	if m == nil {
+		return
+	}
+
+	switch strings.ToUpper(m[1]) {
+	case "NAME":
+		info.Name = strings.TrimSpace(m[2])
+	case "INDEX_LANGUAGE":
+		info.SourceLanguage = languageCode(m[2])
+	case "CONTENTS_LANGUAGE":
+		info.TargetLanguage = languageCode(m[2])
+	}
+}
+
+// Headword without unsorted parts in braces and optional parts in parentheses
+func cleanHeadword(line string) string {
+	line = unsortedRe.ReplaceAllStringFunc(
+		line, func(s string) string {
+			if strings.HasPrefix(s, "{(") {
+				return ""
+			}
+			return strings.Trim(s, "{}")
+		},
+	)
+	line = optionalRe.ReplaceAllString(unescape(line), "")
+
+	return strings.Join(strings.Fields(line), " ")
+}
+
+// Parse article body line by line: [t] holds the transcription, [p] starts a part of speech,
+// [ex] lines are examples of the previous sense and other lines are senses, translations are taken
+// from [trn] tags when they are present.
+func parseBody(lines []string) (string, []dictfile.Sense) {
+	transcription := ""
+	pos := ""
+	senses := make([]dictfile.Sense, 0)
+
+	for _, line := range lines {
+		line = commentRe.ReplaceAllString(line, "")
+		tags := tagContents(line)
+
+		if t, ok := tags["t"]; ok && transcription == "" {
+			transcription = strings.Join(t, ", ")
+		}
+		if p, ok := tags["p"]; ok {
+			for _, label := range p {
+				if partOfSpeech := dictfile.PartOfSpeech(label); partOfSpeech != "" {
+					pos = partOfSpeech
+					break
+				}
+			}
+		}
+
+		if examples, ok := tags["ex"]; ok {
+			if len(senses) > 0 {
+				last := &senses[len(senses)-1]
+				for _, ex := range examples {
+					last.Examples = append(last.Examples, splitExample(ex))
+				}
+			}
+			continue
+		}
+
+		var text string
+		if trn, ok := tags["trn"]; ok {
+			text = strings.Join(trn, "; ")
+		} else {
+			text = plainText(removeNonTranslation(line))
+		}
+		text = strings.TrimSpace(senseNumber.ReplaceAllString(text, ""))
+		if text == "" {
+			continue
+		}
+
+		senses = append(senses, dictfile.Sense{PartOfSpeech: pos, Translations: dictfile.SplitTranslations(text)})
+	}
+
+	return transcription, senses
+}
+
+// Plain text of every tag found in the line grouped by tag name
+func tagContents(line string) map[string][]string {
+	result := make(map[string][]string)
+
+	type open struct {
+		name  string
+		start int
+	}
+	stack := make([]open, 0)
+
+	for _, loc := range tagRe.FindAllStringSubmatchIndex(line, -1) {
+		if escaped(line, loc[0]) {
+			continue
+		}
+		closing := line[loc[2]:loc[3]] == "/"
+		name := line[loc[4]:loc[5]]
+
+		if !closing {
+			stack = append(stack, open{name: name, start: loc[1]})
+			continue
+		}
+
+		for i := len(stack) - 1; i >= 0; i-- {
+			if stack[i].name != name {
+				continue
+			}
+			if text := plainText(line[stack[i].start:loc[0]]); text != "" {
+				result[name] = append(result[name], text)
+			}
+			stack = append(stack[:i], stack[i+1:]...)
+			break
+		}
+	}
+
+	return result
+}
+
+// Remove tags which are not a part of translation together with their content
+func removeNonTranslation(line string) string {
+	return nonTranslationRe.ReplaceAllString(line, "")
+}
+
+// Line without markup, links and escapes
+func plainText(s string) string {
+	s = linkRe.ReplaceAllString(s, "$1")
+
+	var b bytes.Buffer
+	last := 0
+	for _, loc := range tagRe.FindAllStringIndex(s, -1) {
+		if escaped(s, loc[0]) {
+			continue
+		}
+		b.WriteString(s[last:loc[0]])
+		last = loc[1]
+	}
+	b.WriteString(s[last:])
+
+	return strings.Join(strings.Fields(unescape(b.String())), " ")
+}
+
+func escaped(s string, i int) bool {
+	return i > 0 && s[i-1] == '\\'
+}
+
+func unescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+
+	return b.String()
+}
+
+// Example is written as "phrase — translation"
+func splitExample(text string) dictfile.Example {
+	parts := exampleSep.Split(text, 2)
+	if len(parts) == 2 {
+		return dictfile.Example{Text: strings.TrimSpace(parts[0]), Translation: strings.TrimSpace(parts[1])}
+	}
+
+	return dictfile.Example{Text: strings.TrimSpace(text)}
+}
+
+// Each headword gets its own senses so normalization of one entry does not affect others
+func cloneSenses(senses []dictfile.Sense) []dictfile.Sense {
+	result := make([]dictfile.Sense, len(senses))
+	for i, s := range senses {
+		result[i] = dictfile.Sense{
+			PartOfSpeech: s.PartOfSpeech,
+			Translations: append([]string(nil), s.Translations...),
+			Examples:     append([]dictfile.Example(nil), s.Examples...),
+		}
+	}
+
+	return result
+}
+
+var languages = map[string]string{
+	"english": "en", "russian": "ru", "german": "de", "french": "fr", "spanish": "es", "italian": "it",
+	"ukrainian": "uk", "polish": "pl", "portuguese": "pt", "chinese": "zh", "japanese": "ja",
+}
+
+// ISO 639-1 code of a Lingvo language name
+func languageCode(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if code, ok := languages[name]; ok {
+		return code
+	}
+
+	return name
+}
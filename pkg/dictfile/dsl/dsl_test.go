@@ -0,0 +1,134 @@
+package dsl
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"golang.org/x/text/encoding/unicode"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/dictfile"
+)
+
+const article = "#NAME \"Computer Terms\"\n" +
+	"#INDEX_LANGUAGE \"English\"\n" +
+	"#CONTENTS_LANGUAGE \"Russian\"\n" +
+	"\n" +
+	"queue\n" +
+	"\t[m1][t]kjuː[/t] [p]n[/p][/m]\n" +
+	"\t[m1]1. [trn]очередь[/trn][/m]\n" +
+	"\t[m2][ex][lang id=1033]job queue[/lang] — очередь заданий[/ex][/m]\n" +
+	"\t[m1]2. [trn]хвост, коса[/trn] [com](устар.)[/com][/m]\n" +
+	"cache{s}\n" +
+	"(web) cache\n" +
+	"\t[m1][p]n[/p] кэш, <<buffer>> буфер[/m]\n" +
+	"label\n" +
+	"\t[m1][com]no translations[/com][/m]\n"
+
+// Headwords of one article share its senses, links are kept as text
+var cacheTranslations = []string{"кэш", "buffer буфер"}
+
+var want = []*dictfile.Entry{
+	{
+		Headword: "queue", Transcription: "kjuː",
+		Senses: []dictfile.Sense{
+			{
+				PartOfSpeech: "noun", Translations: []string{"очередь"},
+				Examples: []dictfile.Example{{Text: "job queue", Translation: "очередь заданий"}},
+			},
+			{PartOfSpeech: "noun", Translations: []string{"хвост", "коса"}},
+		},
+	},
+	{Headword: "caches", Senses: []dictfile.Sense{{PartOfSpeech: "noun", Translations: cacheTranslations}}},
+	{Headword: "cache", Senses: []dictfile.Sense{{PartOfSpeech: "noun", Translations: cacheTranslations}}},
+}
+
+func write(t *testing.T, name string, body []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, body, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func gzipped(t *testing.T, body []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestRead(t *testing.T) {
+	utf16, err := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder().Bytes([]byte(article))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		file string
+		body []byte
+		err  error
+	}{
+		{name: "utf-8", file: "terms.dsl", body: []byte(article)},
+		{name: "utf-16 with bom", file: "terms.dsl", body: utf16},
+		{name: "dictzip", file: "terms.dsl.dz", body: gzipped(t, utf16)},
+		{
+			name: "legacy encoding", file: "terms.dsl", body: []byte("#NAME \"\xcc\xee\xe9\"\n"),
+			err: dictfile.ErrInvalidFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := make([]*dictfile.Entry, 0)
+			info, err := Read(write(t, tt.file, tt.body), func(entry *dictfile.Entry) error {
+				entries = append(entries, entry)
+				return nil
+			})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Read() error = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+
+			wantInfo := &dictfile.Info{Name: "Computer Terms", SourceLanguage: "en", TargetLanguage: "ru", WordCount: 3}
+			if !reflect.DeepEqual(info, wantInfo) {
+				t.Errorf("Read() info = %+v, want %+v", info, wantInfo)
+			}
+			if !reflect.DeepEqual(entries, want) {
+				got, _ := json.Marshal(entries)
+				expected, _ := json.Marshal(want)
+				t.Errorf("Read() entries = %s, want %s", got, expected)
+			}
+		})
+	}
+}
+
+func TestReadHandlerError(t *testing.T) {
+	stop := errors.New("stop")
+
+	_, err := Read(write(t, "terms.dsl", []byte(article)), func(*dictfile.Entry) error {
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("Read() error = %v, want %v", err, stop)
+	}
+}
@@ -0,0 +1,121 @@
+package dictfile
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidFormat = errors.New("invalid dictionary format")
+
+// Dictionary article
+type Entry struct {
+	Headword      string
+	Transcription string
+	Senses        []Sense
+}
+
+type Sense struct {
+	PartOfSpeech string
+	Translations []string
+	Examples     []Example
+}
+
+type Example struct {
+	Text        string
+	Translation string
+}
+
+// Handler is called for every parsed entry, returned error stops reading
+type Handler func(entry *Entry) error
+
+// Dictionary metadata found in file headers
+type Info struct {
+	Name           string
+	SourceLanguage string
+	TargetLanguage string
+	WordCount      int
+}
+
+// Drop senses without translations, returns false when nothing is left
+func (e *Entry) Normalize() bool {
+	e.Headword = strings.TrimSpace(e.Headword)
+	e.Transcription = strings.TrimSpace(e.Transcription)
+
+	senses := e.Senses[:0]
+	for _, s := range e.Senses {
+		translations := s.Translations[:0]
+		for _, t := range s.Translations {
+			if t = strings.TrimSpace(t); t != "" {
+				translations = append(translations, t)
+			}
+		}
+		s.Translations = translations
+		s.PartOfSpeech = strings.TrimSpace(s.PartOfSpeech)
+
+		if len(s.Translations) > 0 {
+			senses = append(senses, s)
+		}
+	}
+	e.Senses = senses
+
+	return e.Headword != "" && len(e.Senses) > 0
+}
+
+// Split translation variants separated by commas or semicolons, commas inside brackets are kept
+func SplitTranslations(text string) []string {
+	result := make([]string, 0)
+	depth := 0
+	start := 0
+
+	for i, r := range text {
+		switch r {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth = max(depth-1, 0)
+		case ',', ';':
+			if depth == 0 {
+				if t := strings.TrimSpace(text[start:i]); t != "" {
+					result = append(result, t)
+				}
+				start = i + 1
+			}
+		}
+	}
+	if t := strings.TrimSpace(text[start:]); t != "" {
+		result = append(result, t)
+	}
+
+	return result
+}
+
+// Common part of speech labels used in bilingual dictionaries
+var partsOfSpeech = map[string]string{
+	"n": "noun", "noun": "noun", "сущ": "noun",
+	"v": "verb", "verb": "verb", "vt": "verb", "vi": "verb", "гл": "verb",
+	"a": "adjective", "adj": "adjective", "adjective": "adjective", "прил": "adjective",
+	"adv": "adverb", "adverb": "adverb", "нареч": "adverb",
+	"prep": "preposition", "preposition": "preposition", "предл": "preposition",
+	"pron": "pronoun", "pronoun": "pronoun", "мест": "pronoun",
+	"conj": "conjunction", "conjunction": "conjunction", "союз": "conjunction",
+	"int": "interjection", "interj": "interjection", "interjection": "interjection", "межд": "interjection",
+	"num": "numeral", "numeral": "numeral", "числ": "numeral",
+	"abbr": "abbreviation", "сокр": "abbreviation",
+}
+
+// Normalized part of speech for a dictionary label like "n.", "adj" or "гл.", empty for unknown labels
+func PartOfSpeech(label string) string {
+	return partsOfSpeech[strings.ToLower(strings.Trim(label, " .,;:"))]
+}
+
+// Check if text contains cyrillic letters
+func HasCyrillic(text string) bool {
+	for _, r := range text {
+		if unicode.Is(unicode.Cyrillic, r) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,50 @@
+package dictfile
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Open dictionary file, dictzip (.dz) and gzip (.gz) files are decompressed on the fly
+func Open(path string) (io.ReadCloser, error) {
+	const op = "pkg.dictfile.open"
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s.Open: %w", op, err)
+	}
+
+	if !IsCompressed(path) {
+		return f, nil
+	}
+
+	zr, err := gzip.NewReader(bufio.NewReader(f))
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("%s.gzip: %w", op, err)
+	}
+
+	return &compressedFile{Reader: zr, file: f}, nil
+}
+
+func IsCompressed(path string) bool {
+	return strings.HasSuffix(path, ".dz") || strings.HasSuffix(path, ".gz")
+}
+
+type compressedFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (f *compressedFile) Close() error {
+	if err := f.Reader.Close(); err != nil {
+		_ = f.file.Close()
+		return err
+	}
+
+	return f.file.Close()
+}
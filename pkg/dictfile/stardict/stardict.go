@@ -0,0 +1,295 @@
+package stardict
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/dictfile"
+)
+
+const ifoMagic = "StarDict's dict ifo file"
+
+// Longest word in the index, the format limits it to 256 bytes
+const maxWordSize = 256
+
+var ErrMissingFile = errors.New("stardict file not found")
+
+// Dictionary description from the .ifo file
+type ifo struct {
+	bookName         string
+	wordCount        int
+	idxOffsetBits    int
+	sameTypeSequence string
+}
+
+// Files of a StarDict dictionary given by path to its .ifo file: .ifo, index and articles
+func Files(path string) ([]string, error) {
+	base := strings.TrimSuffix(path, ".ifo")
+
+	idx, err := existing(base+".idx", base+".idx.gz")
+	if err != nil {
+		return nil, err
+	}
+
+	dict, err := existing(base+".dict", base+".dict.dz")
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{base + ".ifo", idx, dict}, nil
+}
+
+// Read StarDict dictionary given by path to its .ifo file.
+// The index is read sequentially and every article is read from .dict or .dict.dz by its offset,
+// a compressed dictionary is unpacked into a temporary file first.
+func Read(path string, handler dictfile.Handler) (*dictfile.Info, error) {
+	const op = "pkg.dictfile.stardict.read"
+
+	base := strings.TrimSuffix(path, ".ifo")
+
+	info, err := readIfo(base + ".ifo")
+	if err != nil {
+		return nil, fmt.Errorf("%s.readIfo: %w", op, err)
+	}
+
+	dict, cleanup, err := openDict(base)
+	if err != nil {
+		return nil, fmt.Errorf("%s.openDict: %w", op, err)
+	}
+	defer cleanup()
+
+	idxPath, err := existing(base+".idx", base+".idx.gz")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	idx, err := dictfile.Open(idxPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s.Open: %w", op, err)
+	}
+	defer func() {
+		_ = idx.Close()
+	}()
+
+	err = readIndex(
+		bufio.NewReader(idx), info.idxOffsetBits, func(word string, offset uint64, size uint32) error {
+			data := make([]byte, size)
+			if _, err := dict.ReadAt(data, int64(offset)); err != nil {
+				return fmt.Errorf("ReadAt %q: %w", word, err)
+			}
+
+			entry := parseArticle(word, data, info.sameTypeSequence)
+			if !entry.Normalize() {
+				return nil
+			}
+
+			return handler(entry)
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s.readIndex: %w", op, err)
+	}
+
+	return &dictfile.Info{Name: info.bookName, WordCount: info.wordCount}, nil
+}
+
+func readIfo(path string) (*ifo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if len(lines) == 0 || strings.TrimSpace(strings.TrimPrefix(lines[0], "\uFEFF")) != ifoMagic {
+		return nil, dictfile.ErrInvalidFormat
+	}
+
+	info := &ifo{idxOffsetBits: 32}
+	for _, line := range lines[1:] {
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+
+		switch strings.TrimSpace(key) {
+		case "bookname":
+			info.bookName = strings.TrimSpace(value)
+		case "wordcount":
+			info.wordCount, _ = strconv.Atoi(strings.TrimSpace(value))
+		case "idxoffsetbits":
+			if strings.TrimSpace(value) == "64" {
+				info.idxOffsetBits = 64
+			}
+		case "sametypesequence":
+			info.sameTypeSequence = strings.TrimSpace(value)
+		}
+	}
+
+	return info, nil
+}
+
+// Open .dict for random access, .dict.dz is unpacked into a temporary file removed by cleanup
+func openDict(base string) (io.ReaderAt, func(), error) {
+	path, err := existing(base+".dict", base+".dict.dz")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !dictfile.IsCompressed(path) {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		return f, func() { _ = f.Close() }, nil
+	}
+
+	compressed, err := dictfile.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		_ = compressed.Close()
+	}()
+
+	tmp, err := os.CreateTemp("", "stardict-*.dict")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}
+
+	if _, err = io.Copy(tmp, compressed); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	return tmp, cleanup, nil
+}
+
+// Walk index records: zero terminated word followed by big endian offset and size of the article
+func readIndex(r *bufio.Reader, offsetBits int, fn func(word string, offset uint64, size uint32) error) error {
+	offsetSize := offsetBits / 8
+
+	for {
+		word, err := r.ReadBytes(0)
+		if errors.Is(err, io.EOF) && len(word) == 0 {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: truncated index", dictfile.ErrInvalidFormat)
+		}
+		if len(word) > maxWordSize+1 {
+			return fmt.Errorf("%w: index word is too long", dictfile.ErrInvalidFormat)
+		}
+
+		buf := make([]byte, offsetSize+4)
+		if _, err = io.ReadFull(r, buf); err != nil {
+			return fmt.Errorf("%w: truncated index", dictfile.ErrInvalidFormat)
+		}
+
+		var offset uint64
+		if offsetSize == 8 {
+			offset = binary.BigEndian.Uint64(buf)
+		} else {
+			offset = uint64(binary.BigEndian.Uint32(buf))
+		}
+
+		if err = fn(string(word[:len(word)-1]), offset, binary.BigEndian.Uint32(buf[offsetSize:])); err != nil {
+			return err
+		}
+	}
+}
+
+// Parse article fields. With sametypesequence every field has the type from the sequence and
+// the last field takes the rest of data, otherwise every field starts with its type character.
+// Lowercase types are zero terminated text, uppercase are binary prefixed with their size.
+func parseArticle(word string, data []byte, sameTypeSequence string) *dictfile.Entry {
+	entry := &dictfile.Entry{Headword: word}
+
+	types := []byte(sameTypeSequence)
+	for i := 0; len(data) > 0; i++ {
+		var fieldType byte
+		if len(types) > 0 {
+			if i >= len(types) {
+				break
+			}
+			fieldType = types[i]
+		} else {
+			fieldType, data = data[0], data[1:]
+		}
+		last := len(types) > 0 && i == len(types)-1
+
+		var field []byte
+		switch {
+		case last:
+			field, data = data, nil
+		case fieldType >= 'a' && fieldType <= 'z':
+			if end := bytes.IndexByte(data, 0); end >= 0 {
+				field, data = data[:end], data[end+1:]
+			} else {
+				field, data = data, nil
+			}
+		default:
+			if len(data) < 4 {
+				return entry
+			}
+			size := int(binary.BigEndian.Uint32(data))
+			data = data[4:]
+			if size > len(data) {
+				return entry
+			}
+			field, data = data[:size], data[size:]
+		}
+
+		addField(entry, fieldType, string(field))
+	}
+
+	return entry
+}
+
+func addField(entry *dictfile.Entry, fieldType byte, text string) {
+	var transcription string
+	switch fieldType {
+	case 't':
+		// Phonetic field holds the transcription only
+		if entry.Transcription == "" {
+			entry.Transcription = strings.Trim(strings.TrimSpace(text), "[]/")
+		}
+		return
+	case 'm', 'l':
+	case 'h', 'g', 'x':
+		transcription, text = dictfile.MarkupText(text)
+	default:
+		// Resources, sounds and images carry no translations
+		return
+	}
+
+	parsedTranscription, senses := dictfile.ParseDefinition(text)
+	if transcription == "" {
+		transcription = parsedTranscription
+	}
+	if entry.Transcription == "" {
+		entry.Transcription = strings.Trim(transcription, "[]/")
+	}
+	entry.Senses = append(entry.Senses, senses...)
+}
+
+// First existing file of given paths
+func existing(paths ...string) (string, error) {
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrMissingFile, strings.Join(paths, ", "))
+}
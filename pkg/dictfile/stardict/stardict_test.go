@@ -0,0 +1,196 @@
+package stardict
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/dictfile"
+)
+
+// Word with its article data
+type article struct {
+	word string
+	data string
+}
+
+// Write .ifo, .idx and .dict files of a dictionary into dir, returns path to the .ifo file
+func writeDictionary(
+	t *testing.T, dir, ifo string, articles []article, offsetBits int, compress bool,
+) string {
+	t.Helper()
+
+	var idx, dict bytes.Buffer
+	for _, a := range articles {
+		idx.WriteString(a.word)
+		idx.WriteByte(0)
+		if offsetBits == 64 {
+			_ = binary.Write(&idx, binary.BigEndian, uint64(dict.Len()))
+		} else {
+			_ = binary.Write(&idx, binary.BigEndian, uint32(dict.Len()))
+		}
+		_ = binary.Write(&idx, binary.BigEndian, uint32(len(a.data)))
+		dict.WriteString(a.data)
+	}
+
+	files := map[string][]byte{"terms.ifo": []byte(ifo), "terms.idx": idx.Bytes(), "terms.dict": dict.Bytes()}
+	if compress {
+		delete(files, "terms.dict")
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		_, _ = zw.Write(dict.Bytes())
+		_ = zw.Close()
+		files["terms.dict.dz"] = buf.Bytes()
+	}
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), body, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return filepath.Join(dir, "terms.ifo")
+}
+
+func TestRead(t *testing.T) {
+	plain := []article{
+		{word: "cache", data: "[kæʃ]\nn. кэш, тайник\nbrowser cache — кэш браузера"},
+		{word: "queue", data: "n.\n1. очередь\n2. хвост"},
+		{word: "sound", data: ""},
+	}
+	typed := []article{
+		{
+			word: "cache",
+			data: "t/kæʃ/\x00h<pos>n.</pos> кэш, тайник<br><ex>browser cache — кэш браузера</ex>\x00",
+		},
+		{word: "queue", data: "mn.\n1. очередь\n2. хвост\x00" + "W\x00\x00\x00\x02ab"},
+		{word: "sound", data: "W\x00\x00\x00\x02ab"},
+	}
+	want := []*dictfile.Entry{
+		{
+			Headword: "cache", Transcription: "kæʃ",
+			Senses: []dictfile.Sense{{
+				PartOfSpeech: "noun", Translations: []string{"кэш", "тайник"},
+				Examples: []dictfile.Example{{Text: "browser cache", Translation: "кэш браузера"}},
+			}},
+		},
+		{
+			Headword: "queue",
+			Senses: []dictfile.Sense{
+				{PartOfSpeech: "noun", Translations: []string{"очередь"}},
+				{PartOfSpeech: "noun", Translations: []string{"хвост"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		ifo        string
+		articles   []article
+		offsetBits int
+		compress   bool
+	}{
+		{
+			name:     "same type sequence",
+			ifo:      ifoMagic + "\nversion=2.4.2\nbookname=Computer Terms\nwordcount=3\nsametypesequence=m\n",
+			articles: plain,
+		},
+		{
+			name:     "typed fields",
+			ifo:      ifoMagic + "\nversion=2.4.2\nbookname=Computer Terms\nwordcount=3\n",
+			articles: typed,
+		},
+		{
+			name: "64-bit offsets",
+			ifo: ifoMagic + "\nversion=3.0.0\nbookname=Computer Terms\nwordcount=3\nidxoffsetbits=64\n" +
+				"sametypesequence=m\n",
+			articles: plain, offsetBits: 64,
+		},
+		{
+			name:     "dictzip",
+			ifo:      ifoMagic + "\nversion=2.4.2\nbookname=Computer Terms\nwordcount=3\nsametypesequence=m\n",
+			articles: plain, compress: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeDictionary(t, t.TempDir(), tt.ifo, tt.articles, tt.offsetBits, tt.compress)
+
+			entries := make([]*dictfile.Entry, 0)
+			info, err := Read(path, func(entry *dictfile.Entry) error {
+				entries = append(entries, entry)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+
+			if wantInfo := (&dictfile.Info{Name: "Computer Terms", WordCount: 3}); !reflect.DeepEqual(info, wantInfo) {
+				t.Errorf("Read() info = %+v, want %+v", info, wantInfo)
+			}
+			if !reflect.DeepEqual(entries, want) {
+				got, _ := json.Marshal(entries)
+				expected, _ := json.Marshal(want)
+				t.Errorf("Read() entries = %s, want %s", got, expected)
+			}
+		})
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	ifo := ifoMagic + "\nbookname=Computer Terms\nsametypesequence=m\n"
+
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, dir string) string
+		err   error
+	}{
+		{
+			name: "not an ifo file",
+			setup: func(t *testing.T, dir string) string {
+				return writeDictionary(t, dir, "bookname=Computer Terms\n", nil, 32, false)
+			},
+			err: dictfile.ErrInvalidFormat,
+		},
+		{
+			name: "missing dict file",
+			setup: func(t *testing.T, dir string) string {
+				path := writeDictionary(t, dir, ifo, nil, 32, false)
+				if err := os.Remove(filepath.Join(dir, "terms.dict")); err != nil {
+					t.Fatal(err)
+				}
+				return path
+			},
+			err: ErrMissingFile,
+		},
+		{
+			name: "truncated index",
+			setup: func(t *testing.T, dir string) string {
+				path := writeDictionary(t, dir, ifo, []article{{word: "queue", data: "очередь"}}, 32, false)
+				idx := []byte("queue\x00\x00\x00")
+				if err := os.WriteFile(filepath.Join(dir, "terms.idx"), idx, 0o600); err != nil {
+					t.Fatal(err)
+				}
+				return path
+			},
+			err: dictfile.ErrInvalidFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(tt.setup(t, t.TempDir()), func(*dictfile.Entry) error {
+				return nil
+			})
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Read() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package tei
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/dictfile"
+)
+
+type entry struct {
+	Forms    []form     `xml:"form"`
+	GramGrps []gramGrp  `xml:"gramGrp"`
+	Senses   []sense    `xml:"sense"`
+	Trans    []trans    `xml:"trans"`
+	Cits     []cit      `xml:"cit"`
+	Examples []legacyEg `xml:"eg"`
+}
+
+type form struct {
+	Orth  []string `xml:"orth"`
+	Pron  []string `xml:"pron"`
+	Forms []form   `xml:"form"`
+}
+
+type gramGrp struct {
+	Pos []string `xml:"pos"`
+}
+
+type sense struct {
+	GramGrps []gramGrp  `xml:"gramGrp"`
+	Cits     []cit      `xml:"cit"`
+	Trans    []trans    `xml:"trans"`
+	Examples []legacyEg `xml:"eg"`
+	Defs     []string   `xml:"def"`
+	Senses   []sense    `xml:"sense"`
+}
+
+// Translation or example in TEI P5: <cit type="trans"><quote>...</quote></cit>
+type cit struct {
+	Type   string   `xml:"type,attr"`
+	Quotes []string `xml:"quote"`
+	Cits   []cit    `xml:"cit"`
+}
+
+// Translation in older FreeDict files: <trans><tr>...</tr></trans>
+type trans struct {
+	Tr []string `xml:"tr"`
+}
+
+type legacyEg struct {
+	Q     []string `xml:"q"`
+	Trans []trans  `xml:"trans"`
+}
+
+type header struct {
+	Titles []string `xml:"fileDesc>titleStmt>title"`
+}
+
+// Read FreeDict TEI dictionary, P5 and older P4 files are supported.
+// Entries are decoded one by one from the token stream, so the whole file is never loaded.
+func Read(path string, handler dictfile.Handler) (*dictfile.Info, error) {
+	const op = "pkg.dictfile.tei.read"
+
+	f, err := dictfile.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s.Open: %w", op, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	info := &dictfile.Info{}
+	decoder := xml.NewDecoder(f)
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s.Token: %w: %w", op, dictfile.ErrInvalidFormat, err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "TEI", "TEI.2":
+			if lang := attr(start, "lang"); lang != "" {
+				info.SourceLanguage = lang
+			}
+		case "teiHeader":
+			h := &header{}
+			if err = decoder.DecodeElement(h, &start); err != nil {
+				return nil, fmt.Errorf("%s.DecodeElement: %w", op, err)
+			}
+			if len(h.Titles) > 0 {
+				info.Name = strings.Join(strings.Fields(h.Titles[0]), " ")
+			}
+		case "text", "body":
+			if lang := attr(start, "lang"); lang != "" {
+				info.SourceLanguage = lang
+			}
+		case "entry":
+			e := &entry{}
+			if err = decoder.DecodeElement(e, &start); err != nil {
+				return nil, fmt.Errorf("%s.DecodeElement: %w", op, err)
+			}
+
+			for _, result := range e.entries() {
+				if !result.Normalize() {
+					continue
+				}
+				if err = handler(result); err != nil {
+					return nil, fmt.Errorf("%s.handler: %w", op, err)
+				}
+				info.WordCount++
+			}
+		}
+	}
+
+	return info, nil
+}
+
+// Every orthographic form of an entry is a separate headword with the same senses
+func (e *entry) entries() []*dictfile.Entry {
+	headwords, transcription := formText(e.Forms)
+
+	pos := gramPos(e.GramGrps, "")
+	senses := make([]dictfile.Sense, 0)
+
+	// Old files keep translations directly in the entry
+	if s := newSense(pos, e.Trans, e.Cits, e.Examples); len(s.Translations) > 0 {
+		senses = append(senses, s)
+	}
+	for _, s := range e.Senses {
+		senses = append(senses, flattenSense(s, pos)...)
+	}
+
+	result := make([]*dictfile.Entry, 0, len(headwords))
+	for _, headword := range headwords {
+		copied := make([]dictfile.Sense, len(senses))
+		copy(copied, senses)
+		result = append(result, &dictfile.Entry{Headword: headword, Transcription: transcription, Senses: copied})
+	}
+
+	return result
+}
+
+// Nested senses are listed after their parent and inherit its part of speech
+func flattenSense(s sense, pos string) []dictfile.Sense {
+	pos = gramPos(s.GramGrps, pos)
+
+	result := make([]dictfile.Sense, 0, 1)
+	current := newSense(pos, s.Trans, s.Cits, s.Examples)
+	if len(current.Translations) == 0 {
+		for _, def := range s.Defs {
+			current.Translations = append(current.Translations, dictfile.SplitTranslations(clean(def))...)
+		}
+	}
+	if len(current.Translations) > 0 {
+		result = append(result, current)
+	}
+
+	for _, child := range s.Senses {
+		result = append(result, flattenSense(child, pos)...)
+	}
+
+	return result
+}
+
+func newSense(pos string, trs []trans, cits []cit, egs []legacyEg) dictfile.Sense {
+	s := dictfile.Sense{PartOfSpeech: pos, Translations: make([]string, 0)}
+
+	for _, t := range trs {
+		for _, tr := range t.Tr {
+			s.Translations = append(s.Translations, clean(tr))
+		}
+	}
+
+	for _, c := range cits {
+		switch c.Type {
+		case "trans", "translation", "translationEquivalent":
+			for _, q := range c.Quotes {
+				s.Translations = append(s.Translations, clean(q))
+			}
+		case "example":
+			example := dictfile.Example{Text: clean(strings.Join(c.Quotes, " "))}
+			for _, inner := range c.Cits {
+				if inner.Type == "trans" || inner.Type == "translation" {
+					example.Translation = clean(strings.Join(inner.Quotes, "; "))
+				}
+			}
+			if example.Text != "" {
+				s.Examples = append(s.Examples, example)
+			}
+		}
+	}
+
+	for _, eg := range egs {
+		example := dictfile.Example{Text: clean(strings.Join(eg.Q, " "))}
+		for _, t := range eg.Trans {
+			example.Translation = clean(strings.Join(t.Tr, "; "))
+		}
+		if example.Text != "" {
+			s.Examples = append(s.Examples, example)
+		}
+	}
+
+	return s
+}
+
+func formText(forms []form) ([]string, string) {
+	headwords := make([]string, 0, 1)
+	transcription := ""
+
+	for _, f := range forms {
+		for _, orth := range f.Orth {
+			if orth = clean(orth); orth != "" {
+				headwords = append(headwords, orth)
+			}
+		}
+		for _, pron := range f.Pron {
+			if transcription == "" {
+				transcription = strings.Trim(clean(pron), "/[]")
+			}
+		}
+
+		nested, nestedTranscription := formText(f.Forms)
+		headwords = append(headwords, nested...)
+		if transcription == "" {
+			transcription = nestedTranscription
+		}
+	}
+
+	return headwords, transcription
+}
+
+func gramPos(groups []gramGrp, fallback string) string {
+	for _, g := range groups {
+		for _, p := range g.Pos {
+			if pos := dictfile.PartOfSpeech(p); pos != "" {
+				return pos
+			}
+		}
+	}
+
+	return fallback
+}
+
+func attr(start xml.StartElement, name string) string {
+	for _, a := range start.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+
+	return ""
+}
+
+func clean(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,168 @@
+package tei
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/dictfile"
+)
+
+const p5 = `<?xml version="1.0" encoding="UTF-8"?>
+<TEI xmlns="http://www.tei-c.org/ns/1.0">
+  <teiHeader>
+    <fileDesc><titleStmt><title>English-Russian
+      FreeDict Dictionary</title></titleStmt></fileDesc>
+  </teiHeader>
+  <text>
+    <body xml:lang="en">
+      <entry>
+        <form><orth>queue</orth><pron>/kjuː/</pron></form>
+        <gramGrp><pos>n</pos></gramGrp>
+        <sense>
+          <cit type="trans"><quote>очередь</quote></cit>
+          <cit type="example"><quote>job queue</quote><cit type="trans"><quote>очередь заданий</quote></cit></cit>
+          <sense>
+            <gramGrp><pos>v</pos></gramGrp>
+            <cit type="trans"><quote>ставить в очередь</quote></cit>
+          </sense>
+        </sense>
+      </entry>
+      <entry>
+        <form><orth>cache</orth><form><orth>cash</orth></form></form>
+        <sense><def>кэш, тайник</def></sense>
+      </entry>
+      <entry>
+        <form><orth>empty</orth></form>
+        <sense><def></def></sense>
+      </entry>
+    </body>
+  </text>
+</TEI>`
+
+const p4 = `<?xml version="1.0" encoding="UTF-8"?>
+<TEI.2 lang="en">
+  <teiHeader><fileDesc><titleStmt><title>English-Russian FreeDict Dictionary</title></titleStmt></fileDesc></teiHeader>
+  <text><body>
+    <entry>
+      <form><orth>queue</orth><pron>[kjuː]</pron></form>
+      <gramGrp><pos>n</pos></gramGrp>
+      <trans><tr>очередь</tr></trans>
+      <eg><q>job queue</q><trans><tr>очередь заданий</tr></trans></eg>
+    </entry>
+    <entry>
+      <form><orth>cache</orth></form>
+      <sense><trans><tr>кэш</tr><tr>тайник</tr></trans></sense>
+    </entry>
+  </body></text>
+</TEI.2>`
+
+func write(t *testing.T, name string, body []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, body, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func gzipped(t *testing.T, body string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(body)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestRead(t *testing.T) {
+	example := dictfile.Example{Text: "job queue", Translation: "очередь заданий"}
+	cache := []dictfile.Sense{{Translations: []string{"кэш", "тайник"}}}
+
+	p5Entries := []*dictfile.Entry{
+		{
+			Headword: "queue", Transcription: "kjuː",
+			Senses: []dictfile.Sense{
+				{PartOfSpeech: "noun", Translations: []string{"очередь"}, Examples: []dictfile.Example{example}},
+				{PartOfSpeech: "verb", Translations: []string{"ставить в очередь"}},
+			},
+		},
+		{Headword: "cache", Senses: cache},
+		{Headword: "cash", Senses: cache},
+	}
+	p4Entries := []*dictfile.Entry{
+		{
+			Headword: "queue", Transcription: "kjuː",
+			Senses: []dictfile.Sense{
+				{PartOfSpeech: "noun", Translations: []string{"очередь"}, Examples: []dictfile.Example{example}},
+			},
+		},
+		{Headword: "cache", Senses: cache},
+	}
+
+	tests := []struct {
+		name string
+		file string
+		body []byte
+		want []*dictfile.Entry
+	}{
+		{name: "p5", file: "eng-rus.tei", body: []byte(p5), want: p5Entries},
+		{name: "p4", file: "eng-rus.tei", body: []byte(p4), want: p4Entries},
+		{name: "gzip", file: "eng-rus.tei.gz", body: gzipped(t, p5), want: p5Entries},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := make([]*dictfile.Entry, 0)
+			info, err := Read(write(t, tt.file, tt.body), func(entry *dictfile.Entry) error {
+				entries = append(entries, entry)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+
+			wantInfo := &dictfile.Info{
+				Name: "English-Russian FreeDict Dictionary", SourceLanguage: "en", WordCount: len(tt.want),
+			}
+			if !reflect.DeepEqual(info, wantInfo) {
+				t.Errorf("Read() info = %+v, want %+v", info, wantInfo)
+			}
+			if !reflect.DeepEqual(entries, tt.want) {
+				got, _ := json.Marshal(entries)
+				expected, _ := json.Marshal(tt.want)
+				t.Errorf("Read() entries = %s, want %s", got, expected)
+			}
+		})
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	_, err := Read(write(t, "eng-rus.tei", []byte("<TEI><entry><form>")), func(*dictfile.Entry) error {
+		return nil
+	})
+	if err == nil {
+		t.Error("Read() error = nil for a truncated file")
+	}
+
+	stop := errors.New("stop")
+	_, err = Read(write(t, "eng-rus.tei", []byte(p5)), func(*dictfile.Entry) error {
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("Read() error = %v, want %v", err, stop)
+	}
+}
@@ -0,0 +1,85 @@
+package docfile
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"path"
+	"strings"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/extractor"
+)
+
+const (
+	FormatPDF  = "pdf"
+	FormatEPUB = "epub"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown document format")
+	ErrNoText        = errors.New("no text found, scanned documents are not supported")
+)
+
+// File extensions of supported formats
+var extensions = map[string]string{
+	".pdf":  FormatPDF,
+	".epub": FormatEPUB,
+}
+
+// Media types of supported formats
+var mediaTypes = map[string]string{
+	"application/pdf":      FormatPDF,
+	"application/x-pdf":    FormatPDF,
+	"application/epub+zip": FormatEPUB,
+}
+
+// Format of a file by its media type or by its name or url, empty for unsupported files
+func FormatOf(name, contentType string) string {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil && mediaTypes[mediaType] != "" {
+		return mediaTypes[mediaType]
+	}
+
+	if i := strings.IndexAny(name, "?#"); i >= 0 {
+		name = name[:i]
+	}
+
+	return extensions[strings.ToLower(path.Ext(name))]
+}
+
+// Parse PDF or EPUB file into an article with the same blocks as pages of the html extractor. Every block
+// keeps its page of a PDF or chapter of an EPUB as the location. The title is the one of document metadata
+// or the first top level heading.
+func Parse(format string, body []byte) (article *extractor.Article, err error) {
+	const op = "pkg.docfile.parse"
+
+	// Readers of both formats panic on malformed files
+	defer func() {
+		if r := recover(); r != nil {
+			article, err = nil, fmt.Errorf("%s: malformed %s file: %v", op, format, r)
+		}
+	}()
+
+	switch format {
+	case FormatPDF:
+		article, err = parsePDF(body)
+	case FormatEPUB:
+		article, err = parseEPUB(body)
+	default:
+		return nil, fmt.Errorf("%s: %w: %s", op, ErrUnknownFormat, format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	article.Layout = format
+	if article.Title == "" {
+		for _, b := range article.Blocks {
+			if b.Kind == extractor.BlockHeading && b.Level == 1 {
+				article.Title = b.Text
+				break
+			}
+		}
+	}
+
+	return article, nil
+}
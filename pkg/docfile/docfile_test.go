@@ -0,0 +1,40 @@
+package docfile
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFormatOf(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		want        string
+	}{
+		{name: "guide.pdf", want: FormatPDF},
+		{name: "https://example.com/Book.EPUB?download=1#top", want: FormatEPUB},
+		{name: "https://example.com/download", contentType: "application/pdf; qs=0.9", want: FormatPDF},
+		{name: "book", contentType: "application/epub+zip", want: FormatEPUB},
+		{name: "book.pdf", contentType: "application/octet-stream", want: FormatPDF},
+		{name: "notes.docx", want: ""},
+		{name: "index.html", contentType: "text/html", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := FormatOf(tt.name, tt.contentType); got != tt.want {
+			t.Errorf("FormatOf(%q, %q) = %q, want %q", tt.name, tt.contentType, got, tt.want)
+		}
+	}
+}
+
+func TestParseMalformed(t *testing.T) {
+	for _, format := range []string{FormatPDF, FormatEPUB} {
+		if _, err := Parse(format, []byte("not a document")); err == nil {
+			t.Errorf("Parse(%s) error = nil, want an error", format)
+		}
+	}
+
+	if _, err := Parse("docx", []byte("PK")); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("Parse(docx) error = %v, want %v", err, ErrUnknownFormat)
+	}
+}
@@ -0,0 +1,319 @@
+package docfile
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/extractor"
+)
+
+// Largest file of the archive read into memory, protects from zip bombs
+const maxEntrySize = 32 << 20
+
+var (
+	// Self-closing tags of xhtml, the html parser takes <title/> or <a id="x"/> for start tags
+	selfClosingRe = regexp.MustCompile(`<([a-zA-Z][\w:-]*)(\s[^<>]*?)?\s*/>`)
+
+	voidElements = map[string]bool{
+		"area": true, "base": true, "br": true, "col": true, "hr": true, "img": true, "input": true,
+		"link": true, "meta": true, "source": true, "wbr": true,
+	}
+)
+
+// META-INF/container.xml pointing to the package document
+type epubContainer struct {
+	Rootfiles []struct {
+		FullPath string `xml:"full-path,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+// Package document with metadata, files of the book and their reading order
+type epubPackage struct {
+	Titles   []string   `xml:"metadata>title"`
+	Manifest []epubItem `xml:"manifest>item"`
+	Spine    epubSpine  `xml:"spine"`
+}
+
+type epubItem struct {
+	ID         string `xml:"id,attr"`
+	Href       string `xml:"href,attr"`
+	MediaType  string `xml:"media-type,attr"`
+	Properties string `xml:"properties,attr"`
+}
+
+type epubSpine struct {
+	// Manifest id of the NCX table of contents of EPUB 2
+	Toc      string `xml:"toc,attr"`
+	ItemRefs []struct {
+		IDRef  string `xml:"idref,attr"`
+		Linear string `xml:"linear,attr"`
+	} `xml:"itemref"`
+}
+
+// Text of xhtml documents of the spine in reading order, a document linked from the table of contents starts
+// a new chapter and documents between them belong to the previous one. Chapters are numbered in reading order.
+func parseEPUB(body []byte) (*extractor.Article, error) {
+	const op = "pkg.docfile.parseEPUB"
+
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return nil, fmt.Errorf("%s.NewReader: %w", op, err)
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	container := &epubContainer{}
+	if err = readXML(files, "META-INF/container.xml", container); err != nil {
+		return nil, fmt.Errorf("%s.readXML: %w", op, err)
+	}
+	if len(container.Rootfiles) == 0 {
+		return nil, fmt.Errorf("%s: package document: %w", op, fs.ErrNotExist)
+	}
+
+	packagePath := container.Rootfiles[0].FullPath
+	book := &epubPackage{}
+	if err = readXML(files, packagePath, book); err != nil {
+		return nil, fmt.Errorf("%s.readXML: %w", op, err)
+	}
+
+	dir := path.Dir(packagePath)
+	items := make(map[string]epubItem, len(book.Manifest))
+	for _, item := range book.Manifest {
+		item.Href = resolve(dir, item.Href)
+		items[item.ID] = item
+	}
+	starts := chapterStarts(files, book, items)
+
+	article := &extractor.Article{}
+	if len(book.Titles) > 0 {
+		article.Title = strings.Join(strings.Fields(book.Titles[0]), " ")
+	}
+
+	chapter, location := 0, ""
+	for _, ref := range book.Spine.ItemRefs {
+		item, ok := items[ref.IDRef]
+		if !ok || ref.Linear == "no" || !isXHTML(item.MediaType) || hasProperty(item.Properties, "nav") {
+			continue
+		}
+
+		content, err := readFile(files, item.Href)
+		if err != nil {
+			return nil, fmt.Errorf("%s.readFile: %w", op, err)
+		}
+
+		page, err := extractor.ExtractBody(bytes.NewReader(closeTags(content)))
+		if errors.Is(err, extractor.ErrNoContent) {
+			// Covers and other pages with images only
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s.ExtractBody: %w", op, err)
+		}
+
+		if starts[item.Href] || len(starts) == 0 {
+			chapter++
+			location = fmt.Sprintf("chapter %d", chapter)
+		}
+		for _, b := range page.Blocks {
+			b.Location = location
+			article.Blocks = append(article.Blocks, b)
+		}
+	}
+
+	if len(article.Blocks) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrNoText)
+	}
+
+	return article, nil
+}
+
+// Documents linked from the table of contents: the nav document of EPUB 3 or the NCX file of EPUB 2
+func chapterStarts(files map[string]*zip.File, book *epubPackage, items map[string]epubItem) map[string]bool {
+	starts := make(map[string]bool)
+
+	var links []string
+	for _, item := range items {
+		if hasProperty(item.Properties, "nav") {
+			links = navLinks(files, item.Href)
+			break
+		}
+	}
+	if ncx, ok := items[book.Spine.Toc]; ok && len(links) == 0 {
+		links = ncxLinks(files, ncx.Href)
+	}
+
+	for _, link := range links {
+		starts[link] = true
+	}
+
+	return starts
+}
+
+// Links of the toc nav of an EPUB 3 navigation document, or of its first nav without one
+func navLinks(files map[string]*zip.File, name string) []string {
+	content, err := readFile(files, name)
+	if err != nil {
+		return nil
+	}
+	doc, err := html.Parse(bytes.NewReader(closeTags(content)))
+	if err != nil {
+		return nil
+	}
+
+	var navs []*html.Node
+	var findNavs func(n *html.Node)
+	findNavs = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "nav" {
+			navs = append(navs, n)
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			findNavs(c)
+		}
+	}
+	findNavs(doc)
+	if len(navs) == 0 {
+		return nil
+	}
+
+	toc := navs[0]
+	for _, nav := range navs {
+		if hasProperty(attr(nav, "epub:type"), "toc") {
+			toc = nav
+			break
+		}
+	}
+
+	links := make([]string, 0)
+	var findLinks func(n *html.Node)
+	findLinks = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "a" && attr(n, "href") != "" {
+			links = append(links, resolve(path.Dir(name), attr(n, "href")))
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			findLinks(c)
+		}
+	}
+	findLinks(toc)
+
+	return links
+}
+
+// Sources of navigation points of an EPUB 2 NCX file
+func ncxLinks(files map[string]*zip.File, name string) []string {
+	content, err := readFile(files, name)
+	if err != nil {
+		return nil
+	}
+
+	links := make([]string, 0)
+	decoder := newXMLDecoder(content)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return links
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local == "content" {
+			for _, a := range start.Attr {
+				if a.Name.Local == "src" {
+					links = append(links, resolve(path.Dir(name), a.Value))
+				}
+			}
+		}
+	}
+}
+
+func readXML(files map[string]*zip.File, name string, v interface{}) error {
+	content, err := readFile(files, name)
+	if err != nil {
+		return err
+	}
+
+	return newXMLDecoder(content).Decode(v)
+}
+
+// Decoder reading documents in other declared encodings as utf-8, books are almost always in utf-8
+func newXMLDecoder(content []byte) *xml.Decoder {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.Strict = false
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	return decoder
+}
+
+func readFile(files map[string]*zip.File, name string) ([]byte, error) {
+	f, ok := files[name]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", name, fs.ErrNotExist)
+	}
+
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return io.ReadAll(io.LimitReader(r, maxEntrySize))
+}
+
+// Path of a link relative to the directory of an archive file, without the fragment
+func resolve(dir, href string) string {
+	href, _, _ = strings.Cut(href, "#")
+	if unescaped, err := url.PathUnescape(href); err == nil {
+		href = unescaped
+	}
+
+	return path.Join(dir, href)
+}
+
+// Expand self-closing tags of xhtml other than void elements into start and end tags
+func closeTags(xhtml []byte) []byte {
+	return selfClosingRe.ReplaceAllFunc(xhtml, func(tag []byte) []byte {
+		match := selfClosingRe.FindSubmatch(tag)
+		if voidElements[strings.ToLower(string(match[1]))] {
+			return tag
+		}
+
+		return []byte("<" + string(match[1]) + string(match[2]) + "></" + string(match[1]) + ">")
+	})
+}
+
+func isXHTML(mediaType string) bool {
+	return mediaType == "application/xhtml+xml" || mediaType == "text/html"
+}
+
+// Space separated property lists of manifest items and epub:type attributes
+func hasProperty(properties, property string) bool {
+	for _, p := range strings.Fields(properties) {
+		if p == property {
+			return true
+		}
+	}
+
+	return false
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+
+	return ""
+}
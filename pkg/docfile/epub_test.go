@@ -0,0 +1,169 @@
+package docfile
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io/fs"
+	"reflect"
+	"testing"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/extractor"
+)
+
+const containerXML = `<?xml version="1.0"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles><rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/></rootfiles>
+</container>`
+
+// Package document of a book with a cover, two chapters and a continuation of the first one
+func packageXML(title, toc string) string {
+	return `<?xml version="1.0"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:title>` + title + `</dc:title></metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    <item id="ncx" href="toc.ncx" media-type="application/x-dtbncx+xml"/>
+    <item id="cover" href="text/cover.xhtml" media-type="application/xhtml+xml"/>
+    <item id="ch1" href="text/ch1.xhtml" media-type="application/xhtml+xml"/>
+    <item id="ch1b" href="text/ch1b.xhtml" media-type="application/xhtml+xml"/>
+    <item id="ch2" href="text/ch2.xhtml" media-type="application/xhtml+xml"/>
+    <item id="img" href="images/cover.png" media-type="image/png"/>
+  </manifest>
+  <spine toc="` + toc + `">
+    <itemref idref="cover"/>
+    <itemref idref="nav" linear="no"/>
+    <itemref idref="ch1"/>
+    <itemref idref="ch1b"/>
+    <itemref idref="ch2"/>
+  </spine>
+</package>`
+}
+
+const navXHTML = `<?xml version="1.0"?>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops"><head><title/></head><body>
+  <nav epub:type="landmarks"><ol><li><a href="text/cover.xhtml">Cover</a></li></ol></nav>
+  <nav epub:type="toc"><ol>
+    <li><a href="text/ch1.xhtml">One</a></li>
+    <li><a href="text/ch2.xhtml#start">Two</a></li>
+  </ol></nav>
+</body></html>`
+
+const tocNCX = `<?xml version="1.0"?>
+<ncx xmlns="http://www.daisy.org/z3986/2005/ncx/" version="2005-1"><navMap>
+  <navPoint id="p1"><navLabel><text>One</text></navLabel><content src="text/ch1.xhtml"/></navPoint>
+  <navPoint id="p2"><navLabel><text>Two</text></navLabel><content src="text/ch2.xhtml#start"/></navPoint>
+</navMap></ncx>`
+
+func chapterXHTML(body string) string {
+	return `<?xml version="1.0"?>
+<html xmlns="http://www.w3.org/1999/xhtml"><head><title/><link rel="stylesheet" href="style.css"/></head>
+<body>` + body + `</body></html>`
+}
+
+// EPUB archive of the files by their names
+func makeEPUB(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range []string{
+		"mimetype", "META-INF/container.xml", "OEBPS/content.opf", "OEBPS/nav.xhtml", "OEBPS/toc.ncx",
+		"OEBPS/text/cover.xhtml", "OEBPS/text/ch1.xhtml", "OEBPS/text/ch1b.xhtml", "OEBPS/text/ch2.xhtml",
+	} {
+		content, ok := files[name]
+		if !ok {
+			continue
+		}
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestParseEPUB(t *testing.T) {
+	book := func(title, toc string, nav bool) map[string]string {
+		files := map[string]string{
+			"mimetype":               "application/epub+zip",
+			"META-INF/container.xml": containerXML,
+			"OEBPS/content.opf":      packageXML(title, toc),
+			"OEBPS/toc.ncx":          tocNCX,
+			"OEBPS/text/cover.xhtml": chapterXHTML(`<img src="../images/cover.png" alt=""/>`),
+			"OEBPS/text/ch1.xhtml": chapterXHTML(`<h1>First chapter</h1><p>Opening<br/>words of the book.</p>` +
+				`<a id="note"/><p>Second paragraph.</p>`),
+			"OEBPS/text/ch1b.xhtml": chapterXHTML(`<p>Rest of the first chapter.</p>`),
+			"OEBPS/text/ch2.xhtml": chapterXHTML(`<h1 id="start">Second chapter</h1>` +
+				`<ul><li>List item</li></ul><pre><code>go run .</code></pre>`),
+		}
+		if nav {
+			files["OEBPS/nav.xhtml"] = navXHTML
+		}
+		return files
+	}
+
+	blocks := []extractor.Block{
+		{Kind: extractor.BlockHeading, Level: 1, Text: "First chapter", Location: "chapter 1"},
+		{Kind: extractor.BlockParagraph, Text: "Opening words of the book.", Location: "chapter 1"},
+		{Kind: extractor.BlockParagraph, Text: "Second paragraph.", Location: "chapter 1"},
+		{Kind: extractor.BlockParagraph, Text: "Rest of the first chapter.", Location: "chapter 1"},
+		{Kind: extractor.BlockHeading, Level: 1, Text: "Second chapter", Location: "chapter 2"},
+		{Kind: extractor.BlockListItem, Text: "List item", Location: "chapter 2"},
+		{Kind: extractor.BlockCode, Text: "go run .", Location: "chapter 2"},
+	}
+
+	noText := book("Empty", "ncx", true)
+	for _, name := range []string{"OEBPS/text/ch1.xhtml", "OEBPS/text/ch1b.xhtml", "OEBPS/text/ch2.xhtml"} {
+		noText[name] = chapterXHTML("")
+	}
+	noContainer := book("Broken", "ncx", true)
+	delete(noContainer, "META-INF/container.xml")
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  *extractor.Article
+		err   error
+	}{
+		{
+			name:  "nav document",
+			files: book("  The   Book ", "", true),
+			want:  &extractor.Article{Title: "The Book", Layout: FormatEPUB, Blocks: blocks},
+		},
+		{
+			name:  "ncx and title of the first heading",
+			files: book("", "ncx", false),
+			want:  &extractor.Article{Title: "First chapter", Layout: FormatEPUB, Blocks: blocks},
+		},
+		{
+			name:  "no text",
+			files: noText,
+			err:   ErrNoText,
+		},
+		{
+			name:  "no container",
+			files: noContainer,
+			err:   fs.ErrNotExist,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(FormatEPUB, makeEPUB(t, tt.files))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,478 @@
+package docfile
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ledongthuc/pdf"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/extractor"
+)
+
+const (
+	// Longest line taken for a heading, larger text is a paragraph in large print
+	maxHeadingLength = 120
+	// Lines in a font this much larger than the body text are headings
+	headingSizeRatio = 1.15
+	// Width of monospaced glyphs relative to the font size, used to restore indentation of code
+	monoWidth = 0.6
+	// Gap between glyphs relative to the font size which separates words
+	wordGap = 0.15
+)
+
+var (
+	// Font names of monospaced fonts, lines in them are code
+	monoFontRe = regexp.MustCompile(`(?i)mono|courier|consol|menlo|code|cmtt|typewriter`)
+	// Lines of running headers and footers with digits replaced by "#" which are page numbers
+	pageNumberRe = regexp.MustCompile(`^[-–—\s]*(page\s*)?(#|[ivxl]{1,7})(\s*(/|of)\s*#)?[-–—\s]*$`)
+	digitsRe     = regexp.MustCompile(`\d+`)
+	listMarkerRe = regexp.MustCompile(`^(?:[•◦▪▫‣⁃●○■□–—*-]|\(?\d{1,3}[.)]|\(?[a-z][.)])\s+`)
+	// Letter and a hyphen at the end of a line
+	hyphenRe = regexp.MustCompile(`(\p{L})[-\x{2010}]$`)
+)
+
+// Glyphs of a line in one kind of font, monospaced runs are code
+type pdfRun struct {
+	text string
+	mono bool
+}
+
+// Line of a PDF page rebuilt from positioned glyphs of the text layer
+type pdfLine struct {
+	// Inline code is kept in backticks, lines of code keep their spaces
+	text string
+	// Every glyph of the line is monospaced
+	mono bool
+	// Position of the first glyph, y increases bottom to top
+	x, y float64
+	// Largest font size of the line
+	size float64
+}
+
+// Text of every page as lines in the order of the content stream, which follows the reading order of
+// columns in most documents. Page numbers, running headers and footers are dropped, words broken with
+// a hyphen at the end of a line are joined, text in monospaced fonts is code. Lines in larger fonts
+// are headings, levels are assigned from the largest font.
+func parsePDF(body []byte) (*extractor.Article, error) {
+	const op = "pkg.docfile.parsePDF"
+
+	reader, err := pdf.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return nil, fmt.Errorf("%s.NewReader: %w", op, err)
+	}
+
+	pages := make([][]pdfLine, reader.NumPage())
+	for i := range pages {
+		if page := reader.Page(i + 1); !page.V.IsNull() {
+			pages[i] = pageLines(page.Content().Text)
+		}
+	}
+	dropRunningLines(pages)
+
+	blocks := newPDFLayout(pages).blocks(pages)
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrNoText)
+	}
+
+	article := &extractor.Article{Blocks: blocks}
+	// Titles of document metadata are often file names, the first top level heading is preferred
+	hasTitle := false
+	for _, b := range blocks {
+		hasTitle = hasTitle || b.Kind == extractor.BlockHeading && b.Level == 1
+	}
+	if !hasTitle {
+		article.Title = strings.TrimSpace(reader.Trailer().Key("Info").Key("Title").Text())
+	}
+
+	return article, nil
+}
+
+// Assembles glyphs into lines
+type lineBuilder struct {
+	lines []pdfLine
+	line  pdfLine
+	runs  []pdfRun
+	// Previous glyph and the position where it ends
+	last pdf.Text
+	end  float64
+}
+
+func pageLines(glyphs []pdf.Text) []pdfLine {
+	b := &lineBuilder{}
+	for _, g := range glyphs {
+		b.add(g)
+	}
+	b.flush()
+
+	return b.lines
+}
+
+func (b *lineBuilder) add(g pdf.Text) {
+	// Line ends of the reader after every text operator
+	if g.S == "" || g.S == "\n" {
+		return
+	}
+
+	size := math.Abs(g.FontSize)
+	if size == 0 {
+		size = 1
+	}
+
+	// Bold faked by drawing the same glyph twice with a small offset
+	if len(b.runs) > 0 && g.S == b.last.S && math.Abs(g.X-b.last.X) < size*0.1 && math.Abs(g.Y-b.last.Y) < 1 {
+		return
+	}
+
+	// Superscripts and subscripts stay on their line, a move back to the left starts a new one
+	if len(b.runs) > 0 && (math.Abs(g.Y-b.line.y) > size*0.5 || g.X < b.end-size*2) {
+		b.flush()
+	}
+
+	if len(b.runs) == 0 {
+		b.line = pdfLine{x: g.X, y: g.Y}
+	} else if g.X-b.end > size*wordGap && g.S != " " {
+		b.write(" ", false)
+	}
+
+	b.write(g.S, monoFontRe.MatchString(g.Font))
+	if strings.TrimSpace(g.S) != "" {
+		b.line.size = max(b.line.size, size)
+	}
+
+	width := g.W
+	if width <= 0 {
+		width = size * monoWidth
+	}
+	b.last, b.end = g, g.X+width
+}
+
+// Add text to the last run of the same font kind, spaces belong to any run
+func (b *lineBuilder) write(s string, mono bool) {
+	last := len(b.runs) - 1
+	switch {
+	case last >= 0 && strings.TrimSpace(s) == "":
+		if !strings.HasSuffix(b.runs[last].text, " ") || b.runs[last].mono {
+			b.runs[last].text += s
+		}
+	case last >= 0 && b.runs[last].mono == mono:
+		b.runs[last].text += s
+	default:
+		b.runs = append(b.runs, pdfRun{text: s, mono: mono})
+	}
+}
+
+func (b *lineBuilder) flush() {
+	runs := b.runs
+	b.runs = nil
+	if len(runs) == 0 {
+		return
+	}
+
+	mono := true
+	for _, run := range runs {
+		mono = mono && (run.mono || strings.TrimSpace(run.text) == "")
+	}
+
+	var text strings.Builder
+	for _, run := range runs {
+		trimmed := strings.TrimSpace(run.text)
+		if mono || !run.mono || trimmed == "" {
+			text.WriteString(run.text)
+			continue
+		}
+		// Inline code keeps spaces around it outside of backticks
+		text.WriteString(run.text[:strings.Index(run.text, trimmed)])
+		text.WriteString("`" + trimmed + "`")
+		text.WriteString(run.text[strings.Index(run.text, trimmed)+len(trimmed):])
+	}
+
+	line := b.line
+	line.mono = mono
+	if mono {
+		line.text = strings.TrimRight(text.String(), " ")
+	} else {
+		line.text = strings.Join(strings.Fields(text.String()), " ")
+	}
+	if strings.TrimSpace(line.text) != "" {
+		b.lines = append(b.lines, line)
+	}
+}
+
+// Drop page numbers and running headers and footers: lines at the top or bottom of pages which repeat
+// with other digits on at least three pages
+func dropRunningLines(pages [][]pdfLine) {
+	counts := make(map[string]int)
+	for _, lines := range pages {
+		seen := make(map[string]bool)
+		for _, i := range edgeLines(lines) {
+			if key := runningKey(lines[i].text); !seen[key] {
+				seen[key] = true
+				counts[key]++
+			}
+		}
+	}
+
+	for p, lines := range pages {
+		drop := make(map[int]bool)
+		for _, i := range edgeLines(lines) {
+			key := runningKey(lines[i].text)
+			if counts[key] >= 3 || pageNumberRe.MatchString(key) {
+				drop[i] = true
+			}
+		}
+
+		kept := lines[:0]
+		for i, line := range lines {
+			if !drop[i] {
+				kept = append(kept, line)
+			}
+		}
+		pages[p] = kept
+	}
+}
+
+// Indexes of the two topmost and the two lowest lines of a page
+func edgeLines(lines []pdfLine) []int {
+	order := make([]int, len(lines))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return lines[order[i]].y > lines[order[j]].y })
+
+	if len(order) <= 4 {
+		return order
+	}
+
+	return append(order[:2:2], order[len(order)-2:]...)
+}
+
+// Running header text compared across pages: lower case with numbers replaced by "#"
+func runningKey(text string) string {
+	return digitsRe.ReplaceAllString(strings.ToLower(strings.Join(strings.Fields(text), " ")), "#")
+}
+
+// Font sizes and line spacing of a document
+type pdfLayout struct {
+	// Line spacing relative to the font size
+	leading float64
+	// Heading levels by rounded font size
+	levels map[float64]int
+}
+
+func newPDFLayout(pages [][]pdfLine) *pdfLayout {
+	// Body text is set in the font size of most of the text
+	lengths := make(map[float64]int)
+	ratios := make([]float64, 0)
+	for _, lines := range pages {
+		for i, line := range lines {
+			if !line.mono {
+				lengths[roundSize(line.size)] += len(line.text)
+			}
+			if i > 0 && roundSize(lines[i-1].size) == roundSize(line.size) {
+				if gap := lines[i-1].y - line.y; gap > 0 && gap < line.size*3 {
+					ratios = append(ratios, gap/line.size)
+				}
+			}
+		}
+	}
+
+	bodySize := 0.0
+	for size, length := range lengths {
+		if length > lengths[bodySize] || length == lengths[bodySize] && size < bodySize {
+			bodySize = size
+		}
+	}
+
+	headingSizes := make([]float64, 0)
+	for _, lines := range pages {
+		for _, line := range lines {
+			size := roundSize(line.size)
+			if !line.mono && size >= bodySize*headingSizeRatio && len(line.text) <= maxHeadingLength &&
+				!containsSize(headingSizes, size) {
+				headingSizes = append(headingSizes, size)
+			}
+		}
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(headingSizes)))
+
+	layout := &pdfLayout{leading: 1.2, levels: make(map[float64]int, len(headingSizes))}
+	for i, size := range headingSizes {
+		layout.levels[size] = min(i+1, 6)
+	}
+	if len(ratios) > 0 {
+		sort.Float64s(ratios)
+		layout.leading = ratios[len(ratios)/2]
+	}
+
+	return layout
+}
+
+// Block being assembled from lines
+type pdfBlock struct {
+	kind     extractor.BlockKind
+	level    int
+	location string
+	lines    []pdfLine
+}
+
+func (l *pdfLayout) blocks(pages [][]pdfLine) []extractor.Block {
+	blocks := make([]extractor.Block, 0)
+
+	var current *pdfBlock
+	flush := func() {
+		if current != nil {
+			if b := current.block(); strings.TrimSpace(b.Text) != "" {
+				blocks = append(blocks, b)
+			}
+		}
+		current = nil
+	}
+
+	for i, lines := range pages {
+		for j, line := range lines {
+			kind, level := l.classify(line)
+			if current != nil && l.continues(current, kind, level, line, j == 0) {
+				current.lines = append(current.lines, line)
+				continue
+			}
+
+			flush()
+			current = &pdfBlock{
+				kind: kind, level: level, location: fmt.Sprintf("page %d", i+1), lines: []pdfLine{line},
+			}
+		}
+	}
+	flush()
+
+	return blocks
+}
+
+func (l *pdfLayout) classify(line pdfLine) (extractor.BlockKind, int) {
+	if line.mono {
+		return extractor.BlockCode, 0
+	}
+	// Drop caps are single large letters
+	if level := l.levels[roundSize(line.size)]; level > 0 && utf8.RuneCountInString(line.text) > 1 &&
+		len(line.text) <= maxHeadingLength {
+		return extractor.BlockHeading, level
+	}
+	if listMarkerRe.MatchString(line.text) {
+		return extractor.BlockListItem, 0
+	}
+
+	return extractor.BlockParagraph, 0
+}
+
+// Line goes on with the block: lines of a paragraph follow each other without a gap, a paragraph
+// goes on in the next column or on the next page when it ends in the middle of a sentence
+func (l *pdfLayout) continues(block *pdfBlock, kind extractor.BlockKind, level int, line pdfLine, pageStart bool) bool {
+	prev := block.lines[len(block.lines)-1]
+	size := max(prev.size, line.size)
+	gap := prev.y - line.y
+	adjacent := !pageStart && gap > 0 && gap < l.leading*size*1.35
+
+	switch block.kind {
+	case extractor.BlockCode:
+		// Listings go on over pages, blank lines split them
+		return kind == extractor.BlockCode && (pageStart || gap > 0 && gap < l.leading*size*1.6)
+	case extractor.BlockHeading:
+		return kind == extractor.BlockHeading && level == block.level && adjacent
+	case extractor.BlockListItem:
+		// Wrapped lines of an item are indented past the marker
+		return kind == extractor.BlockParagraph && adjacent && line.x > block.lines[0].x+size*0.3
+	}
+
+	if kind != extractor.BlockParagraph {
+		return false
+	}
+	if !adjacent {
+		return (pageStart || gap <= 0) && !endsSentence(prev.text) && startsLower(line.text)
+	}
+
+	// Paragraphs of books start with an indented line instead of a gap
+	return !endsSentence(prev.text) || line.x-prev.x < size*0.8
+}
+
+func (b *pdfBlock) block() extractor.Block {
+	block := extractor.Block{Kind: b.kind, Level: b.level, Location: b.location}
+	if b.kind == extractor.BlockCode {
+		block.Text = codeText(b.lines)
+		return block
+	}
+
+	block.Text = joinLines(b.lines)
+	if b.kind == extractor.BlockListItem {
+		block.Text = listMarkerRe.ReplaceAllString(block.Text, "")
+	}
+
+	return block
+}
+
+// Join lines of a block, words broken with a hyphen or a soft hyphen at the end of a line are joined back
+func joinLines(lines []pdfLine) string {
+	text := ""
+	for _, line := range lines {
+		switch {
+		case text == "":
+			text = line.text
+		case strings.HasSuffix(text, "\u00ad"):
+			text = strings.TrimSuffix(text, "\u00ad") + line.text
+		case hyphenRe.MatchString(text) && startsLower(line.text):
+			_, size := utf8.DecodeLastRuneInString(text)
+			text = text[:len(text)-size] + line.text
+		default:
+			text += " " + line.text
+		}
+	}
+
+	return strings.ReplaceAll(text, "\u00ad", "")
+}
+
+// Lines of code with indentation restored from their positions
+func codeText(lines []pdfLine) string {
+	left := lines[0].x
+	for _, line := range lines {
+		left = min(left, line.x)
+	}
+
+	rows := make([]string, 0, len(lines))
+	for _, line := range lines {
+		indent := int(math.Round((line.x - left) / (line.size * monoWidth)))
+		rows = append(rows, strings.Repeat(" ", max(indent, 0))+line.text)
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+func endsSentence(text string) bool {
+	text = strings.TrimRight(text, "\"'”’)]» ")
+
+	return text != "" && strings.ContainsAny(text[len(text)-1:], ".!?:;")
+}
+
+func startsLower(text string) bool {
+	r, _ := utf8.DecodeRuneInString(text)
+
+	return unicode.IsLower(r)
+}
+
+// Font sizes differ by fractions of a point between lines of the same style
+func roundSize(size float64) float64 {
+	return math.Round(size*2) / 2
+}
+
+func containsSize(sizes []float64, size float64) bool {
+	for _, s := range sizes {
+		if s == size {
+			return true
+		}
+	}
+
+	return false
+}
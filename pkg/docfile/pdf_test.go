@@ -0,0 +1,178 @@
+package docfile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/extractor"
+)
+
+// Line of text drawn on a PDF page
+type textLine struct {
+	mono bool
+	size float64
+	x, y float64
+	text string
+}
+
+// Type 1 font of printable ASCII glyphs of the same width
+func font(name string, width int) string {
+	return fmt.Sprintf(
+		"<< /Type /Font /Subtype /Type1 /BaseFont /%s /FirstChar 32 /LastChar 126 /Widths [%s] >>",
+		name, strings.TrimSpace(strings.Repeat(fmt.Sprintf("%d ", width), 126-32+1)),
+	)
+}
+
+// PDF document with pages of text lines in Helvetica or Courier and the title in its metadata
+func makePDF(title string, pages [][]textLine) []byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"", // pages, filled in below
+		font("Helvetica", 500),
+		font("Courier", 600),
+		fmt.Sprintf("<< /Title (%s) >>", title),
+	}
+
+	kids := make([]string, 0, len(pages))
+	for _, lines := range pages {
+		var content strings.Builder
+		for _, line := range lines {
+			font := "F1"
+			if line.mono {
+				font = "F2"
+			}
+			fmt.Fprintf(&content, "BT /%s %g Tf %g %g Td (%s) Tj ET\n", font, line.size, line.x, line.y, line.text)
+		}
+
+		objects = append(objects, fmt.Sprintf(
+			"<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String(),
+		))
+		objects = append(objects, fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Contents %d 0 R "+
+				"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> >>",
+			len(objects),
+		))
+		kids = append(kids, fmt.Sprintf("%d 0 R", len(objects)))
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages))
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return buf.Bytes()
+}
+
+func TestParsePDF(t *testing.T) {
+	block := func(kind extractor.BlockKind, level int, text, location string) extractor.Block {
+		return extractor.Block{Kind: kind, Level: level, Text: text, Location: location}
+	}
+
+	tests := []struct {
+		name  string
+		title string
+		pages [][]textLine
+		want  *extractor.Article
+		err   error
+	}{
+		{
+			name:  "headings lists and code",
+			title: "manual.pdf",
+			pages: [][]textLine{
+				{
+					{size: 20, x: 72, y: 720, text: "Getting started"},
+					{size: 11, x: 72, y: 690, text: "Install the package and import it in your code"},
+					{size: 11, x: 72, y: 677, text: "to parse documents."},
+					{size: 11, x: 72, y: 650, text: "- First item"},
+					{mono: true, size: 10, x: 72, y: 620, text: "go get example.com/pkg"},
+					{size: 9, x: 300, y: 40, text: "1"},
+				},
+				{
+					{size: 15, x: 72, y: 720, text: "Usage"},
+					{size: 11, x: 72, y: 690, text: "Call the function to read words."},
+					{size: 9, x: 300, y: 40, text: "2"},
+				},
+			},
+			want: &extractor.Article{
+				Title:  "Getting started",
+				Layout: FormatPDF,
+				Blocks: []extractor.Block{
+					block(extractor.BlockHeading, 1, "Getting started", "page 1"),
+					block(extractor.BlockParagraph, 0,
+						"Install the package and import it in your code to parse documents.", "page 1"),
+					block(extractor.BlockListItem, 0, "First item", "page 1"),
+					block(extractor.BlockCode, 0, "go get example.com/pkg", "page 1"),
+					block(extractor.BlockHeading, 2, "Usage", "page 2"),
+					block(extractor.BlockParagraph, 0, "Call the function to read words.", "page 2"),
+				},
+			},
+		},
+		{
+			name:  "running headers, hyphens and paragraphs over pages",
+			title: "Manual",
+			pages: [][]textLine{
+				{
+					{size: 9, x: 72, y: 760, text: "Go Manual"},
+					{size: 11, x: 72, y: 690, text: "The crawler fetches docu-"},
+					{size: 11, x: 72, y: 677, text: "ments of a site and continues on the"},
+					{size: 9, x: 300, y: 40, text: "Page 1 of 3"},
+				},
+				{
+					{size: 9, x: 72, y: 760, text: "Go Manual"},
+					{size: 11, x: 72, y: 720, text: "next page without a break."},
+					{size: 11, x: 72, y: 707, text: "Run "},
+					{mono: true, size: 11, x: 96, y: 707, text: "go test"},
+					{size: 11, x: 140, y: 707, text: " before commits."},
+					{size: 9, x: 300, y: 40, text: "Page 2 of 3"},
+				},
+				{
+					{size: 9, x: 72, y: 760, text: "Go Manual"},
+					{size: 11, x: 72, y: 720, text: "Last page."},
+					{size: 9, x: 300, y: 40, text: "Page 3 of 3"},
+				},
+			},
+			want: &extractor.Article{
+				Title:  "Manual",
+				Layout: FormatPDF,
+				Blocks: []extractor.Block{
+					block(extractor.BlockParagraph, 0, "The crawler fetches documents of a site and continues "+
+						"on the next page without a break. Run `go test` before commits.", "page 1"),
+					block(extractor.BlockParagraph, 0, "Last page.", "page 3"),
+				},
+			},
+		},
+		{
+			name:  "scanned",
+			title: "Scan",
+			pages: [][]textLine{{}},
+			err:   ErrNoText,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(FormatPDF, makePDF(tt.title, tt.pages))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
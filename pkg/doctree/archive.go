@@ -0,0 +1,241 @@
+package doctree
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strings"
+)
+
+var (
+	ErrUnknownArchive  = errors.New("unknown archive format, expected .zip, .tar, .tar.gz or .tgz")
+	ErrArchiveTooLarge = errors.New("archive is too large unpacked")
+)
+
+// Handler of a regular file of an archive with a clean relative name, the content is read from r
+type walkFunc func(name string, size int64, r io.Reader) error
+
+// Read files of a zip or tar archive, optionally gzip compressed, matching the options and ordered by path.
+// The format is detected by content. A directory holding all files of the archive, like the one of GitHub
+// tarballs, is not a part of paths. The archive is read in one pass.
+func ReadArchive(name string, archive []byte, options Options) ([]File, error) {
+	const op = "pkg.doctree.readArchive"
+
+	m, err := newMatcher(options)
+	if err != nil {
+		return nil, fmt.Errorf("%s.newMatcher: %w", op, err)
+	}
+
+	c := &collector{m: m, options: options}
+	if err = walkArchive(archive, options.MaxUnpackedSize, c.add); err != nil {
+		return nil, fmt.Errorf("%s.walkArchive: %s: %w", op, name, err)
+	}
+
+	files := c.result()
+	sortFiles(files)
+
+	return files, nil
+}
+
+// Walk regular files of an archive in their order, entries escaping the root are left out. Unpacking more
+// than maxUnpacked bytes of files and tar headers is an error, no limit when zero.
+func walkArchive(archive []byte, maxUnpacked int64, fn walkFunc) error {
+	unpacked := &io.LimitedReader{N: math.MaxInt64}
+	if maxUnpacked > 0 {
+		unpacked.N = maxUnpacked + 1
+	}
+
+	var err error
+	switch {
+	case bytes.HasPrefix(archive, []byte("PK\x03\x04")) || bytes.HasPrefix(archive, []byte("PK\x05\x06")):
+		err = walkZip(archive, unpacked, fn)
+	case bytes.HasPrefix(archive, []byte{0x1f, 0x8b}):
+		gz, gzErr := gzip.NewReader(bytes.NewReader(archive))
+		if gzErr != nil {
+			return gzErr
+		}
+		defer gz.Close()
+
+		unpacked.R = gz
+		err = walkTar(unpacked, fn)
+	case len(archive) > 262 && string(archive[257:262]) == "ustar":
+		unpacked.R = bytes.NewReader(archive)
+		err = walkTar(unpacked, fn)
+	default:
+		return ErrUnknownArchive
+	}
+	// Readers fail with an unexpected end when the limit cuts an archive
+	if unpacked.N <= 0 {
+		return ErrArchiveTooLarge
+	}
+
+	return err
+}
+
+// Files of a zip archive read through the shared limit of unpacked bytes
+func walkZip(archive []byte, unpacked *io.LimitedReader, fn walkFunc) error {
+	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return err
+	}
+
+	for _, f := range r.File {
+		name, ok := cleanName(f.Name)
+		if !ok || !f.Mode().IsRegular() {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		unpacked.R = rc
+		err = fn(name, int64(f.UncompressedSize64), unpacked)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+		if unpacked.N <= 0 {
+			return ErrArchiveTooLarge
+		}
+	}
+
+	return nil
+}
+
+// Files of a tar stream, content left unread by fn is skipped by the next header
+func walkTar(r io.Reader, fn walkFunc) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name, ok := cleanName(header.Name)
+		if !ok || header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err = fn(name, header.Size, tr); err != nil {
+			return err
+		}
+	}
+}
+
+func cleanName(name string) (string, bool) {
+	name = path.Clean(strings.TrimPrefix(strings.ReplaceAll(name, "\\", "/"), "/"))
+	if name == "." || name == ".." || strings.HasPrefix(name, "../") {
+		return "", false
+	}
+
+	return name, true
+}
+
+// File of an archive with its full name as the path
+type archiveFile struct {
+	File
+	// File matches with the common directory left out of its path and with its full name
+	inDir, whole bool
+}
+
+// Files of an archive gathered in one pass. A directory holding all files is known only at the end, so while
+// all files are in the directory of the first one they are matched both with and without it and the limits
+// apply to both ways.
+type collector struct {
+	m       *matcher
+	options Options
+	// Directory of the first file with a trailing slash, empty when files are in the root or not in one directory
+	dir   string
+	files []archiveFile
+	// Files and their bytes matching with the common directory left out and with full names
+	inDirCount, wholeCount int
+	inDirSize, wholeSize   int64
+}
+
+func (c *collector) add(name string, size int64, r io.Reader) error {
+	if c.files == nil {
+		c.files = make([]archiveFile, 0)
+		if dir, _, ok := strings.Cut(name, "/"); ok {
+			c.dir = dir + "/"
+		}
+	} else if c.dir != "" && !strings.HasPrefix(name, c.dir) {
+		c.leaveDir()
+	}
+
+	file := archiveFile{
+		File:  File{Path: name, Size: size},
+		inDir: c.dir != "" && c.m.match(strings.TrimPrefix(name, c.dir)),
+		whole: c.m.match(name),
+	}
+	if !file.inDir && !file.whole {
+		return nil
+	}
+
+	if file.inDir {
+		c.inDirCount++
+	}
+	if file.whole {
+		c.wholeCount++
+	}
+	if c.options.MaxFiles > 0 && max(c.inDirCount, c.wholeCount) > c.options.MaxFiles {
+		return ErrTooManyFiles
+	}
+
+	body, err := readLimited(r, size, c.options)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	file.Body = body
+
+	if file.inDir {
+		c.inDirSize += int64(len(body))
+	}
+	if file.whole {
+		c.wholeSize += int64(len(body))
+	}
+	if c.options.MaxTotalSize > 0 && max(c.inDirSize, c.wholeSize) > c.options.MaxTotalSize {
+		return ErrTooLarge
+	}
+	c.files = append(c.files, file)
+
+	return nil
+}
+
+// Files are not in one directory, only files matching with full names are kept
+func (c *collector) leaveDir() {
+	c.dir = ""
+	files := c.files[:0]
+	for _, file := range c.files {
+		if file.whole {
+			file.inDir = false
+			files = append(files, file)
+		}
+	}
+	c.files = files
+	c.inDirCount, c.inDirSize = 0, 0
+}
+
+// Matching files with the common directory left out of paths
+func (c *collector) result() []File {
+	files := make([]File, 0, len(c.files))
+	for _, file := range c.files {
+		switch {
+		case c.dir != "" && file.inDir:
+			file.Path = strings.TrimPrefix(file.Path, c.dir)
+			files = append(files, file.File)
+		case c.dir == "" && file.whole:
+			files = append(files, file.File)
+		}
+	}
+
+	return files
+}
@@ -0,0 +1,199 @@
+package doctree
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testFile struct {
+	name string
+	body string
+}
+
+func zipArchive(t *testing.T, files []testFile) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, f := range files {
+		fw, err := w.Create(f.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = fw.Write([]byte(f.body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func tarArchive(t *testing.T, files []testFile, compress bool) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	for _, f := range files {
+		header := &tar.Header{Name: f.name, Mode: 0o644, Size: int64(len(f.body)), Typeflag: tar.TypeReg}
+		if err := w.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(f.body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !compress {
+		return buf.Bytes()
+	}
+
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	if _, err := zw.Write(buf.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return gz.Bytes()
+}
+
+func TestReadArchive(t *testing.T) {
+	tarball := []testFile{
+		{name: "project-main/README.md", body: "# Project"},
+		{name: "project-main/docs/guide.md", body: "Guide"},
+		{name: "project-main/main.go", body: "package main"},
+		{name: "project-main/Makefile", body: "all:"},
+	}
+	rooted := []testFile{
+		{name: "README.md", body: "# Project"},
+		{name: "docs/guide.md", body: "Guide"},
+		{name: "../escape.md", body: "Outside"},
+		{name: "/etc/notes.md", body: "Absolute"},
+		{name: "docs\\windows.md", body: "Windows"},
+	}
+
+	tests := []struct {
+		name    string
+		archive []byte
+		options Options
+		want    []File
+		err     error
+	}{
+		{
+			name:    "zip with common directory",
+			archive: zipArchive(t, tarball),
+			want: []File{
+				{Path: "README.md", Size: 9, Body: []byte("# Project")},
+				{Path: "docs/guide.md", Size: 5, Body: []byte("Guide")},
+				{Path: "main.go", Size: 12, Body: []byte("package main")},
+			},
+		},
+		{
+			name:    "tar.gz with common directory",
+			archive: tarArchive(t, tarball, true),
+			options: Options{Include: []string{"*.md"}},
+			want: []File{
+				{Path: "README.md", Size: 9, Body: []byte("# Project")},
+				{Path: "docs/guide.md", Size: 5, Body: []byte("Guide")},
+			},
+		},
+		{
+			name:    "tar with escaping and windows paths",
+			archive: tarArchive(t, rooted, false),
+			want: []File{
+				{Path: "README.md", Size: 9, Body: []byte("# Project")},
+				{Path: "docs/guide.md", Size: 5, Body: []byte("Guide")},
+				{Path: "docs/windows.md", Size: 7, Body: []byte("Windows")},
+				{Path: "etc/notes.md", Size: 8, Body: []byte("Absolute")},
+			},
+		},
+		{
+			name:    "large files without content",
+			archive: zipArchive(t, tarball),
+			options: Options{MaxFileSize: 5},
+			want: []File{
+				{Path: "README.md", Size: 9},
+				{Path: "docs/guide.md", Size: 5, Body: []byte("Guide")},
+				{Path: "main.go", Size: 12},
+			},
+		},
+		{
+			name:    "too many files",
+			archive: tarArchive(t, tarball, true),
+			options: Options{MaxFiles: 1},
+			err:     ErrTooManyFiles,
+		},
+		{
+			name: "files outside of the first directory",
+			archive: tarArchive(t, []testFile{
+				{name: "docs/intro.md", body: "Intro"},
+				{name: "docs/.hidden/notes.md", body: "Notes"},
+				{name: "README.md", body: "# Project"},
+			}, true),
+			want: []File{
+				{Path: "README.md", Size: 9, Body: []byte("# Project")},
+				{Path: "docs/intro.md", Size: 5, Body: []byte("Intro")},
+			},
+		},
+		{
+			name:    "anchored pattern with common directory",
+			archive: tarArchive(t, tarball, true),
+			options: Options{Include: []string{"/docs/*.md"}},
+			want:    []File{{Path: "docs/guide.md", Size: 5, Body: []byte("Guide")}},
+		},
+		{
+			name:    "too large in total",
+			archive: zipArchive(t, tarball),
+			options: Options{MaxTotalSize: 10},
+			err:     ErrTooLarge,
+		},
+		{
+			name:    "compressed archive too large unpacked",
+			archive: tarArchive(t, []testFile{{name: "README.md", body: strings.Repeat("a", 1<<20)}}, true),
+			options: Options{MaxUnpackedSize: 1 << 19},
+			err:     ErrArchiveTooLarge,
+		},
+		{
+			name:    "zip archive too large unpacked",
+			archive: zipArchive(t, []testFile{{name: "README.md", body: strings.Repeat("a", 1<<20)}}),
+			options: Options{MaxUnpackedSize: 1 << 19},
+			err:     ErrArchiveTooLarge,
+		},
+		{
+			name:    "skipped files count unpacked",
+			archive: tarArchive(t, []testFile{{name: "data.bin", body: strings.Repeat("a", 1<<20)}}, true),
+			options: Options{MaxUnpackedSize: 1 << 19},
+			err:     ErrArchiveTooLarge,
+		},
+		{
+			name:    "unknown format",
+			archive: []byte("# Project"),
+			err:     ErrUnknownArchive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadArchive("project.zip", tt.archive, tt.options)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ReadArchive() error = %v, want %v", err, tt.err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadArchive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package doctree
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrTooManyFiles   = errors.New("too many matching files")
+	ErrTooLarge       = errors.New("matching files are too large in total")
+	ErrInvalidPattern = errors.New("invalid pattern")
+)
+
+var (
+	// Markup documentation, readme files, Go sources with doc comments and API specs in any directory
+	DefaultInclude = []string{
+		"*.md", "*.markdown", "*.mdx", "*.rst", "*.rest", "*.adoc", "*.asciidoc", "*.asc", "README*", "*.go",
+		"{openapi,swagger}*.{json,yaml,yml}",
+	}
+	// Hidden files and directories, dependencies, test fixtures and Go tests
+	DefaultExclude = []string{".*", "node_modules", "vendor", "testdata", "*_test.go"}
+)
+
+// File of a tree with a slash separated path relative to its root
+type File struct {
+	Path string
+	Size int64
+	// Content of the file, nil for files larger than the size limit
+	Body []byte
+}
+
+// Patterns are matched against slash separated paths relative to the root. They support "*" and "?" within
+// a path element, "**" across elements and "{a,b}" alternatives. A pattern without a slash matches the name
+// of the file or of any directory above it, "/" at the start anchors a pattern to the root.
+type Options struct {
+	// Files matching any of the patterns are read, DefaultInclude when empty
+	Include []string
+	// Files matching any of the patterns are left out even if included, DefaultExclude when nil
+	Exclude []string
+	// Larger files are returned without content, no limit when zero
+	MaxFileSize int64
+	// More matching files is an error, no limit when zero
+	MaxFiles int
+	// Content of matching files adding up to more bytes is an error, no limit when zero
+	MaxTotalSize int64
+	// Archives unpacking to more bytes are an error, no limit when zero
+	MaxUnpackedSize int64
+}
+
+// Read files of a directory or an archive file matching the options, ordered by path
+func Read(name string, options Options) ([]File, error) {
+	const op = "pkg.doctree.read"
+
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s.Stat: %w", op, err)
+	}
+	if info.IsDir() {
+		return ReadDir(name, options)
+	}
+
+	body, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s.ReadFile: %w", op, err)
+	}
+
+	return ReadArchive(filepath.Base(name), body, options)
+}
+
+// Read files of a directory matching the options, ordered by path. Symbolic links are not followed.
+func ReadDir(root string, options Options) ([]File, error) {
+	const op = "pkg.doctree.readDir"
+
+	m, err := newMatcher(options)
+	if err != nil {
+		return nil, fmt.Errorf("%s.newMatcher: %w", op, err)
+	}
+
+	files := make([]File, 0)
+	var total int64
+	err = filepath.WalkDir(root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, name)
+		if err != nil || rel == "." {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if m.excluded(rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || !m.match(rel) {
+			return nil
+		}
+		if options.MaxFiles > 0 && len(files) == options.MaxFiles {
+			return ErrTooManyFiles
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		file := File{Path: rel, Size: info.Size()}
+		if options.MaxFileSize == 0 || file.Size <= options.MaxFileSize {
+			if file.Body, err = os.ReadFile(name); err != nil {
+				return err
+			}
+			if total += int64(len(file.Body)); options.MaxTotalSize > 0 && total > options.MaxTotalSize {
+				return ErrTooLarge
+			}
+		}
+		files = append(files, file)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s.WalkDir: %w", op, err)
+	}
+
+	sortFiles(files)
+
+	return files, nil
+}
+
+// Read content of a file up to the size limit, larger files are returned without content
+func readLimited(r io.Reader, size int64, options Options) ([]byte, error) {
+	if options.MaxFileSize > 0 && size > options.MaxFileSize {
+		return nil, nil
+	}
+	if options.MaxFileSize == 0 {
+		return io.ReadAll(r)
+	}
+
+	// Sizes of archive headers are not trusted
+	body, err := io.ReadAll(io.LimitReader(r, options.MaxFileSize+1))
+	if err != nil || int64(len(body)) > options.MaxFileSize {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+func sortFiles(files []File) {
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+}
+
+type pattern struct {
+	re *regexp.Regexp
+	// Pattern without a slash matching any element of the path
+	anyElement bool
+}
+
+func (p pattern) match(name string) bool {
+	if !p.anyElement {
+		return p.re.MatchString(name)
+	}
+
+	for _, element := range strings.Split(name, "/") {
+		if p.re.MatchString(element) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type matcher struct {
+	include []pattern
+	exclude []pattern
+}
+
+func newMatcher(options Options) (*matcher, error) {
+	include, exclude := options.Include, options.Exclude
+	if len(include) == 0 {
+		include = DefaultInclude
+	}
+	if exclude == nil {
+		exclude = DefaultExclude
+	}
+
+	m := &matcher{}
+	for _, p := range include {
+		compiled, err := compile(p)
+		if err != nil {
+			return nil, err
+		}
+		m.include = append(m.include, compiled)
+	}
+	for _, p := range exclude {
+		compiled, err := compile(p)
+		if err != nil {
+			return nil, err
+		}
+		m.exclude = append(m.exclude, compiled)
+	}
+
+	return m, nil
+}
+
+// File is included and not excluded
+func (m *matcher) match(name string) bool {
+	if m.excluded(name) {
+		return false
+	}
+
+	for _, p := range m.include {
+		if p.match(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *matcher) excluded(name string) bool {
+	for _, p := range m.exclude {
+		if p.match(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Translate a glob pattern into a regular expression matching whole paths
+func compile(glob string) (pattern, error) {
+	glob = strings.TrimSpace(glob)
+	p := pattern{anyElement: !strings.Contains(glob, "/")}
+	glob = path.Clean(strings.TrimPrefix(glob, "/"))
+	if glob == "." || glob == ".." || strings.HasPrefix(glob, "../") {
+		return p, fmt.Errorf("%w: %q", ErrInvalidPattern, glob)
+	}
+
+	var re strings.Builder
+	re.WriteString("^")
+	depth := 0
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case c == '*' && strings.HasPrefix(glob[i:], "**/"):
+			re.WriteString("(?:.*/)?")
+			i += 2
+		case c == '*' && strings.HasPrefix(glob[i:], "**"):
+			re.WriteString(".*")
+			i++
+		case c == '*':
+			re.WriteString("[^/]*")
+		case c == '?':
+			re.WriteString("[^/]")
+		case c == '{':
+			re.WriteString("(?:")
+			depth++
+		case c == '}' && depth > 0:
+			re.WriteString(")")
+			depth--
+		case c == ',' && depth > 0:
+			re.WriteString("|")
+		default:
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if depth > 0 {
+		return p, fmt.Errorf("%w: %q", ErrInvalidPattern, glob)
+	}
+	re.WriteString("$")
+
+	compiled, err := regexp.Compile(re.String())
+	if err != nil {
+		return p, fmt.Errorf("%w: %q", ErrInvalidPattern, glob)
+	}
+	p.re = compiled
+
+	return p, nil
+}
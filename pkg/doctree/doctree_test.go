@@ -0,0 +1,184 @@
+package doctree
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPatternMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "*.md", name: "README.md", want: true},
+		{pattern: "*.md", name: "docs/guide/intro.md", want: true},
+		{pattern: "*.md", name: "docs/intro.mdx", want: false},
+		{pattern: "README*", name: "pkg/README.rst", want: true},
+		{pattern: "?.go", name: "a.go", want: true},
+		{pattern: "?.go", name: "ab.go", want: false},
+		{pattern: "vendor", name: "vendor/lib/doc.md", want: true},
+		{pattern: "vendor", name: "pkg/vendor/doc.md", want: true},
+		{pattern: "vendor", name: "vendors/doc.md", want: false},
+		{pattern: ".*", name: "pkg/.github/README.md", want: true},
+		{pattern: "docs/*.md", name: "docs/intro.md", want: true},
+		{pattern: "docs/*.md", name: "docs/guide/intro.md", want: false},
+		{pattern: "docs/*.md", name: "pkg/docs/intro.md", want: false},
+		{pattern: "/docs/*.md", name: "docs/intro.md", want: true},
+		{pattern: "docs/**/*.md", name: "docs/intro.md", want: true},
+		{pattern: "docs/**/*.md", name: "docs/guide/setup/intro.md", want: true},
+		{pattern: "docs/**", name: "docs/guide/intro.md", want: true},
+		{pattern: "**/api/*.yaml", name: "services/api/openapi.yaml", want: true},
+		{pattern: "{openapi,swagger}*.{json,yaml,yml}", name: "api/swagger.v2.json", want: true},
+		{pattern: "{openapi,swagger}*.{json,yaml,yml}", name: "api/openapi.yml", want: true},
+		{pattern: "{openapi,swagger}*.{json,yaml,yml}", name: "api/schema.yaml", want: false},
+		{pattern: "*.go", name: "main.go.orig", want: false},
+		{pattern: "a+b.md", name: "a+b.md", want: true},
+		{pattern: "a+b.md", name: "aab.md", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.name, func(t *testing.T) {
+			p, err := compile(tt.pattern)
+			if err != nil {
+				t.Fatalf("compile(%q) error = %v", tt.pattern, err)
+			}
+			if got := p.match(tt.name); got != tt.want {
+				t.Errorf("match(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileInvalid(t *testing.T) {
+	for _, pattern := range []string{"", "/", "..", "../docs/*.md", "docs/../../*.md", "{a,b"} {
+		t.Run(pattern, func(t *testing.T) {
+			if _, err := compile(pattern); !errors.Is(err, ErrInvalidPattern) {
+				t.Errorf("compile(%q) error = %v, want %v", pattern, err, ErrInvalidPattern)
+			}
+		})
+	}
+}
+
+func TestMatcher(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		path    string
+		want    bool
+	}{
+		{name: "default include", path: "docs/intro.md", want: true},
+		{name: "default include of go sources", path: "pkg/doc.go", want: true},
+		{name: "not included", path: "Makefile", want: false},
+		{name: "default exclude of hidden directories", path: ".github/README.md", want: false},
+		{name: "default exclude of go tests", path: "pkg/doc_test.go", want: false},
+		{name: "default exclude of dependencies", path: "node_modules/pkg/README.md", want: false},
+		{
+			name: "custom include", options: Options{Include: []string{"*.txt"}},
+			path: "notes/todo.txt", want: true,
+		},
+		{
+			name: "custom include replaces default", options: Options{Include: []string{"*.txt"}},
+			path: "README.md", want: false,
+		},
+		{
+			name: "empty exclude keeps everything", options: Options{Exclude: []string{}},
+			path: ".github/README.md", want: true,
+		},
+		{
+			name: "custom exclude", options: Options{Exclude: []string{"/docs/internal/**"}},
+			path: "docs/internal/notes.md", want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := newMatcher(tt.options)
+			if err != nil {
+				t.Fatalf("newMatcher() error = %v", err)
+			}
+			if got := m.match(tt.path); got != tt.want {
+				t.Errorf("match(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadDir(t *testing.T) {
+	root := t.TempDir()
+	tree := map[string]string{
+		"README.md":               "# Project",
+		"docs/guide.md":           "Guide",
+		"docs/large.md":           "Large document body",
+		"main.go":                 "package main",
+		"main_test.go":            "package main",
+		"Makefile":                "all:",
+		".github/CONTRIBUTING.md": "Contributing",
+		"vendor/lib/README.md":    "Library",
+	}
+	for name, body := range tree {
+		name = filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		options Options
+		want    []File
+		err     error
+	}{
+		{
+			name:    "default patterns",
+			options: Options{MaxFileSize: 12},
+			want: []File{
+				{Path: "README.md", Size: 9, Body: []byte("# Project")},
+				{Path: "docs/guide.md", Size: 5, Body: []byte("Guide")},
+				{Path: "docs/large.md", Size: 19},
+				{Path: "main.go", Size: 12, Body: []byte("package main")},
+			},
+		},
+		{
+			name:    "anchored include",
+			options: Options{Include: []string{"/docs/*.md"}},
+			want: []File{
+				{Path: "docs/guide.md", Size: 5, Body: []byte("Guide")},
+				{Path: "docs/large.md", Size: 19, Body: []byte("Large document body")},
+			},
+		},
+		{
+			name:    "too many files",
+			options: Options{MaxFiles: 2},
+			err:     ErrTooManyFiles,
+		},
+		{
+			name:    "too large in total",
+			options: Options{MaxTotalSize: 20},
+			err:     ErrTooLarge,
+		},
+		{
+			name:    "invalid pattern",
+			options: Options{Include: []string{"{md"}},
+			err:     ErrInvalidPattern,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Read(root, tt.options)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Read() error = %v, want %v", err, tt.err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package extractor
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	// Elements which never hold article text
+	boilerplateTags = map[atom.Atom]bool{
+		atom.Script:   true,
+		atom.Style:    true,
+		atom.Noscript: true,
+		atom.Template: true,
+		atom.Nav:      true,
+		atom.Aside:    true,
+		atom.Footer:   true,
+		atom.Form:     true,
+		atom.Button:   true,
+		atom.Select:   true,
+		atom.Input:    true,
+		atom.Textarea: true,
+		atom.Svg:      true,
+		atom.Iframe:   true,
+		atom.Canvas:   true,
+		atom.Object:   true,
+		atom.Embed:    true,
+		atom.Dialog:   true,
+		atom.Menu:     true,
+	}
+
+	boilerplateRoles = map[string]bool{
+		"navigation":    true,
+		"banner":        true,
+		"contentinfo":   true,
+		"complementary": true,
+		"search":        true,
+		"dialog":        true,
+		"alert":         true,
+	}
+
+	// Class and id name parts used for navigation, banners and page chrome
+	boilerplateNameRe = regexp.MustCompile(
+		`(?i)(^|[-_\s])(nav|navbar|navigation|sidebar|sidenav|toc|breadcrumbs?|footer|cookies?|consent|gdpr|` +
+			`banner|announcement|pagination|pager|prev-next|edit|edit-page|feedback|share|social|related|` +
+			`skip-link|headerlink|hash-link|anchor-link|source-file|last-updated|copyright|advert|ads)([-_\s]|$)`,
+	)
+
+	// Link texts of the "Edit this page" kind
+	boilerplateLinkRe = regexp.MustCompile(
+		`(?i)^(edit (this page|on github|on gitlab)|improve this (page|doc)|view (page )?source|` +
+			`suggest (an )?edits?|report an issue|back to top|¶|#)$`,
+	)
+)
+
+// Remove navigation, page chrome and other boilerplate below root
+func prune(root *html.Node) {
+	var next *html.Node
+	for c := root.FirstChild; c != nil; c = next {
+		next = c.NextSibling
+
+		switch c.Type {
+		case html.CommentNode:
+			detach(c)
+		case html.ElementNode:
+			if isBoilerplate(c) {
+				detach(c)
+				continue
+			}
+			prune(c)
+		}
+	}
+}
+
+func isBoilerplate(n *html.Node) bool {
+	if boilerplateTags[n.DataAtom] {
+		return true
+	}
+
+	// Article headers often keep the page title, so only headers without headings are dropped
+	if n.DataAtom == atom.Header && !hasHeading(n) {
+		return true
+	}
+
+	if boilerplateRoles[attr(n, "role")] || attr(n, "aria-hidden") == "true" {
+		return true
+	}
+	if _, hidden := findAttr(n, "hidden"); hidden {
+		return true
+	}
+	style := strings.ReplaceAll(strings.ToLower(attr(n, "style")), " ", "")
+	if strings.Contains(style, "display:none") || strings.Contains(style, "visibility:hidden") {
+		return true
+	}
+
+	if boilerplateNameRe.MatchString(attr(n, "class")) {
+		return true
+	}
+
+	// Generators derive section and heading ids from the heading text, e.g. "edit-a-config-file"
+	if boilerplateNameRe.MatchString(attr(n, "id")) && headingLevel(n) == 0 && !hasHeading(n) {
+		return true
+	}
+
+	if n.DataAtom == atom.A {
+		if boilerplateLinkRe.MatchString(normalizeSpace(textContent(n))) ||
+			boilerplateLinkRe.MatchString(normalizeSpace(attr(n, "title"))) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasHeading(n *html.Node) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if headingLevel(c) > 0 || hasHeading(c) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func findAttr(n *html.Node, key string) (string, bool) {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,106 @@
+package extractor
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Simplified css selector: tag, class, id and one attribute, empty parts match anything
+type selector struct {
+	tag       atom.Atom
+	class     string
+	id        string
+	attrKey   string
+	attrValue string
+}
+
+func (s selector) match(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+	if s.tag != 0 && n.DataAtom != s.tag {
+		return false
+	}
+	if s.class != "" && !hasClass(n, s.class) {
+		return false
+	}
+	if s.id != "" && attr(n, "id") != s.id {
+		return false
+	}
+	if s.attrKey != "" && attr(n, s.attrKey) != s.attrValue {
+		return false
+	}
+
+	return true
+}
+
+// Find first node in document order matching selector
+func find(root *html.Node, s selector) *html.Node {
+	if s.match(root) {
+		return root
+	}
+	for c := root.FirstChild; c != nil; c = c.NextSibling {
+		if found := find(c, s); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+
+	return ""
+}
+
+func hasClass(n *html.Node, class string) bool {
+	for _, c := range strings.Fields(attr(n, "class")) {
+		if c == class {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Concatenated text of node and its children
+func textContent(n *html.Node) string {
+	var b strings.Builder
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+			return
+		}
+		if n.Type == html.ElementNode && n.DataAtom == atom.Br {
+			b.WriteByte('\n')
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+
+	return b.String()
+}
+
+// Collapse whitespace runs into single spaces
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// Remove node from its parent
+func detach(n *html.Node) {
+	if n.Parent != nil {
+		n.Parent.RemoveChild(n)
+	}
+}
@@ -0,0 +1,362 @@
+package extractor
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/textnorm"
+)
+
+var ErrNoContent = errors.New("no article content found")
+
+type BlockKind string
+
+const (
+	BlockHeading   BlockKind = "heading"
+	BlockParagraph BlockKind = "paragraph"
+	BlockListItem  BlockKind = "list_item"
+	BlockTable     BlockKind = "table"
+	BlockCode      BlockKind = "code"
+)
+
+// Structural piece of article text
+type Block struct {
+	Kind BlockKind `json:"kind"`
+	// Heading level from 1 to 6, zero for other blocks
+	Level int    `json:"level,omitempty"`
+	Text  string `json:"text"`
+	// Page or chapter of documents split into them: "page 12", "chapter 3"
+	Location string `json:"location,omitempty"`
+	// Locations starting inside the block like timestamps of subtitle cues, starts are in characters of the text
+	Marks []Location `json:"marks,omitempty"`
+}
+
+// Start of a page or chapter in characters of the article text
+type Location struct {
+	Start int    `json:"start"`
+	Label string `json:"label"`
+}
+
+// Main content of a page without navigation and other boilerplate
+type Article struct {
+	Title  string  `json:"title"`
+	Layout string  `json:"layout"`
+	Blocks []Block `json:"blocks"`
+}
+
+// Extract article from html page
+func Extract(r io.Reader) (*Article, error) {
+	const op = "pkg.extractor.extract"
+
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s.Parse: %w", op, err)
+	}
+
+	article := &Article{Layout: LayoutHeuristic}
+
+	var root *html.Node
+	if l := detectLayout(doc); l != nil {
+		if root = l.contentRoot(doc); root != nil {
+			article.Layout = l.name
+			prune(root)
+		}
+	}
+	if root == nil {
+		body := find(doc, selector{tag: atom.Body})
+		if body == nil {
+			return nil, fmt.Errorf("%s: %w", op, ErrNoContent)
+		}
+		prune(body)
+
+		if root = bestCandidate(body); root == nil {
+			root = body
+		}
+	}
+
+	if article.Blocks = blocks(root); len(article.Blocks) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrNoContent)
+	}
+	article.Title = pageTitle(doc, article.Blocks)
+
+	return article, nil
+}
+
+// Extract text of the whole page body without looking for the main content, for pages which hold nothing
+// but content like chapters of books
+func ExtractBody(r io.Reader) (*Article, error) {
+	const op = "pkg.extractor.extractBody"
+
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s.Parse: %w", op, err)
+	}
+
+	body := find(doc, selector{tag: atom.Body})
+	if body == nil {
+		return nil, fmt.Errorf("%s: %w", op, ErrNoContent)
+	}
+	prune(body)
+
+	article := &Article{Layout: LayoutBody}
+	if article.Blocks = blocks(body); len(article.Blocks) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrNoContent)
+	}
+	article.Title = pageTitle(doc, article.Blocks)
+
+	return article, nil
+}
+
+// Blocks of the content tree
+func blocks(root *html.Node) []Block {
+	e := &emitter{kind: BlockParagraph}
+	e.walk(root)
+	e.flush()
+
+	return e.blocks
+}
+
+// Article as markdown-like text: headings are prefixed with "#", list items with "-",
+// code blocks are fenced and inline code is kept in backticks, blocks are separated by blank lines
+func (a *Article) Text() string {
+	parts := make([]string, 0, len(a.Blocks))
+	for _, b := range a.Blocks {
+		parts = append(parts, b.Markdown())
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// Starts of pages or chapters in the article text, blocks without a location belong to the previous one
+func (a *Article) Locations() []Location {
+	locations := make([]Location, 0)
+	start := 0
+	for i, b := range a.Blocks {
+		if i > 0 {
+			// Blank line between blocks
+			start += 2
+		}
+		if b.Location != "" && (len(locations) == 0 || locations[len(locations)-1].Label != b.Location) {
+			locations = append(locations, Location{Start: start, Label: b.Location})
+		}
+
+		markdown := b.Markdown()
+		if len(b.Marks) > 0 {
+			// Markup before the text: "#" of headings and "-" of list items
+			prefix := utf8.RuneCountInString(markdown[:max(strings.Index(markdown, b.Text), 0)])
+			for _, mark := range b.Marks {
+				locations = append(locations, Location{Start: start + prefix + mark.Start, Label: mark.Label})
+			}
+		}
+		start += utf8.RuneCountInString(markdown)
+	}
+
+	return locations
+}
+
+// Normalize typography of the title and of the text of blocks, marks are moved along with the text
+func (a *Article) Normalize() {
+	a.Title = textnorm.Normalize(a.Title)
+	for i := range a.Blocks {
+		b := &a.Blocks[i]
+		if len(b.Marks) == 0 {
+			b.Text = textnorm.Normalize(b.Text)
+			continue
+		}
+
+		// Text between marks is normalized apart to find the new starts of marks
+		runes := []rune(b.Text)
+		var text strings.Builder
+		from, count := 0, 0
+		for j, mark := range b.Marks {
+			to := min(max(mark.Start, from), len(runes))
+			segment := textnorm.Normalize(string(runes[from:to]))
+			text.WriteString(segment)
+			count += utf8.RuneCountInString(segment)
+			b.Marks[j].Start = count
+			from = to
+		}
+		text.WriteString(textnorm.Normalize(string(runes[from:])))
+		b.Text = text.String()
+	}
+}
+
+// Block text with markdown markup of its kind
+func (b Block) Markdown() string {
+	switch b.Kind {
+	case BlockHeading:
+		return strings.Repeat("#", b.Level) + " " + b.Text
+	case BlockListItem:
+		return "- " + b.Text
+	case BlockCode:
+		fence := "```"
+		for strings.Contains(b.Text, fence) {
+			fence += "`"
+		}
+		return fence + "\n" + b.Text + "\n" + fence
+	}
+
+	return b.Text
+}
+
+// First top level heading of the article or the <title> of the page
+func pageTitle(doc *html.Node, blocks []Block) string {
+	for _, b := range blocks {
+		if b.Kind == BlockHeading && b.Level == 1 {
+			return b.Text
+		}
+	}
+
+	if title := find(doc, selector{tag: atom.Title}); title != nil {
+		return normalizeSpace(textContent(title))
+	}
+
+	return ""
+}
+
+func headingLevel(n *html.Node) int {
+	if n.Type != html.ElementNode {
+		return 0
+	}
+
+	switch n.DataAtom {
+	case atom.H1:
+		return 1
+	case atom.H2:
+		return 2
+	case atom.H3:
+		return 3
+	case atom.H4:
+		return 4
+	case atom.H5:
+		return 5
+	case atom.H6:
+		return 6
+	}
+
+	return 0
+}
+
+// Converts content tree into a flat list of blocks
+type emitter struct {
+	blocks []Block
+	// Inline text collected for the current block
+	inline strings.Builder
+	kind   BlockKind
+}
+
+func (e *emitter) walk(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		switch c.Type {
+		case html.TextNode:
+			e.inline.WriteString(c.Data)
+		case html.ElementNode:
+			e.element(c)
+		}
+	}
+}
+
+func (e *emitter) element(n *html.Node) {
+	if level := headingLevel(n); level > 0 {
+		e.flush()
+		e.add(Block{Kind: BlockHeading, Level: level, Text: normalizeSpace(textContent(n))})
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		e.inline.WriteByte('\n')
+	case atom.Img:
+		// Images carry no article text, alt texts are mostly file names
+	case atom.Code, atom.Kbd, atom.Samp, atom.Tt, atom.Var:
+		e.inline.WriteString(codeSpan(normalizeSpace(textContent(n))))
+	case atom.Pre:
+		e.flush()
+		e.add(Block{Kind: BlockCode, Text: strings.Trim(textContent(n), "\n")})
+	case atom.Table:
+		e.flush()
+		e.add(Block{Kind: BlockTable, Text: tableText(n)})
+	case atom.Li:
+		e.block(n, BlockListItem)
+	case atom.P, atom.Dt, atom.Dd, atom.Figcaption, atom.Caption, atom.Summary:
+		e.block(n, BlockParagraph)
+	case atom.Div, atom.Section, atom.Article, atom.Main, atom.Header, atom.Blockquote, atom.Ul, atom.Ol,
+		atom.Dl, atom.Figure, atom.Details, atom.Address, atom.Hr, atom.Center:
+		e.block(n, e.kind)
+	default:
+		e.walk(n)
+	}
+}
+
+// Walk node as a separate block of given kind
+func (e *emitter) block(n *html.Node, kind BlockKind) {
+	e.flush()
+
+	prev := e.kind
+	e.kind = kind
+	e.walk(n)
+	e.flush()
+	e.kind = prev
+}
+
+// Emit collected inline text as a block of current kind
+func (e *emitter) flush() {
+	text := normalizeSpace(e.inline.String())
+	e.inline.Reset()
+
+	e.add(Block{Kind: e.kind, Text: text})
+}
+
+func (e *emitter) add(b Block) {
+	if strings.TrimSpace(b.Text) == "" {
+		return
+	}
+	e.blocks = append(e.blocks, b)
+}
+
+// Inline code in backticks, the fence is longer than any backtick run inside the code
+func codeSpan(code string) string {
+	if code == "" {
+		return ""
+	}
+
+	fence := "`"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+
+	return fence + code + fence
+}
+
+// Table rows on separate lines with cells separated by vertical bars
+func tableText(table *html.Node) string {
+	rows := make([]string, 0)
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.DataAtom == atom.Tr {
+			cells := make([]string, 0)
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				if c.Type == html.ElementNode && (c.DataAtom == atom.Td || c.DataAtom == atom.Th) {
+					cells = append(cells, normalizeSpace(textContent(c)))
+				}
+			}
+			if row := strings.Join(cells, " | "); strings.Trim(row, " |") != "" {
+				rows = append(rows, row)
+			}
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(table)
+
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,204 @@
+package extractor
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const docsPage = `<html><head><title>Page title</title></head><body>
+<nav><a href="/">Home</a><a href="/docs">Docs</a></nav>
+<main><article>
+<h1>Getting started</h1>
+<p>Install the   package with <code>go get</code> and import it.</p>
+<ul><li>First item</li><li>Second item</li></ul>
+<pre><code>go get example.com/pkg</code></pre>
+<h2>Usage</h2>
+<p>Call the function to parse a document and read its words.</p>
+</article></main>
+<footer>Copyright 2024</footer>
+</body></html>`
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name string
+		page string
+		want *Article
+		err  error
+	}{
+		{
+			name: "main content without boilerplate",
+			page: docsPage,
+			want: &Article{Title: "Getting started", Layout: LayoutHeuristic, Blocks: []Block{
+				{Kind: BlockHeading, Level: 1, Text: "Getting started"},
+				{Kind: BlockParagraph, Text: "Install the package with `go get` and import it."},
+				{Kind: BlockListItem, Text: "First item"},
+				{Kind: BlockListItem, Text: "Second item"},
+				{Kind: BlockCode, Text: "go get example.com/pkg"},
+				{Kind: BlockHeading, Level: 2, Text: "Usage"},
+				{Kind: BlockParagraph, Text: "Call the function to parse a document and read its words."},
+			}},
+		},
+		{
+			name: "known layout with title of the page",
+			page: `<html><head><title>Only title</title></head><body><div class="md-content">` +
+				`<article class="md-content__inner"><p>Text of mkdocs page.</p></article></div></body></html>`,
+			want: &Article{Title: "Only title", Layout: LayoutMkDocs, Blocks: []Block{
+				{Kind: BlockParagraph, Text: "Text of mkdocs page."},
+			}},
+		},
+		{
+			name: "empty page",
+			page: `<html><head><title>Empty</title></head><body></body></html>`,
+			err:  ErrNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Extract(strings.NewReader(tt.page))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Extract() error = %v, want %v", err, tt.err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Extract() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractBody(t *testing.T) {
+	got, err := ExtractBody(strings.NewReader(
+		`<html><head><title>Chapter 1</title></head><body><p>One</p><p>Two</p></body></html>`,
+	))
+	if err != nil {
+		t.Fatalf("ExtractBody() error = %v", err)
+	}
+
+	want := &Article{Title: "Chapter 1", Layout: LayoutBody, Blocks: []Block{
+		{Kind: BlockParagraph, Text: "One"},
+		{Kind: BlockParagraph, Text: "Two"},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractBody() = %+v, want %+v", got, want)
+	}
+}
+
+func TestBlockMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		block Block
+		want  string
+	}{
+		{name: "heading", block: Block{Kind: BlockHeading, Level: 3, Text: "Usage"}, want: "### Usage"},
+		{name: "list item", block: Block{Kind: BlockListItem, Text: "First"}, want: "- First"},
+		{name: "paragraph", block: Block{Kind: BlockParagraph, Text: "Text"}, want: "Text"},
+		{name: "table", block: Block{Kind: BlockTable, Text: "a | b"}, want: "a | b"},
+		{name: "code", block: Block{Kind: BlockCode, Text: "go test"}, want: "```\ngo test\n```"},
+		{
+			name:  "code with fence",
+			block: Block{Kind: BlockCode, Text: "```go\nx := 1\n```"},
+			want:  "````\n```go\nx := 1\n```\n````",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.block.Markdown(); got != tt.want {
+				t.Errorf("Markdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArticleText(t *testing.T) {
+	article := &Article{Blocks: []Block{
+		{Kind: BlockHeading, Level: 1, Text: "Title"},
+		{Kind: BlockParagraph, Text: "Text"},
+		{Kind: BlockListItem, Text: "Item"},
+	}}
+
+	if got, want := article.Text(), "# Title\n\nText\n\n- Item"; got != want {
+		t.Errorf("Text() = %q, want %q", got, want)
+	}
+}
+
+func TestArticleLocations(t *testing.T) {
+	article := &Article{Blocks: []Block{
+		{Kind: BlockHeading, Level: 1, Text: "Intro", Location: "page 1"},
+		{Kind: BlockParagraph, Text: "Text", Location: "page 1"},
+		{Kind: BlockParagraph, Text: "More", Location: "page 2"},
+		{Kind: BlockListItem, Text: "ab cd", Marks: []Location{{Start: 3, Label: "00:00:01.000"}}},
+		{Kind: BlockParagraph, Text: "End", Location: "page 1"},
+	}}
+
+	want := []Location{
+		{Start: 0, Label: "page 1"},
+		{Start: 15, Label: "page 2"},
+		{Start: 26, Label: "00:00:01.000"},
+		{Start: 30, Label: "page 1"},
+	}
+	if got := article.Locations(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Locations() = %v, want %v", got, want)
+	}
+	// Starts point to the labelled text
+	if text := []rune(article.Text()); string(text[15:19]) != "More" || string(text[26:28]) != "cd" {
+		t.Errorf("Locations() do not match Text() %q", article.Text())
+	}
+}
+
+func TestArticleNormalize(t *testing.T) {
+	tests := []struct {
+		name  string
+		block Block
+		want  Block
+	}{
+		{
+			name:  "text without marks",
+			block: Block{Kind: BlockParagraph, Text: "“Quoted”\u00a0text – ﬁne"},
+			want:  Block{Kind: BlockParagraph, Text: `"Quoted" text - fine`},
+		},
+		{
+			name: "marks after longer ligatures",
+			block: Block{Kind: BlockParagraph, Text: "ﬁle one\u00a0two", Marks: []Location{
+				{Start: 4, Label: "00:00:01.000"}, {Start: 8, Label: "00:00:02.000"},
+			}},
+			want: Block{Kind: BlockParagraph, Text: "file one two", Marks: []Location{
+				{Start: 5, Label: "00:00:01.000"}, {Start: 9, Label: "00:00:02.000"},
+			}},
+		},
+		{
+			name: "marks after composed characters and removed soft hyphens",
+			block: Block{Kind: BlockParagraph, Text: "Cafe\u0301 in\u00adter fin", Marks: []Location{
+				{Start: 6, Label: "00:00:01.000"}, {Start: 13, Label: "00:00:02.000"},
+			}},
+			want: Block{Kind: BlockParagraph, Text: "Café inter fin", Marks: []Location{
+				{Start: 5, Label: "00:00:01.000"}, {Start: 11, Label: "00:00:02.000"},
+			}},
+		},
+		{
+			name: "marks past the end",
+			block: Block{Kind: BlockParagraph, Text: "ﬁn", Marks: []Location{
+				{Start: 10, Label: "00:00:01.000"},
+			}},
+			want: Block{Kind: BlockParagraph, Text: "fin", Marks: []Location{
+				{Start: 3, Label: "00:00:01.000"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			article := &Article{Title: "‘Title’", Blocks: []Block{tt.block}}
+			article.Normalize()
+
+			if article.Title != "'Title'" {
+				t.Errorf("Title = %q, want %q", article.Title, "'Title'")
+			}
+			if !reflect.DeepEqual(article.Blocks[0], tt.want) {
+				t.Errorf("Normalize() = %+v, want %+v", article.Blocks[0], tt.want)
+			}
+		})
+	}
+}
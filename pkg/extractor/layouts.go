@@ -0,0 +1,136 @@
+package extractor
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	LayoutMkDocs     = "mkdocs"
+	LayoutDocusaurus = "docusaurus"
+	LayoutSphinx     = "sphinx"
+	LayoutHugo       = "hugo"
+	LayoutGitBook    = "gitbook"
+	LayoutHeuristic  = "heuristic"
+	// Whole page body taken as content
+	LayoutBody = "body"
+)
+
+// Known documentation site generator
+type layout struct {
+	name string
+	// Substring of <meta name="generator"> content
+	generator string
+	// Elements present only in pages rendered by this generator
+	signatures []selector
+	// Main content containers, most specific first
+	content []selector
+}
+
+var layouts = []layout{
+	{
+		name:      LayoutMkDocs,
+		generator: "mkdocs",
+		signatures: []selector{
+			{class: "md-content"},
+			{tag: atom.Div, class: "rst-content"},
+		},
+		content: []selector{
+			{tag: atom.Article, class: "md-content__inner"},
+			{class: "md-content"},
+			{tag: atom.Div, attrKey: "itemprop", attrValue: "articleBody"},
+			{tag: atom.Div, attrKey: "role", attrValue: "main"},
+		},
+	},
+	{
+		name:      LayoutDocusaurus,
+		generator: "docusaurus",
+		signatures: []selector{
+			{tag: atom.Div, id: "__docusaurus"},
+			{class: "theme-doc-markdown"},
+		},
+		content: []selector{
+			{class: "theme-doc-markdown"},
+			{tag: atom.Article},
+		},
+	},
+	{
+		name:      LayoutSphinx,
+		generator: "sphinx",
+		signatures: []selector{
+			{tag: atom.Div, class: "sphinxsidebar"},
+			{tag: atom.Div, class: "bodywrapper"},
+		},
+		content: []selector{
+			{tag: atom.Div, attrKey: "itemprop", attrValue: "articleBody"},
+			{tag: atom.Div, class: "body"},
+			{attrKey: "role", attrValue: "main"},
+			{tag: atom.Div, class: "document"},
+		},
+	},
+	{
+		name:      LayoutGitBook,
+		generator: "gitbook",
+		signatures: []selector{
+			{tag: atom.Div, class: "book-summary"},
+			{tag: atom.Section, class: "markdown-section"},
+		},
+		content: []selector{
+			{tag: atom.Section, class: "markdown-section"},
+			{tag: atom.Div, class: "page-inner"},
+			{tag: atom.Main},
+		},
+	},
+	{
+		// Hugo goes last as themes vary a lot and the generator meta is the only reliable marker
+		name:      LayoutHugo,
+		generator: "hugo",
+		signatures: []selector{
+			{tag: atom.Div, class: "td-content"},
+			{tag: atom.Article, class: "book-article"},
+		},
+		content: []selector{
+			{tag: atom.Div, class: "td-content"},
+			{tag: atom.Article, class: "markdown"},
+			{tag: atom.Article},
+			{tag: atom.Main},
+		},
+	},
+}
+
+// Detect site generator, returns nil for unknown layouts
+func detectLayout(root *html.Node) *layout {
+	generator := ""
+	if meta := find(root, selector{tag: atom.Meta, attrKey: "name", attrValue: "generator"}); meta != nil {
+		generator = strings.ToLower(attr(meta, "content"))
+	}
+
+	for i := range layouts {
+		if generator != "" && strings.Contains(generator, layouts[i].generator) {
+			return &layouts[i]
+		}
+	}
+
+	for i := range layouts {
+		for _, s := range layouts[i].signatures {
+			if find(root, s) != nil {
+				return &layouts[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// Find content container of a known layout
+func (l *layout) contentRoot(root *html.Node) *html.Node {
+	for _, s := range l.content {
+		if n := find(root, s); n != nil {
+			return n
+		}
+	}
+
+	return nil
+}
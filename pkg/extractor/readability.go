@@ -0,0 +1,128 @@
+package extractor
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Minimal paragraph length taken into account when scoring containers
+const minScoredTextLength = 25
+
+var (
+	positiveClassRe = regexp.MustCompile(
+		`(?i)article|body|content|entry|main|markdown|page|post|prose|text|documentation|docs`,
+	)
+	negativeClassRe = regexp.MustCompile(
+		`(?i)comment|footer|footnote|masthead|menu|meta|nav|promo|related|share|sidebar|social|sponsor|toc|widget`,
+	)
+)
+
+// Readability-style search of the container holding most of the text
+func bestCandidate(root *html.Node) *html.Node {
+	scores := make(map[*html.Node]float64)
+	candidates := make([]*html.Node, 0)
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type != html.ElementNode {
+			return
+		}
+
+		switch n.DataAtom {
+		case atom.P, atom.Pre, atom.Td, atom.Li, atom.Dd, atom.Blockquote:
+			text := normalizeSpace(textContent(n))
+			if len(text) >= minScoredTextLength {
+				score := 1 + float64(strings.Count(text, ",")) + min(float64(len(text))/100, 3)
+				if parent := n.Parent; parent != nil && parent.Type == html.ElementNode {
+					candidates = addScore(scores, candidates, parent, score)
+					if grand := parent.Parent; grand != nil && grand.Type == html.ElementNode {
+						candidates = addScore(scores, candidates, grand, score/2)
+					}
+				}
+			}
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(root)
+
+	var (
+		best      *html.Node
+		bestScore float64
+	)
+	for _, n := range candidates {
+		score := scores[n] * (1 - linkDensity(n))
+		if best == nil || score > bestScore {
+			best, bestScore = n, score
+		}
+	}
+
+	return best
+}
+
+func addScore(
+	scores map[*html.Node]float64, candidates []*html.Node, n *html.Node, score float64,
+) []*html.Node {
+	if _, ok := scores[n]; !ok {
+		scores[n] = classWeight(n)
+		candidates = append(candidates, n)
+	}
+	scores[n] += score
+
+	return candidates
+}
+
+// Initial container score based on its class and id names
+func classWeight(n *html.Node) float64 {
+	weight := 0.0
+	for _, name := range []string{attr(n, "class"), attr(n, "id")} {
+		if name == "" {
+			continue
+		}
+		if negativeClassRe.MatchString(name) {
+			weight -= 25
+		}
+		if positiveClassRe.MatchString(name) {
+			weight += 25
+		}
+	}
+
+	switch n.DataAtom {
+	case atom.Article, atom.Main:
+		weight += 10
+	case atom.Div, atom.Section:
+		weight += 5
+	case atom.Form, atom.Ul, atom.Ol, atom.Nav, atom.Aside:
+		weight -= 5
+	}
+
+	return weight
+}
+
+// Share of container text placed inside links
+func linkDensity(n *html.Node) float64 {
+	total := len(normalizeSpace(textContent(n)))
+	if total == 0 {
+		return 0
+	}
+
+	linked := 0
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.DataAtom == atom.A {
+			linked += len(normalizeSpace(textContent(n)))
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+
+	return float64(linked) / float64(total)
+}
@@ -0,0 +1,127 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+	"github.com/shlembo598/text-lexicon-go/pkg/textnorm"
+)
+
+var (
+	ErrUnexpectedStatus = errors.New("unexpected response status")
+	ErrTooLarge         = errors.New("document is too large")
+)
+
+// Fetched page
+type Page struct {
+	URL         string
+	ContentType string
+	Body        []byte
+}
+
+// Fetcher loads a document by url
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) (*Page, error)
+}
+
+type httpFetcher struct {
+	client      *http.Client
+	maxBodySize int64
+	userAgent   string
+}
+
+// HTTP fetcher constructor, client is injected so it can be replaced in tests
+func NewHTTPFetcher(client *http.Client, cfg *config.Config) Fetcher {
+	return &httpFetcher{
+		client:      client,
+		maxBodySize: cfg.Documents.MaxDocumentSize,
+		userAgent:   cfg.Documents.UserAgent,
+	}
+}
+
+// Fetch page body by url
+func (f *httpFetcher) Fetch(ctx context.Context, url string) (*Page, error) {
+	const op = "pkg.fetcher.fetch"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s.NewRequest: %w", op, err)
+	}
+	req.Header.Set("User-Agent", f.userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,text/plain;q=0.9,*/*;q=0.8")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s.Do: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("%s: %w: %d", op, ErrUnexpectedStatus, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, f.maxBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%s.ReadAll: %w", op, err)
+	}
+	if int64(len(body)) > f.maxBodySize {
+		return nil, fmt.Errorf("%s: %w", op, ErrTooLarge)
+	}
+
+	page := &Page{
+		URL:         resp.Request.URL.String(),
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        body,
+	}
+	page.decode()
+
+	return page, nil
+}
+
+// Transcode text pages to UTF-8 from the charset of the header, of a <meta> tag or of their bytes,
+// the content type tells the new charset. Binary files like PDFs are kept as is.
+func (p *Page) decode() {
+	mediaType, params, err := mime.ParseMediaType(p.ContentType)
+	if err != nil || mediaType == "application/octet-stream" {
+		// Content of an unknown type is text when it looks so
+		if sniffed, _, _ := mime.ParseMediaType(http.DetectContentType(p.Body)); isText(sniffed) {
+			p.Body = textnorm.ToUTF8(p.Body, "")
+		}
+		return
+	}
+	if !isText(mediaType) {
+		return
+	}
+
+	p.Body = textnorm.ToUTF8(p.Body, p.ContentType)
+	// Charset of the header would decode the body once more
+	params["charset"] = textnorm.UTF8
+	p.ContentType = mime.FormatMediaType(mediaType, params)
+}
+
+// Media type of text content, markup and data formats included
+func isText(mediaType string) bool {
+	switch {
+	case strings.HasPrefix(mediaType, "text/"), strings.HasSuffix(mediaType, "+xml"),
+		strings.HasSuffix(mediaType, "+json"), strings.HasSuffix(mediaType, "yaml"):
+		return true
+	}
+
+	return mediaType == "application/xml" || mediaType == "application/json" || mediaType == "application/x-subrip"
+}
+
+// Check if page contains html markup
+func (p *Page) IsHTML() bool {
+	mediaType, _, err := mime.ParseMediaType(p.ContentType)
+	if err != nil {
+		return http.DetectContentType(p.Body) == "text/html; charset=utf-8"
+	}
+
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
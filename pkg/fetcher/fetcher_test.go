@@ -0,0 +1,120 @@
+package fetcher
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shlembo598/text-lexicon-go/internal/config"
+)
+
+func TestFetch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/docs/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != "text-lexicon-go" {
+			http.Error(w, "unknown agent", http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<p>Queues</p>"))
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/docs/queues", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/large", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(make([]byte, 33))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cfg := &config.Config{}
+	cfg.Documents.MaxDocumentSize = 32
+	cfg.Documents.UserAgent = "text-lexicon-go"
+	f := NewHTTPFetcher(server.Client(), cfg)
+
+	tests := []struct {
+		name string
+		path string
+		// Url of the fetched page after redirects
+		url  string
+		body string
+		err  error
+	}{
+		{name: "page", path: "/docs/queues", url: "/docs/queues", body: "<p>Queues</p>"},
+		{name: "redirect", path: "/moved", url: "/docs/queues", body: "<p>Queues</p>"},
+		{name: "not found", path: "/missing", err: ErrUnexpectedStatus},
+		{name: "too large", path: "/large", err: ErrTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := f.Fetch(context.Background(), server.URL+tt.path)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Fetch() error = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+			if page.URL != server.URL+tt.url || string(page.Body) != tt.body {
+				t.Errorf("Fetch() = %s %q, want %s %q", page.URL, page.Body, server.URL+tt.url, tt.body)
+			}
+			if !page.IsHTML() {
+				t.Errorf("IsHTML() = false for %q", page.ContentType)
+			}
+		})
+	}
+}
+
+func TestFetchCharset(t *testing.T) {
+	// "Очередь" in windows-1251
+	cp1251 := []byte("<p>\xce\xf7\xe5\xf0\xe5\xe4\xfc</p>")
+	pdf := []byte("%PDF-1.7\n\xce\xf7\xe5")
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+		wantType    string
+		want        []byte
+	}{
+		{
+			name: "charset of the header", contentType: "text/html; charset=windows-1251", body: cp1251,
+			wantType: "text/html; charset=utf-8", want: []byte("<p>Очередь</p>"),
+		},
+		{
+			name: "charset of the bytes", contentType: "text/plain", body: cp1251,
+			wantType: "text/plain; charset=utf-8", want: []byte("<p>Очередь</p>"),
+		},
+		{
+			name: "binary file", contentType: "application/pdf", body: pdf,
+			wantType: "application/pdf", want: pdf,
+		},
+		{
+			name: "text of an unknown type", contentType: "application/octet-stream", body: []byte("\ufeffQueues"),
+			wantType: "application/octet-stream", want: []byte("Queues"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				_, _ = w.Write(tt.body)
+			}))
+			defer server.Close()
+
+			cfg := &config.Config{}
+			cfg.Documents.MaxDocumentSize = 1 << 20
+			page, err := NewHTTPFetcher(server.Client(), cfg).Fetch(context.Background(), server.URL)
+			if err != nil {
+				t.Fatalf("Fetch() error = %v", err)
+			}
+			if page.ContentType != tt.wantType || !bytes.Equal(page.Body, tt.want) {
+				t.Errorf("Fetch() = %s %q, want %s %q", page.ContentType, page.Body, tt.wantType, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package godoc
+
+import (
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/doc/comment"
+	"go/parser"
+	"go/token"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/extractor"
+)
+
+const Layout = "go"
+
+// Values of a group named in its heading, the rest is elided
+const maxHeadingNames = 3
+
+// Declaration with a doc comment
+type symbol struct {
+	pos token.Pos
+	// Qualified name of the symbol, the location of its sentences: "echo.Context.Bind"
+	label   string
+	heading string
+	level   int
+	// Identifier the comment usually starts with
+	name string
+	doc  string
+}
+
+// Go source file by its name or url
+func IsSource(name string) bool {
+	if i := strings.IndexAny(name, "?#"); i >= 0 {
+		name = name[:i]
+	}
+
+	return strings.EqualFold(path.Ext(name), ".go")
+}
+
+// Parse Go source file into an article of doc comments of the package and of its exported constants, variables,
+// types, functions and methods in source order. Every comment starts with a heading naming its symbol and its
+// blocks keep the qualified symbol name as the location. Doc links and the symbol name a comment starts with
+// are kept in backticks as code. The title is the package name when the file holds the package comment.
+func Parse(name string, source []byte) (*extractor.Article, error) {
+	const op = "pkg.godoc.parse"
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path.Base(name), source, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("%s.ParseFile: %w", op, err)
+	}
+
+	pkg, err := doc.NewFromFiles(fset, []*ast.File{file}, file.Name.Name)
+	if err != nil {
+		return nil, fmt.Errorf("%s.NewFromFiles: %w", op, err)
+	}
+
+	symbols := packageSymbols(pkg, file)
+	sort.SliceStable(symbols, func(i, j int) bool {
+		return symbols[i].pos < symbols[j].pos
+	})
+
+	article := &extractor.Article{Layout: Layout}
+	if pkg.Doc != "" {
+		article.Title = "Package " + pkg.Name
+	}
+	p := pkg.Parser()
+	for _, s := range symbols {
+		article.Blocks = append(article.Blocks, s.blocks(p)...)
+	}
+
+	if len(article.Blocks) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, extractor.ErrNoContent)
+	}
+
+	return article, nil
+}
+
+// Documented symbols of the package, members of types are a level below them
+func packageSymbols(pkg *doc.Package, file *ast.File) []symbol {
+	symbols := make([]symbol, 0)
+	if pkg.Doc != "" {
+		symbols = append(symbols, symbol{
+			pos: file.Package, label: pkg.Name, heading: "Package `" + pkg.Name + "`", level: 1, name: pkg.Name,
+			doc: pkg.Doc,
+		})
+	}
+
+	values := func(values []*doc.Value, level int) {
+		for _, v := range values {
+			if v.Doc == "" || len(v.Names) == 0 {
+				continue
+			}
+			symbols = append(symbols, symbol{
+				pos: v.Decl.Pos(), label: pkg.Name + "." + v.Names[0], heading: valuesHeading(v.Names), level: level,
+				name: v.Names[0], doc: v.Doc,
+			})
+		}
+	}
+	funcs := func(funcs []*doc.Func, receiver string, level int) {
+		for _, f := range funcs {
+			if f.Doc == "" {
+				continue
+			}
+			name := f.Name
+			if receiver != "" {
+				name = receiver + "." + f.Name
+			}
+			symbols = append(symbols, symbol{
+				pos: f.Decl.Pos(), label: pkg.Name + "." + name, heading: "`" + name + "`", level: level,
+				name: f.Name, doc: f.Doc,
+			})
+		}
+	}
+
+	values(pkg.Consts, 2)
+	values(pkg.Vars, 2)
+	funcs(pkg.Funcs, "", 2)
+	for _, t := range pkg.Types {
+		if t.Doc != "" {
+			symbols = append(symbols, symbol{
+				pos: t.Decl.Pos(), label: pkg.Name + "." + t.Name, heading: "`" + t.Name + "`", level: 2,
+				name: t.Name, doc: t.Doc,
+			})
+		}
+		for _, m := range members(t) {
+			name := t.Name + "." + m.Names[0].Name
+			symbols = append(symbols, symbol{
+				pos: m.Pos(), label: pkg.Name + "." + name, heading: "`" + name + "`", level: 3,
+				name: m.Names[0].Name, doc: m.Doc.Text(),
+			})
+		}
+		values(t.Consts, 3)
+		values(t.Vars, 3)
+		funcs(t.Funcs, "", 3)
+		funcs(t.Methods, t.Name, 3)
+	}
+
+	return symbols
+}
+
+// Documented exported fields of a struct and methods of an interface, go/doc leaves unexported ones out
+func members(t *doc.Type) []*ast.Field {
+	fields := make([]*ast.Field, 0)
+	for _, spec := range t.Decl.Specs {
+		typeSpec, ok := spec.(*ast.TypeSpec)
+		if !ok || typeSpec.Name.Name != t.Name {
+			continue
+		}
+
+		var list *ast.FieldList
+		switch typ := typeSpec.Type.(type) {
+		case *ast.StructType:
+			list = typ.Fields
+		case *ast.InterfaceType:
+			list = typ.Methods
+		}
+		if list == nil {
+			continue
+		}
+
+		for _, field := range list.List {
+			if field.Doc != nil && len(field.Names) > 0 && field.Names[0].IsExported() {
+				fields = append(fields, field)
+			}
+		}
+	}
+
+	return fields
+}
+
+// Heading of a constant or variable group: "`A`, `B`, `C` …"
+func valuesHeading(names []string) string {
+	shown := make([]string, 0, maxHeadingNames)
+	for _, name := range names[:min(len(names), maxHeadingNames)] {
+		shown = append(shown, "`"+name+"`")
+	}
+	heading := strings.Join(shown, ", ")
+	if len(names) > maxHeadingNames {
+		heading += " …"
+	}
+
+	return heading
+}
+
+// Heading and blocks of the doc comment, all located at the symbol
+func (s symbol) blocks(p *comment.Parser) []extractor.Block {
+	blocks := []extractor.Block{{Kind: extractor.BlockHeading, Level: s.level, Text: s.heading}}
+	add := func(b extractor.Block) {
+		if strings.TrimSpace(b.Text) != "" {
+			blocks = append(blocks, b)
+		}
+	}
+
+	for i, block := range p.Parse(s.doc).Content {
+		switch block := block.(type) {
+		case *comment.Paragraph:
+			text := inline(block.Text)
+			if i == 0 {
+				text = markName(text, s.name)
+			}
+			add(extractor.Block{Kind: extractor.BlockParagraph, Text: text})
+		case *comment.Heading:
+			add(extractor.Block{Kind: extractor.BlockHeading, Level: min(s.level+1, 6), Text: inline(block.Text)})
+		case *comment.Code:
+			add(extractor.Block{Kind: extractor.BlockCode, Text: strings.TrimRight(block.Text, "\n")})
+		case *comment.List:
+			for _, item := range block.Items {
+				parts := make([]string, 0, len(item.Content))
+				for _, content := range item.Content {
+					if paragraph, ok := content.(*comment.Paragraph); ok {
+						parts = append(parts, inline(paragraph.Text))
+					}
+				}
+				add(extractor.Block{Kind: extractor.BlockListItem, Text: strings.Join(parts, " ")})
+			}
+		}
+	}
+
+	for i := range blocks {
+		blocks[i].Location = s.label
+	}
+
+	return blocks
+}
+
+// Text of a paragraph on one line, doc links are code
+func inline(texts []comment.Text) string {
+	var b strings.Builder
+	for _, text := range texts {
+		switch text := text.(type) {
+		case comment.Plain:
+			b.WriteString(string(text))
+		case comment.Italic:
+			b.WriteString(string(text))
+		case *comment.Link:
+			b.WriteString(inline(text.Text))
+		case *comment.DocLink:
+			b.WriteString("`" + inline(text.Text) + "`")
+		}
+	}
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// Put the symbol name a comment starts with in backticks: "`Context` represents", "A `Context` is",
+// "Package `echo` implements"
+func markName(text, name string) string {
+	for _, prefix := range []string{"", "A ", "An ", "The ", "Package "} {
+		if rest, ok := strings.CutPrefix(text, prefix+name); ok && (rest == "" || strings.HasPrefix(rest, " ")) {
+			return prefix + "`" + name + "`" + rest
+		}
+	}
+
+	return text
+}
@@ -0,0 +1,154 @@
+package godoc
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/extractor"
+)
+
+const source = `// Package echo implements a web framework.
+//
+// # Overview
+//
+// Handlers read a [Context]:
+//
+//	e := echo.New()
+//
+// It offers:
+//   - Fast routing
+//   - Middleware
+package echo
+
+// Methods of requests
+const (
+	GET    = "GET"
+	POST   = "POST"
+	PUT    = "PUT"
+	DELETE = "DELETE"
+)
+
+// unexported is left out
+const unexported = 1
+
+// Context represents the context of the current request.
+type Context interface {
+	// Bind binds the request body into a value.
+	Bind(i interface{}) error
+	// reset is internal
+	reset()
+}
+
+// An Echo is the top level framework instance.
+type Echo struct {
+	// Debug turns the debug mode on.
+	Debug bool
+	// router is not documented
+	router int
+}
+
+// New creates an instance of [Echo].
+func New() *Echo { return &Echo{} }
+
+// Start starts the server on the address.
+func (e *Echo) Start(address string) error { return nil }
+
+func (e *Echo) Undocumented() {}
+`
+
+func TestParse(t *testing.T) {
+	at := func(location string, blocks ...extractor.Block) []extractor.Block {
+		for i := range blocks {
+			blocks[i].Location = location
+		}
+		return blocks
+	}
+	heading := func(level int, text string) extractor.Block {
+		return extractor.Block{Kind: extractor.BlockHeading, Level: level, Text: text}
+	}
+	paragraph := func(text string) extractor.Block {
+		return extractor.Block{Kind: extractor.BlockParagraph, Text: text}
+	}
+
+	var blocks []extractor.Block
+	for _, symbol := range [][]extractor.Block{
+		at("echo",
+			heading(1, "Package `echo`"),
+			paragraph("Package `echo` implements a web framework."),
+			heading(2, "Overview"),
+			paragraph("Handlers read a `Context`:"),
+			extractor.Block{Kind: extractor.BlockCode, Text: "e := echo.New()"},
+			paragraph("It offers:"),
+			extractor.Block{Kind: extractor.BlockListItem, Text: "Fast routing"},
+			extractor.Block{Kind: extractor.BlockListItem, Text: "Middleware"},
+		),
+		at("echo.GET", heading(2, "`GET`, `POST`, `PUT` …"), paragraph("Methods of requests")),
+		at("echo.Context",
+			heading(2, "`Context`"), paragraph("`Context` represents the context of the current request.")),
+		at("echo.Context.Bind",
+			heading(3, "`Context.Bind`"), paragraph("`Bind` binds the request body into a value.")),
+		at("echo.Echo", heading(2, "`Echo`"), paragraph("An `Echo` is the top level framework instance.")),
+		at("echo.Echo.Debug", heading(3, "`Echo.Debug`"), paragraph("`Debug` turns the debug mode on.")),
+		at("echo.New", heading(3, "`New`"), paragraph("`New` creates an instance of `Echo`.")),
+		at("echo.Echo.Start", heading(3, "`Echo.Start`"), paragraph("`Start` starts the server on the address.")),
+	} {
+		blocks = append(blocks, symbol...)
+	}
+
+	tests := []struct {
+		name   string
+		source string
+		want   *extractor.Article
+		err    error
+	}{
+		{
+			name:   "package",
+			source: source,
+			want:   &extractor.Article{Title: "Package echo", Layout: Layout, Blocks: blocks},
+		},
+		{
+			name:   "file without package comment",
+			source: "package echo\n\n// Version of the framework\nconst Version = \"4.0\"\n",
+			want: &extractor.Article{Layout: Layout, Blocks: at("echo.Version",
+				heading(2, "`Version`"), paragraph("`Version` of the framework"))},
+		},
+		{
+			name:   "nothing documented",
+			source: "package echo\n\nfunc New() {}\n",
+			err:    extractor.ErrNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse("echo.go", []byte(tt.source))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Parse("echo.go", []byte("package")); err == nil {
+		t.Error("Parse() of invalid source error = nil, want an error")
+	}
+}
+
+func TestIsSource(t *testing.T) {
+	tests := map[string]bool{
+		"echo.go": true,
+		"https://raw.githubusercontent.com/labstack/echo/master/context.GO?token=1": true,
+		"https://github.com/labstack/echo/blob/master/echo.go#L10":                  true,
+		"echo.go.txt":         false,
+		"https://go.dev/doc/": false,
+	}
+
+	for name, want := range tests {
+		if got := IsSource(name); got != want {
+			t.Errorf("IsSource(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
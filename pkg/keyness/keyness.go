@@ -0,0 +1,32 @@
+package keyness
+
+import (
+	"math"
+)
+
+// Signed log-likelihood of a word used a times in a text of c words and b times in a reference corpus
+// of d words. The score is positive when the word is used in the text more often than in the corpus,
+// scores above 3.84 are significant at the 0.05 level and above 15.13 at the 0.0001 level.
+func LogLikelihood(a, c, b, d float64) float64 {
+	if c <= 0 || d <= 0 || a+b <= 0 {
+		return 0
+	}
+
+	expectedText := c * (a + b) / (c + d)
+	expectedReference := d * (a + b) / (c + d)
+
+	score := 2 * (term(a, expectedText) + term(b, expectedReference))
+	if a/c < b/d {
+		return -score
+	}
+
+	return score
+}
+
+func term(observed, expected float64) float64 {
+	if observed == 0 || expected == 0 {
+		return 0
+	}
+
+	return observed * math.Log(observed/expected)
+}
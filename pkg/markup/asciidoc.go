@@ -0,0 +1,210 @@
+package markup
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/extractor"
+)
+
+var (
+	adocHeadingRe    = regexp.MustCompile(`^(={1,6}|#{1,6})\s+(.*?)\s*(?:=+)?\s*$`)
+	adocAttributeRe  = regexp.MustCompile(`^:!?[\w-]+!?:`)
+	adocBlockAttrRe  = regexp.MustCompile(`^\[(.*)\]\s*$`)
+	adocBlockTitleRe = regexp.MustCompile(`^\.([^\s.].*)$`)
+	adocDelimiterRe  = regexp.MustCompile(`^(-{4,}|\.{4,}|={4,}|\*{4,}|_{4,}|/{4,}|\+{4,}|--|` + "`{3,}" + `)\s*$`)
+	adocTableRe      = regexp.MustCompile(`^[|,:!]===\s*$`)
+	adocMacroRe      = regexp.MustCompile(`^(?:image|include|video|audio|toc|ifdef|ifndef|ifeval|endif)::`)
+	adocBreakRe      = regexp.MustCompile(`^(?:'{3,}|<{3,}|-{3}|\*{3})\s*$`)
+	adocAdmonitionRe = regexp.MustCompile(`^(?:NOTE|TIP|IMPORTANT|WARNING|CAUTION):\s+(.*)$`)
+	adocListItemRe   = regexp.MustCompile(`^\s*(?:\*{1,5}|-|\.{1,5}|\d{1,9}\.|[a-zA-Z]\.)\s+(?:\[[ xX*]\]\s+)?(.*)$`)
+	adocTermRe       = regexp.MustCompile(`^(.*?\S)(?::{2,4}|;;)(?:\s+(.*))?$`)
+	adocCommentRe    = regexp.MustCompile(`^//(?:[^/]|$)`)
+	adocCodeSpanRe   = regexp.MustCompile("`\\+?([^`]+?)\\+?`")
+	adocPassRe       = regexp.MustCompile(`\+\+?([^+]+?)\+?\+`)
+	adocLinkRe       = regexp.MustCompile(`(?:link:|xref:|mailto:)?(?:https?://)?[^\s\[\]]*\[([^\]]*)\]`)
+	adocCrossRefRe   = regexp.MustCompile(`<<[^,>]*(?:,\s*([^>]*))?>>`)
+	adocUIMacroRe    = regexp.MustCompile(
+		`\b(?:kbd|btn|menu|pass|footnote|footnoteref|anchor|indexterm2?):[^\[\s]*\[([^\]]*)\]`,
+	)
+	adocAttrRefRe = regexp.MustCompile(`\{[\w-]+\}`)
+	adocAnchorRe  = regexp.MustCompile(`\[\[[^\]]*\]\]|\[#[^\]]*\]`)
+	adocStrongRe  = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__|##(.+?)##`)
+	adocMarkRe    = regexp.MustCompile(`(^|[^\w#])#([^#\s](?:[^#]*[^#\s])?)#`)
+	adocRoleRe    = regexp.MustCompile(`\[\.[\w-]+\]`)
+)
+
+// Block styles which make a delimited block code
+var adocCodeStyles = map[string]bool{"source": true, "listing": true, "literal": true}
+
+func parseAsciiDoc(lines []string) []extractor.Block {
+	b := newBuilder(asciiDocInline)
+
+	style := ""
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" {
+			b.flush()
+			style = ""
+			i++
+			continue
+		}
+
+		if adocCommentRe.MatchString(trimmed) || adocMacroRe.MatchString(trimmed) || adocBreakRe.MatchString(trimmed) ||
+			adocAttributeRe.MatchString(line) && len(b.text) == 0 {
+			b.flush()
+			i++
+			continue
+		}
+
+		if adocTableRe.MatchString(trimmed) {
+			end := i + 1
+			rows := make([][]string, 0)
+			for end < len(lines) && strings.TrimSpace(lines[end]) != trimmed {
+				if row := strings.TrimSpace(lines[end]); row != "" {
+					rows = append(rows, strings.FieldsFunc(row, func(r rune) bool { return r == rune(trimmed[0]) }))
+				}
+				end++
+			}
+			b.table(rows)
+			i = end + 1
+			style = ""
+			continue
+		}
+
+		if adocDelimiterRe.MatchString(trimmed) {
+			end := i + 1
+			for end < len(lines) && strings.TrimSpace(lines[end]) != trimmed {
+				end++
+			}
+			content := lines[i+1 : min(end, len(lines))]
+
+			switch trimmed[0] {
+			case '/', '+':
+				// Comments and passthrough html
+			case '-', '.', '`':
+				if trimmed == "--" && !adocCodeStyles[style] {
+					b.nested(parseAsciiDoc(content))
+				} else {
+					b.code(content)
+				}
+			default:
+				if adocCodeStyles[style] {
+					b.code(content)
+				} else {
+					b.nested(parseAsciiDoc(content))
+				}
+			}
+			i = end + 1
+			style = ""
+			continue
+		}
+
+		if match := adocBlockAttrRe.FindStringSubmatch(trimmed); match != nil && len(b.text) == 0 {
+			if !strings.HasPrefix(match[1], "[") {
+				style, _, _ = strings.Cut(strings.ToLower(match[1]), ",")
+				style = strings.TrimSpace(style)
+			}
+			i++
+			continue
+		}
+
+		if match := adocHeadingRe.FindStringSubmatch(line); match != nil && len(b.text) == 0 {
+			b.heading(len(match[1]), match[2])
+			i++
+			continue
+		}
+
+		if match := adocBlockTitleRe.FindStringSubmatch(trimmed); match != nil && len(b.text) == 0 {
+			b.line(match[1])
+			b.flush()
+			i++
+			continue
+		}
+
+		// Literal paragraph indented by spaces
+		if indentOf(line) > 0 && len(b.text) == 0 && !adocListItemRe.MatchString(line) {
+			end := i
+			for end < len(lines) && !isBlank(lines[end]) {
+				end++
+			}
+			b.code(lines[i:end])
+			i = end
+			continue
+		}
+
+		if match := adocAdmonitionRe.FindStringSubmatch(trimmed); match != nil && len(b.text) == 0 {
+			b.line(match[1])
+			i++
+			continue
+		}
+
+		if match := adocListItemRe.FindStringSubmatch(line); match != nil {
+			b.start(extractor.BlockListItem)
+			b.line(match[1])
+			i++
+			continue
+		}
+
+		if match := adocTermRe.FindStringSubmatch(trimmed); match != nil && !strings.Contains(match[1], "://") {
+			b.start(extractor.BlockListItem)
+			b.line(match[1])
+			b.line(match[2])
+			i++
+			continue
+		}
+
+		// List continuation joins the next block to the item, the block is taken on its own
+		if trimmed == "+" {
+			b.flush()
+			i++
+			continue
+		}
+
+		if style != "" && len(b.text) == 0 && adocCodeStyles[style] {
+			end := i
+			for end < len(lines) && !isBlank(lines[end]) {
+				end++
+			}
+			b.code(lines[i:end])
+			i = end
+			continue
+		}
+
+		b.line(line)
+		i++
+	}
+	b.flush()
+
+	return b.blocks
+}
+
+// Drop inline markup of AsciiDoc keeping text of links and macros and code spans in backticks
+func asciiDocInline(text string) string {
+	return outsideCode(text, adocCodeSpanRe, codeSpan, func(s string) string {
+		s = adocAnchorRe.ReplaceAllString(s, "")
+		s = adocRoleRe.ReplaceAllString(s, "")
+		s = adocUIMacroRe.ReplaceAllStringFunc(s, func(m string) string {
+			if strings.HasPrefix(m, "footnote") || strings.HasPrefix(m, "anchor") {
+				return ""
+			}
+			return adocUIMacroRe.FindStringSubmatch(m)[1]
+		})
+		s = adocCrossRefRe.ReplaceAllString(s, "$1")
+		s = adocLinkRe.ReplaceAllStringFunc(s, func(m string) string {
+			text := adocLinkRe.FindStringSubmatch(m)[1]
+			if text == "" {
+				return strings.SplitN(m, "[", 2)[0]
+			}
+			return text
+		})
+		s = adocAttrRefRe.ReplaceAllString(s, "")
+		s = adocPassRe.ReplaceAllString(s, "$1")
+		s = adocStrongRe.ReplaceAllString(s, "$1$2$3")
+		s = emphasisRe.ReplaceAllString(s, "$1$2")
+		s = underscoreRe.ReplaceAllString(s, "$1$2$3")
+		return adocMarkRe.ReplaceAllString(s, "$1$2")
+	})
+}
@@ -0,0 +1,191 @@
+package markup
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/extractor"
+)
+
+var (
+	mdHeadingRe   = regexp.MustCompile(`^ {0,3}(#{1,6})(?:\s+(.*?))?\s*#*\s*$`)
+	mdSetextRe    = regexp.MustCompile(`^ {0,3}(=+|-+)\s*$`)
+	mdFenceRe     = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})")
+	mdBreakRe     = regexp.MustCompile(`^ {0,3}([-*_])(?:\s*([-*_]))(?:\s*([-*_]))[\s\-*_]*$`)
+	mdListItemRe  = regexp.MustCompile(`^(\s*)(?:[-*+]|\d{1,9}[.)])\s+(?:\[[ xX]\]\s+)?(.*)$`)
+	mdQuoteRe     = regexp.MustCompile(`^ {0,3}> ?`)
+	mdReferenceRe = regexp.MustCompile(`^ {0,3}\[[^\]]+\]:\s*\S+`)
+	// Admonitions of MkDocs and Python-Markdown: !!! note "Title", collapsible ??? and ???+
+	mdAdmonitionRe = regexp.MustCompile(`^(\s*)(?:!!!|\?\?\?\+?)\s*[\w-]+(?:\s+"(.*)")?\s*$`)
+	// Alerts of GitHub: > [!NOTE]
+	mdAlertRe     = regexp.MustCompile(`^\s*\[!\w+\]\s*$`)
+	mdTableRowRe  = regexp.MustCompile(`^\s*\|`)
+	mdTableRuleRe = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	mdFrontMatter = regexp.MustCompile(`^(---|\+\+\+)\s*$`)
+	mdCodeSpanRe  = regexp.MustCompile("`+(.+?)`+")
+	mdImageRe     = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)|!\[[^\]]*\]\[[^\]]*\]`)
+	mdLinkRe      = regexp.MustCompile(`\[([^\]]+)\](?:\([^)]*\)|\[[^\]]*\])`)
+	mdAutolinkRe  = regexp.MustCompile(`<((?:https?|mailto):[^>\s]+)>`)
+	mdTagRe       = regexp.MustCompile(`</?[a-zA-Z][\w-]*(?:\s[^>]*)?/?>`)
+	mdStrongRe    = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__|~~(.+?)~~`)
+	mdEscapeRe    = regexp.MustCompile(`\\([\\` + "`" + `*_{}\[\]()#+\-.!|<>])`)
+	mdFootnoteRe  = regexp.MustCompile(`\[\^[^\]]+\]`)
+)
+
+func parseMarkdown(lines []string) []extractor.Block {
+	b := newBuilder(markdownInline)
+
+	i := 0
+	if len(lines) > 0 && mdFrontMatter.MatchString(lines[0]) {
+		for j := 1; j < len(lines); j++ {
+			if strings.TrimSpace(lines[j]) == strings.TrimSpace(lines[0]) {
+				i = j + 1
+				break
+			}
+		}
+	}
+
+	inList := false
+	for i < len(lines) {
+		line := lines[i]
+
+		if isBlank(line) {
+			b.flush()
+			i++
+			continue
+		}
+
+		if match := mdFenceRe.FindStringSubmatch(line); match != nil {
+			end := i + 1
+			for end < len(lines) && !isClosingFence(lines[end], match[2]) {
+				end++
+			}
+			b.code(lines[i+1 : min(end, len(lines))])
+			i = end + 1
+			inList = false
+			continue
+		}
+
+		if match := mdHeadingRe.FindStringSubmatch(line); match != nil {
+			b.heading(len(match[1]), match[2])
+			i++
+			inList = false
+			continue
+		}
+
+		if len(b.text) == 0 && !inList && i+1 < len(lines) && mdSetextRe.MatchString(lines[i+1]) &&
+			!mdListItemRe.MatchString(line) && !mdQuoteRe.MatchString(line) {
+			level := 1
+			if strings.TrimSpace(lines[i+1])[0] == '-' {
+				level = 2
+			}
+			b.heading(level, line)
+			i += 2
+			continue
+		}
+
+		if mdBreakRe.MatchString(line) {
+			b.flush()
+			i++
+			inList = false
+			continue
+		}
+
+		if match := mdAdmonitionRe.FindStringSubmatch(line); match != nil {
+			b.flush()
+			if match[2] != "" {
+				b.line(match[2])
+				b.flush()
+			}
+			end := indentedBlock(lines, i+1, len(match[1]))
+			b.nested(parseMarkdown(dedent(lines[i+1 : end])))
+			i = end
+			continue
+		}
+
+		if mdQuoteRe.MatchString(line) {
+			end := i
+			quoted := make([]string, 0)
+			for end < len(lines) && mdQuoteRe.MatchString(lines[end]) {
+				if text := mdQuoteRe.ReplaceAllString(lines[end], ""); !mdAlertRe.MatchString(text) {
+					quoted = append(quoted, text)
+				}
+				end++
+			}
+			b.nested(parseMarkdown(quoted))
+			i = end
+			continue
+		}
+
+		if mdTableRowRe.MatchString(line) && i+1 < len(lines) && mdTableRuleRe.MatchString(lines[i+1]) {
+			rows := make([][]string, 0)
+			end := i
+			for end < len(lines) && !isBlank(lines[end]) && strings.Contains(lines[end], "|") {
+				if !mdTableRuleRe.MatchString(lines[end]) {
+					rows = append(rows, splitRow(lines[end]))
+				}
+				end++
+			}
+			b.table(rows)
+			i = end
+			continue
+		}
+
+		if match := mdListItemRe.FindStringSubmatch(line); match != nil {
+			b.start(extractor.BlockListItem)
+			b.line(match[2])
+			i++
+			inList = true
+			continue
+		}
+
+		if mdReferenceRe.MatchString(line) {
+			i++
+			continue
+		}
+
+		// Indented code is a block of lines indented by four spaces after a blank line outside of lists
+		if len(b.text) == 0 && !inList && indentOf(line) >= 4 {
+			end := i
+			for end < len(lines) && (isBlank(lines[end]) || indentOf(lines[end]) >= 4) {
+				end++
+			}
+			b.code(lines[i:end])
+			i = end
+			continue
+		}
+
+		if len(b.text) == 0 {
+			inList = inList && indentOf(line) > 0
+			if inList {
+				b.start(extractor.BlockListItem)
+			}
+		}
+		b.line(line)
+		i++
+	}
+	b.flush()
+
+	return b.blocks
+}
+
+func isClosingFence(line, fence string) bool {
+	trimmed := strings.TrimSpace(line)
+
+	return strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" && indentOf(line) < 4
+}
+
+// Drop markdown inline markup keeping text of links and code spans in backticks
+func markdownInline(text string) string {
+	return outsideCode(text, mdCodeSpanRe, codeSpan, func(s string) string {
+		s = mdImageRe.ReplaceAllString(s, "")
+		s = mdFootnoteRe.ReplaceAllString(s, "")
+		s = mdLinkRe.ReplaceAllString(s, "$1")
+		s = mdAutolinkRe.ReplaceAllString(s, "$1")
+		s = mdTagRe.ReplaceAllString(s, "")
+		s = mdStrongRe.ReplaceAllString(s, "$1$2$3")
+		s = emphasisRe.ReplaceAllString(s, "$1$2")
+		s = underscoreRe.ReplaceAllString(s, "$1$2$3")
+		return mdEscapeRe.ReplaceAllString(s, "$1")
+	})
+}
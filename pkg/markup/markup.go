@@ -0,0 +1,250 @@
+package markup
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/extractor"
+)
+
+const (
+	FormatMarkdown = "markdown"
+	FormatRST      = "rst"
+	FormatAsciiDoc = "asciidoc"
+)
+
+var ErrUnknownFormat = errors.New("unknown markup format")
+
+// File extensions of supported formats
+var extensions = map[string]string{
+	".md":       FormatMarkdown,
+	".markdown": FormatMarkdown,
+	".mdx":      FormatMarkdown,
+	".rst":      FormatRST,
+	".rest":     FormatRST,
+	".adoc":     FormatAsciiDoc,
+	".asciidoc": FormatAsciiDoc,
+	".asc":      FormatAsciiDoc,
+}
+
+var (
+	htmlCommentRe = regexp.MustCompile(`(?s)<!--.*?-->`)
+	// Emphasis of markdown and AsciiDoc, underscores inside words are kept
+	emphasisRe   = regexp.MustCompile(`(^|[^\w*])\*([^*\s](?:[^*]*[^*\s])?)\*`)
+	underscoreRe = regexp.MustCompile(`(^|[^\w])_([^_\s](?:[^_]*[^_\s])?)_($|[^\w])`)
+)
+
+// Format of a file by its name or url, empty for unsupported files
+func FormatOf(name string) string {
+	if i := strings.IndexAny(name, "?#"); i >= 0 {
+		name = name[:i]
+	}
+
+	return extensions[strings.ToLower(path.Ext(name))]
+}
+
+// Parse markup source into an article with the same blocks as pages of the html extractor: headings,
+// paragraphs, list items, tables and code. Admonitions are unwrapped into paragraphs, markup of links,
+// emphasis and images is dropped, inline code is kept in backticks. The title is the first top level heading.
+func Parse(format string, source []byte) (*extractor.Article, error) {
+	const op = "pkg.markup.parse"
+
+	text := strings.ReplaceAll(string(source), "\r\n", "\n")
+	text = strings.TrimPrefix(text, "\ufeff")
+
+	var blocks []extractor.Block
+	switch format {
+	case FormatMarkdown:
+		blocks = parseMarkdown(lines(htmlCommentRe.ReplaceAllString(text, "")))
+	case FormatRST:
+		blocks = parseRST(lines(text))
+	case FormatAsciiDoc:
+		blocks = parseAsciiDoc(lines(text))
+	default:
+		return nil, fmt.Errorf("%s: %w: %s", op, ErrUnknownFormat, format)
+	}
+
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, extractor.ErrNoContent)
+	}
+
+	article := &extractor.Article{Layout: format, Blocks: blocks}
+	for _, b := range blocks {
+		if b.Kind == extractor.BlockHeading && b.Level == 1 {
+			article.Title = b.Text
+			break
+		}
+	}
+
+	return article, nil
+}
+
+func lines(text string) []string {
+	return strings.Split(strings.ReplaceAll(text, "\t", "    "), "\n")
+}
+
+// Collects lines of paragraphs and list items into blocks
+type builder struct {
+	blocks []extractor.Block
+	kind   extractor.BlockKind
+	text   []string
+	// Cleans inline markup of the format
+	inline func(string) string
+}
+
+func newBuilder(inline func(string) string) *builder {
+	return &builder{kind: extractor.BlockParagraph, inline: inline}
+}
+
+// Add line to the current paragraph or list item
+func (b *builder) line(text string) {
+	b.text = append(b.text, strings.TrimSpace(text))
+}
+
+// Start a new block of lines of the given kind
+func (b *builder) start(kind extractor.BlockKind) {
+	b.flush()
+	b.kind = kind
+}
+
+// End the current paragraph or list item
+func (b *builder) flush() {
+	if len(b.text) > 0 {
+		b.add(extractor.Block{Kind: b.kind, Text: b.inline(strings.Join(b.text, " "))})
+	}
+	b.text = nil
+	b.kind = extractor.BlockParagraph
+}
+
+func (b *builder) heading(level int, text string) {
+	b.flush()
+	b.add(extractor.Block{Kind: extractor.BlockHeading, Level: min(max(level, 1), 6), Text: b.inline(text)})
+}
+
+func (b *builder) code(code []string) {
+	b.flush()
+	b.add(extractor.Block{Kind: extractor.BlockCode, Text: strings.Trim(strings.Join(dedent(code), "\n"), "\n")})
+}
+
+// Table rows with cells separated by vertical bars as in the html extractor
+func (b *builder) table(rows [][]string) {
+	b.flush()
+
+	text := make([]string, 0, len(rows))
+	for _, row := range rows {
+		cells := make([]string, 0, len(row))
+		for _, cell := range row {
+			if cell = b.inline(strings.TrimSpace(cell)); cell != "" {
+				cells = append(cells, cell)
+			}
+		}
+		if len(cells) > 0 {
+			text = append(text, strings.Join(cells, " | "))
+		}
+	}
+	b.add(extractor.Block{Kind: extractor.BlockTable, Text: strings.Join(text, "\n")})
+}
+
+// Add blocks parsed from nested content like admonitions and quotes
+func (b *builder) nested(blocks []extractor.Block) {
+	b.flush()
+	for _, block := range blocks {
+		b.add(block)
+	}
+}
+
+func (b *builder) add(block extractor.Block) {
+	if strings.TrimSpace(block.Text) == "" {
+		return
+	}
+	block.Text = strings.TrimSpace(block.Text)
+	b.blocks = append(b.blocks, block)
+}
+
+// Remove the common indentation of non blank lines
+func dedent(lines []string) []string {
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if n := indentOf(line); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if len(line) >= indent && indent > 0 {
+			line = line[indent:]
+		}
+		result = append(result, strings.TrimRight(line, " "))
+	}
+
+	return result
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+// Lines after start which are blank or indented deeper than indent, trailing blank lines are left out
+func indentedBlock(lines []string, start, indent int) int {
+	end := start
+	for i := start; i < len(lines); i++ {
+		if isBlank(lines[i]) {
+			continue
+		}
+		if indentOf(lines[i]) <= indent {
+			break
+		}
+		end = i + 1
+	}
+
+	return end
+}
+
+// Apply clean to text outside of code spans matched by codeRe, code spans are replaced by code
+func outsideCode(text string, codeRe *regexp.Regexp, code func(string) string, clean func(string) string) string {
+	var sb strings.Builder
+	last := 0
+	for _, match := range codeRe.FindAllStringSubmatchIndex(text, -1) {
+		sb.WriteString(clean(text[last:match[0]]))
+		sb.WriteString(code(text[match[2]:match[3]]))
+		last = match[1]
+	}
+	sb.WriteString(clean(text[last:]))
+
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// Inline code in backticks, the fence is longer than any backtick run inside the code
+func codeSpan(code string) string {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return ""
+	}
+
+	fence := "`"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+
+	return fence + code + fence
+}
+
+// Split a table row on vertical bars, outer bars are optional
+func splitRow(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	row = strings.TrimSuffix(row, "|")
+
+	return strings.Split(row, "|")
+}
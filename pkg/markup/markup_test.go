@@ -0,0 +1,143 @@
+package markup
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/extractor"
+)
+
+const markdownSource = "---\ntitle: Front matter\n---\n" +
+	"# Getting *started*\n\n" +
+	"Install the `go get` package\nwith [a link](https://example.com).\n\n" +
+	"<!-- hidden -->\n" +
+	"- First item\n- Second\n  continued\n\n" +
+	"```go\nfunc main() {}\n```\n\n" +
+	"> Quoted text.\n\n" +
+	"| Name | Value |\n|------|-------|\n| a | b |\n\n" +
+	"!!! warning \"Careful\"\n    Admonition body.\n\n" +
+	"Setext heading\n--------------\n"
+
+const rstSource = "=========\nTop title\n=========\n\n" +
+	"Intro with ``code`` and `a link <https://example.com>`_ and *emphasis*.\n\n" +
+	"Section\n-------\n\n" +
+	"* First item\n* Second item\n\n" +
+	".. note::\n\n   Note body text.\n\n" +
+	".. code-block:: go\n\n   func main() {}\n\n" +
+	"Paragraph::\n\n    literal block\n\n" +
+	".. comment line\n"
+
+const asciiDocSource = "= Document Title\n:toc:\n\n" +
+	"== Section\n\n" +
+	"Paragraph with `code` and *bold* and link:https://example.com[a link].\n\n" +
+	"* First item\n* Second item\n\n" +
+	"[source,go]\n----\nfunc main() {}\n----\n\n" +
+	"NOTE: Admonition paragraph.\n\n" +
+	"[WARNING]\n====\nBlock admonition.\n====\n\n" +
+	"|===\n| Name | Value\n| a | b\n|===\n\n" +
+	"// comment\n"
+
+func TestParse(t *testing.T) {
+	heading := func(level int, text string) extractor.Block {
+		return extractor.Block{Kind: extractor.BlockHeading, Level: level, Text: text}
+	}
+	paragraph := func(text string) extractor.Block {
+		return extractor.Block{Kind: extractor.BlockParagraph, Text: text}
+	}
+	item := func(text string) extractor.Block {
+		return extractor.Block{Kind: extractor.BlockListItem, Text: text}
+	}
+	code := extractor.Block{Kind: extractor.BlockCode, Text: "func main() {}"}
+	table := extractor.Block{Kind: extractor.BlockTable, Text: "Name | Value\na | b"}
+
+	tests := []struct {
+		name   string
+		format string
+		source string
+		want   *extractor.Article
+		err    error
+	}{
+		{
+			name: "markdown", format: FormatMarkdown, source: markdownSource,
+			want: &extractor.Article{Title: "Getting started", Layout: FormatMarkdown, Blocks: []extractor.Block{
+				heading(1, "Getting started"),
+				paragraph("Install the `go get` package with a link."),
+				item("First item"),
+				item("Second continued"),
+				code,
+				paragraph("Quoted text."),
+				table,
+				paragraph("Careful"),
+				paragraph("Admonition body."),
+				heading(2, "Setext heading"),
+			}},
+		},
+		{
+			name: "reStructuredText", format: FormatRST, source: rstSource,
+			want: &extractor.Article{Title: "Top title", Layout: FormatRST, Blocks: []extractor.Block{
+				heading(1, "Top title"),
+				paragraph("Intro with `code` and a link and emphasis."),
+				heading(2, "Section"),
+				item("First item"),
+				item("Second item"),
+				paragraph("Note body text."),
+				code,
+				paragraph("Paragraph:"),
+				{Kind: extractor.BlockCode, Text: "literal block"},
+			}},
+		},
+		{
+			name: "AsciiDoc", format: FormatAsciiDoc, source: asciiDocSource,
+			want: &extractor.Article{Title: "Document Title", Layout: FormatAsciiDoc, Blocks: []extractor.Block{
+				heading(1, "Document Title"),
+				heading(2, "Section"),
+				paragraph("Paragraph with `code` and bold and a link."),
+				item("First item"),
+				item("Second item"),
+				code,
+				paragraph("Admonition paragraph."),
+				paragraph("Block admonition."),
+				table,
+			}},
+		},
+		{
+			name: "byte order mark and windows line ends", format: FormatMarkdown,
+			source: "\ufeff## Usage\r\n\r\nRun it.\r\n",
+			want: &extractor.Article{Layout: FormatMarkdown, Blocks: []extractor.Block{
+				heading(2, "Usage"), paragraph("Run it."),
+			}},
+		},
+		{name: "only comments", format: FormatMarkdown, source: "<!-- draft -->\n", err: extractor.ErrNoContent},
+		{name: "unknown format", format: "textile", source: "h1. Title", err: ErrUnknownFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.format, []byte(tt.source))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	tests := map[string]string{
+		"README.md":                         FormatMarkdown,
+		"docs/guide.MDX":                    FormatMarkdown,
+		"https://example.com/intro.rst?x=1": FormatRST,
+		"manual.adoc#install":               FormatAsciiDoc,
+		"index.html":                        "",
+		"Makefile":                          "",
+	}
+
+	for name, want := range tests {
+		if got := FormatOf(name); got != want {
+			t.Errorf("FormatOf(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
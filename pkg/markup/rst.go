@@ -0,0 +1,269 @@
+package markup
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/extractor"
+)
+
+var (
+	rstDirectiveRe  = regexp.MustCompile(`^(\s*)\.\.\s+([\w:-]+)::\s*(.*)$`)
+	rstCommentRe    = regexp.MustCompile(`^(\s*)\.\.(?:\s|$)`)
+	rstOptionRe     = regexp.MustCompile(`^\s*:[\w -]+:`)
+	rstListItemRe   = regexp.MustCompile(`^(\s*)(?:[-*+•]|#\.|\d{1,9}[.)]|\(\d{1,9}\)|[a-zA-Z]\))\s+(.*)$`)
+	rstFieldRe      = regexp.MustCompile(`^:([^:]+):\s*(.*)$`)
+	rstGridBorderRe = regexp.MustCompile(`^\s*\+[-=+]+\+\s*$`)
+	rstSimpleRuleRe = regexp.MustCompile(`^\s*=+(\s+=+)+\s*$`)
+	rstColumnGapRe  = regexp.MustCompile(`\s{2,}`)
+	rstLiteralRe    = regexp.MustCompile("``(.+?)``")
+	rstCodeRoleRe   = regexp.MustCompile(
+		":(?:code|literal|command|file|samp|kbd|envvar|option|program)(?::\\w+)?:`([^`]+)`",
+	)
+	rstRoleRe       = regexp.MustCompile(":[\\w:.+-]+:`([^`]+)`")
+	rstTargetRe     = regexp.MustCompile("`([^`<]*?)\\s*<[^>]*>`__?")
+	rstReferenceRe  = regexp.MustCompile("`([^`]+)`__?")
+	rstInterpretRe  = regexp.MustCompile("`([^`]+)`")
+	rstStrongRe     = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	rstSubstituteRe = regexp.MustCompile(`\|([^|\s](?:[^|]*[^|\s])?)\|`)
+	rstFootnoteRe   = regexp.MustCompile(`\s*\[(?:#\w*|\*|\d+)\]_`)
+	rstWordRefRe    = regexp.MustCompile(`\b([A-Za-z][A-Za-z0-9-]*)__?(\s|[.,;:!?)]|$)`)
+	rstRoleTextRe   = regexp.MustCompile(`^(.*?)\s*<[^>]*>$`)
+)
+
+// Directives whose content is code
+var rstCodeDirectives = map[string]bool{
+	"code-block": true, "code": true, "sourcecode": true, "parsed-literal": true, "doctest": true,
+	"testcode": true, "testoutput": true, "ipython": true, "jupyter-execute": true, "prompt": true,
+}
+
+// Directives whose content is text: admonitions and containers
+var rstTextDirectives = map[string]bool{
+	"note": true, "warning": true, "tip": true, "important": true, "caution": true, "danger": true,
+	"attention": true, "hint": true, "error": true, "seealso": true, "todo": true, "admonition": true,
+	"topic": true, "sidebar": true, "container": true, "only": true, "versionadded": true,
+	"versionchanged": true, "deprecated": true, "tab": true, "group-tab": true, "rubric": true,
+	"epigraph": true, "highlights": true, "pull-quote": true, "compound": true, "class": true,
+}
+
+// Directives whose argument is a title or a version rather than a part of the text
+var rstTitledDirectives = map[string]bool{
+	"admonition": true, "topic": true, "sidebar": true, "rubric": true, "tab": true, "group-tab": true,
+	"versionadded": true, "versionchanged": true, "deprecated": true, "only": true, "container": true,
+	"class": true,
+}
+
+func parseRST(lines []string) []extractor.Block {
+	// Heading levels are assigned in the order adornment styles appear
+	styles := make([]string, 0)
+	level := func(style string) int {
+		for i, s := range styles {
+			if s == style {
+				return i + 1
+			}
+		}
+		styles = append(styles, style)
+		return len(styles)
+	}
+
+	return parseRSTBlock(lines, level)
+}
+
+func parseRSTBlock(lines []string, level func(string) int) []extractor.Block {
+	b := newBuilder(rstInline)
+
+	literalNext := false
+	for i := 0; i < len(lines); {
+		line := lines[i]
+
+		if isBlank(line) {
+			b.flush()
+			i++
+			continue
+		}
+
+		// Section title with an overline and an underline
+		if isAdornment(line) && i+2 < len(lines) && !isBlank(lines[i+1]) &&
+			strings.TrimSpace(lines[i+2]) == strings.TrimSpace(line) {
+			b.heading(level("over"+line[:1]), lines[i+1])
+			i += 3
+			continue
+		}
+
+		// Section title with an underline at least as long as the title
+		if len(b.text) == 0 && i+1 < len(lines) && isAdornment(lines[i+1]) &&
+			indentOf(line) == 0 && len(strings.TrimSpace(lines[i+1])) >= min(len(strings.TrimSpace(line)), 4) {
+			b.heading(level(strings.TrimSpace(lines[i+1])[:1]), line)
+			i += 2
+			continue
+		}
+
+		if isAdornment(line) && len(strings.TrimSpace(line)) >= 4 {
+			// Transition
+			b.flush()
+			i++
+			continue
+		}
+
+		if match := rstDirectiveRe.FindStringSubmatch(line); match != nil {
+			indent, name, argument := len(match[1]), strings.ToLower(match[2]), match[3]
+			end := indentedBlock(lines, i+1, indent)
+			content := lines[i+1 : end]
+			for len(content) > 0 && rstOptionRe.MatchString(content[0]) {
+				content = content[1:]
+			}
+
+			switch {
+			case rstCodeDirectives[name]:
+				b.code(content)
+			case rstTextDirectives[name]:
+				b.flush()
+				if !rstTitledDirectives[name] {
+					content = append([]string{argument}, dedent(content)...)
+				} else if name == "admonition" || name == "topic" || name == "sidebar" || name == "rubric" {
+					b.line(argument)
+					b.flush()
+				}
+				b.nested(parseRSTBlock(dedent(content), level))
+			}
+			i = end
+			continue
+		}
+
+		if match := rstCommentRe.FindStringSubmatch(line); match != nil {
+			b.flush()
+			i = indentedBlock(lines, i+1, len(match[1]))
+			continue
+		}
+
+		if indentOf(line) > 0 && len(b.text) == 0 {
+			end := indentedBlock(lines, i, 0)
+			if literalNext {
+				b.code(lines[i:end])
+			} else {
+				// Block quote
+				b.nested(parseRSTBlock(dedent(lines[i:end]), level))
+			}
+			literalNext = false
+			i = end
+			continue
+		}
+		literalNext = false
+
+		if rstGridBorderRe.MatchString(line) {
+			end := i
+			rows := make([][]string, 0)
+			for end < len(lines) && (rstGridBorderRe.MatchString(lines[end]) ||
+				strings.HasPrefix(strings.TrimSpace(lines[end]), "|")) {
+				if !rstGridBorderRe.MatchString(lines[end]) {
+					rows = append(rows, splitRow(lines[end]))
+				}
+				end++
+			}
+			b.table(rows)
+			i = end
+			continue
+		}
+
+		if rstSimpleRuleRe.MatchString(line) {
+			rows := make([][]string, 0)
+			end := i + 1
+			for ; end < len(lines); end++ {
+				if rstSimpleRuleRe.MatchString(lines[end]) {
+					// The closing border is followed by a blank line, the one under the header is not
+					if end+1 == len(lines) || isBlank(lines[end+1]) {
+						end++
+						break
+					}
+					continue
+				}
+				if !isBlank(lines[end]) {
+					rows = append(rows, rstColumnGapRe.Split(strings.TrimSpace(lines[end]), -1))
+				}
+			}
+			b.table(rows)
+			i = end
+			continue
+		}
+
+		if match := rstListItemRe.FindStringSubmatch(line); match != nil {
+			b.start(extractor.BlockListItem)
+			b.line(match[2])
+			end := indentedBlock(lines, i+1, len(match[1]))
+			// Continuation lines of the item text, nested blocks after a blank line are parsed separately
+			j := i + 1
+			for j < end && !isBlank(lines[j]) {
+				b.line(lines[j])
+				j++
+			}
+			if j < end {
+				b.nested(parseRSTBlock(dedent(lines[j:end]), level))
+			}
+			b.flush()
+			i = end
+			continue
+		}
+
+		if match := rstFieldRe.FindStringSubmatch(line); match != nil && len(b.text) == 0 {
+			b.start(extractor.BlockParagraph)
+			b.line(match[2])
+			i++
+			continue
+		}
+
+		text := strings.TrimSpace(line)
+		if strings.HasSuffix(text, "::") {
+			literalNext = true
+			text = strings.TrimSuffix(text, "::")
+			if text != "" && !strings.HasSuffix(text, " ") {
+				text += ":"
+			}
+		}
+		b.line(text)
+		i++
+
+		if literalNext {
+			b.flush()
+		}
+	}
+	b.flush()
+
+	return b.blocks
+}
+
+// Line of one punctuation character repeated, underlines and overlines of section titles
+func isAdornment(line string) bool {
+	line = strings.TrimRight(line, " ")
+	if len(line) < 2 || !strings.ContainsRune("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", rune(line[0])) {
+		return false
+	}
+
+	return strings.Trim(line, line[:1]) == ""
+}
+
+// Drop inline markup of reStructuredText keeping text of references and roles and literals in backticks
+func rstInline(text string) string {
+	text = rstCodeRoleRe.ReplaceAllString(text, "``$1``")
+
+	return outsideCode(text, rstLiteralRe, codeSpan, func(s string) string {
+		s = rstFootnoteRe.ReplaceAllString(s, "")
+		s = rstRoleRe.ReplaceAllStringFunc(s, func(role string) string {
+			title := rstRoleRe.FindStringSubmatch(role)[1]
+			if match := rstRoleTextRe.FindStringSubmatch(title); match != nil && match[1] != "" {
+				return match[1]
+			}
+			// Python cross references show only the last part of dotted names with "~"
+			if strings.HasPrefix(title, "~") {
+				return title[strings.LastIndex(title, ".")+1:]
+			}
+			return strings.TrimPrefix(title, "!")
+		})
+		s = rstTargetRe.ReplaceAllString(s, "$1")
+		s = rstReferenceRe.ReplaceAllString(s, "$1")
+		s = rstInterpretRe.ReplaceAllString(s, "$1")
+		s = rstStrongRe.ReplaceAllString(s, "$1")
+		s = emphasisRe.ReplaceAllString(s, "$1$2")
+		s = rstSubstituteRe.ReplaceAllString(s, "$1")
+		return rstWordRefRe.ReplaceAllString(s, "$1$2")
+	})
+}
@@ -0,0 +1,142 @@
+package names
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/cefr"
+	"github.com/shlembo598/text-lexicon-go/pkg/tokenizer"
+)
+
+//go:embed brands.txt
+var brandsFile string
+
+// Lemmas of product and company names
+var brands = loadBrands(brandsFile)
+
+// Share of capitalized occurrences in the middle of sentences which makes a word a name
+const minCapitalizedShare = 0.9
+
+type Options struct {
+	// Capitalized occurrences in the middle of sentences needed to take a word out of the list for a name
+	MinCount int
+}
+
+// Capitalization of a word in the middle of sentences
+type stats struct {
+	capitalized int
+	lowercase   int
+}
+
+func loadBrands(data string) map[string]bool {
+	result := make(map[string]bool)
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		result[tokenizer.Lemma(strings.ToLower(line))] = true
+	}
+
+	return result
+}
+
+// Lemma is a known product or company name which is not an english word
+func IsBrand(lemma string) bool {
+	return brands[lemma] && cefr.Rank(lemma) == 0
+}
+
+// Word is a known product or company name, possessive endings are ignored: "GitHub's"
+func IsKnown(word string) bool {
+	word = strings.ToLower(word)
+	for _, suffix := range []string{"'s", "’s"} {
+		word = strings.TrimSuffix(word, suffix)
+	}
+
+	return brands[tokenizer.Lemma(word)]
+}
+
+// Find lemmas of probable proper nouns and product names among tokens of the text. A word is a name when it
+// is a known brand or when it is capitalized in the middle of sentences nearly every time. Brands which are
+// english words need the text to capitalize them too. Sentence starts, headings and code are not examined,
+// neither are words written in capitals which are usually acronyms.
+func Find(text string, tokens []tokenizer.Token, options Options) map[string]bool {
+	byLemma := make(map[string]*stats)
+
+	sentences, _ := tokenizer.Sentences(text)
+	s := 0
+	sentenceStart := true
+	for _, token := range tokens {
+		for s < len(sentences) && sentences[s].End <= token.Start {
+			s++
+			sentenceStart = true
+		}
+		if s == len(sentences) || token.Start < sentences[s].Start {
+			continue
+		}
+		first := sentenceStart
+		sentenceStart = false
+		if first || sentences[s].Heading || token.FromCode {
+			continue
+		}
+
+		st := byLemma[token.Lemma]
+		if st == nil {
+			st = &stats{}
+			byLemma[token.Lemma] = st
+		}
+		switch {
+		case isUpper(token.Text):
+		case startsUpper(token.Text):
+			st.capitalized++
+		default:
+			st.lowercase++
+		}
+	}
+
+	result := make(map[string]bool)
+	for _, token := range tokens {
+		if !token.FromCode && IsBrand(token.Lemma) {
+			result[token.Lemma] = true
+		}
+	}
+	for lemma, st := range byLemma {
+		if st.capitalized == 0 || float64(st.capitalized) < minCapitalizedShare*float64(st.capitalized+st.lowercase) {
+			continue
+		}
+		if st.capitalized >= options.MinCount || brands[lemma] {
+			result[lemma] = true
+		}
+	}
+
+	return result
+}
+
+func startsUpper(word string) bool {
+	r, _ := utf8.DecodeRuneInString(word)
+	return unicode.IsUpper(r)
+}
+
+// Word of two or more letters written in capitals with an optional plural or possessive ending: "API", "URLs"
+func isUpper(word string) bool {
+	for _, suffix := range []string{"'s", "’s", "s"} {
+		word = strings.TrimSuffix(word, suffix)
+	}
+
+	letters := 0
+	for _, r := range word {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+
+	return letters > 1
+}
@@ -0,0 +1,324 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"path"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/extractor"
+	"github.com/shlembo598/text-lexicon-go/pkg/markup"
+)
+
+const Layout = "openapi"
+
+// Nesting of schema properties followed, deeper properties are left out
+const maxSchemaDepth = 8
+
+var ErrNotSpec = errors.New("not an OpenAPI or Swagger document")
+
+// File extensions of specs, they are JSON or YAML
+var extensions = map[string]bool{".json": true, ".yaml": true, ".yml": true}
+
+// Media types of specs
+var mediaTypes = map[string]bool{
+	"application/json": true, "application/yaml": true, "application/x-yaml": true, "text/yaml": true,
+	"text/x-yaml": true, "application/vnd.oai.openapi": true, "application/vnd.oai.openapi+json": true,
+}
+
+// Operations of a path item
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Components of OpenAPI 3 and top level sections of Swagger 2 with described items, schemas aside
+var componentSections = []string{"parameters", "responses", "requestBodies", "headers", "securitySchemes"}
+
+// File may be a spec by its name, url or media type, its content tells for sure
+func IsCandidate(name, contentType string) bool {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil && mediaTypes[mediaType] {
+		return true
+	}
+
+	if i := strings.IndexAny(name, "?#"); i >= 0 {
+		name = name[:i]
+	}
+
+	return extensions[strings.ToLower(path.Ext(name))]
+}
+
+// Parse OpenAPI 3 or Swagger 2 document in JSON or YAML into an article of its prose in document order:
+// the api description, tag descriptions, summaries and descriptions of operations with their parameters
+// and responses, and descriptions of schemas with their properties. Blocks of an operation are located
+// by its operation id, or by its method and path without one, other blocks by their JSON pointer like
+// "#/components/schemas/Pet/properties/name". Descriptions are parsed as markdown. The title is the api title.
+func Parse(source []byte) (*extractor.Article, error) {
+	const op = "pkg.openapi.parse"
+
+	root := &yaml.Node{}
+	if err := yaml.Unmarshal(source, root); err != nil {
+		return nil, fmt.Errorf("%s: %w: %v", op, ErrNotSpec, err)
+	}
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrNotSpec)
+	}
+	spec := root.Content[0]
+	if spec.Kind != yaml.MappingNode || get(spec, "openapi") == nil && get(spec, "swagger") == nil {
+		return nil, fmt.Errorf("%s: %w", op, ErrNotSpec)
+	}
+
+	b := &builder{}
+	info := get(spec, "info")
+	b.location = "#/info"
+	b.heading(1, text(info, "title"))
+	b.paragraph(text(info, "summary"))
+	b.description(text(info, "description"))
+
+	for i, tag := range items(get(spec, "tags")) {
+		b.location = "#/tags/" + strconv.Itoa(i)
+		b.item(text(tag, "name"), text(tag, "description"))
+	}
+
+	for _, p := range pairs(get(spec, "paths")) {
+		b.pathItem(p.key, p.value)
+	}
+
+	for _, p := range pairs(get(spec, "definitions")) {
+		b.schema("#/definitions/"+escape(p.key), p.key, p.value)
+	}
+	components := get(spec, "components")
+	for _, p := range pairs(get(components, "schemas")) {
+		b.schema("#/components/schemas/"+escape(p.key), p.key, p.value)
+	}
+	for _, section := range componentSections {
+		b.components("#/components/"+section, get(components, section))
+		b.components("#/"+section, get(spec, section))
+	}
+	b.components("#/securityDefinitions", get(spec, "securityDefinitions"))
+
+	if len(b.blocks) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, extractor.ErrNoContent)
+	}
+
+	return &extractor.Article{Title: text(info, "title"), Layout: Layout, Blocks: b.blocks}, nil
+}
+
+// Collects blocks located at the current operation or schema
+type builder struct {
+	blocks   []extractor.Block
+	location string
+}
+
+func (b *builder) add(block extractor.Block) {
+	if strings.TrimSpace(block.Text) == "" {
+		return
+	}
+	block.Location = b.location
+	b.blocks = append(b.blocks, block)
+}
+
+func (b *builder) heading(level int, text string) {
+	b.add(extractor.Block{Kind: extractor.BlockHeading, Level: level, Text: text})
+}
+
+func (b *builder) paragraph(text string) {
+	b.add(extractor.Block{Kind: extractor.BlockParagraph, Text: strings.Join(strings.Fields(text), " ")})
+}
+
+// Blocks of a markdown description, its headings are below headings of the document
+func (b *builder) description(text string) {
+	for _, block := range markdown(text) {
+		if block.Kind == extractor.BlockHeading {
+			block.Level = min(block.Level+2, 6)
+		}
+		b.add(block)
+	}
+}
+
+// List item of a named parameter, property or response starting with the name in backticks,
+// further blocks of its description follow the item
+func (b *builder) item(name, text string) {
+	blocks := markdown(text)
+	if len(blocks) == 0 {
+		return
+	}
+
+	first := extractor.Block{Kind: extractor.BlockListItem, Text: "`" + name + "`"}
+	if blocks[0].Kind == extractor.BlockParagraph {
+		first.Text += ": " + blocks[0].Text
+		blocks = blocks[1:]
+	}
+	b.add(first)
+	for _, block := range blocks {
+		b.add(block)
+	}
+}
+
+// Operations of a path with parameters shared by them
+func (b *builder) pathItem(pathName string, item *yaml.Node) {
+	shared := items(get(item, "parameters"))
+	for _, method := range methods {
+		operation := get(item, method)
+		if operation == nil {
+			continue
+		}
+
+		b.location = text(operation, "operationId")
+		if b.location == "" {
+			b.location = strings.ToUpper(method) + " " + pathName
+		}
+		b.heading(2, "`"+strings.ToUpper(method)+" "+pathName+"`")
+		b.paragraph(text(operation, "summary"))
+		b.description(text(operation, "description"))
+
+		for _, parameter := range append(shared, items(get(operation, "parameters"))...) {
+			b.item(text(parameter, "name"), text(parameter, "description"))
+		}
+		b.description(text(get(operation, "requestBody"), "description"))
+		for _, p := range pairs(get(operation, "responses")) {
+			b.item(p.key, text(p.value, "description"))
+		}
+	}
+}
+
+// Schema with a heading and descriptions of its properties, schemas without descriptions are left out
+func (b *builder) schema(pointer, name string, schema *yaml.Node) {
+	b.location = pointer
+	b.heading(2, "`"+name+"`")
+	start := len(b.blocks)
+	b.paragraph(text(schema, "title"))
+	b.description(text(schema, "description"))
+	b.members(pointer, schema, 0)
+
+	if len(b.blocks) == start {
+		b.blocks = b.blocks[:start-1]
+	}
+}
+
+// Descriptions of properties, items and combined schemas of a schema, each located by its pointer
+func (b *builder) members(pointer string, schema *yaml.Node, depth int) {
+	if schema == nil || depth >= maxSchemaDepth {
+		return
+	}
+
+	for _, p := range pairs(get(schema, "properties")) {
+		b.location = pointer + "/properties/" + escape(p.key)
+		b.item(p.key, text(p.value, "description"))
+		b.members(pointer+"/properties/"+escape(p.key), p.value, depth+1)
+	}
+	for _, key := range []string{"allOf", "oneOf", "anyOf"} {
+		for i, sub := range items(get(schema, key)) {
+			b.location = pointer + "/" + key + "/" + strconv.Itoa(i)
+			b.description(text(sub, "description"))
+			b.members(b.location, sub, depth+1)
+		}
+	}
+	if elements := get(schema, "items"); elements != nil && elements.Kind == yaml.MappingNode {
+		b.location = pointer + "/items"
+		b.description(text(elements, "description"))
+		b.members(pointer+"/items", elements, depth+1)
+	}
+	if additional := get(schema, "additionalProperties"); additional != nil && additional.Kind == yaml.MappingNode {
+		b.members(pointer+"/additionalProperties", additional, depth+1)
+	}
+}
+
+// Described items of a components section, each located by its pointer
+func (b *builder) components(pointer string, section *yaml.Node) {
+	for _, p := range pairs(section) {
+		b.location = pointer + "/" + escape(p.key)
+		b.item(p.key, text(p.value, "description"))
+		b.members(b.location+"/schema", get(p.value, "schema"), 0)
+	}
+}
+
+// Blocks of markdown text, none for empty text
+func markdown(text string) []extractor.Block {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	article, err := markup.Parse(markup.FormatMarkdown, []byte(text))
+	if err != nil {
+		return nil
+	}
+
+	return article.Blocks
+}
+
+type pair struct {
+	key   string
+	value *yaml.Node
+}
+
+// Entries of a mapping node in document order, extensions are left out
+func pairs(n *yaml.Node) []pair {
+	n = resolve(n)
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	result := make([]pair, 0, len(n.Content)/2)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if key := n.Content[i].Value; !strings.HasPrefix(key, "x-") {
+			result = append(result, pair{key: key, value: resolve(n.Content[i+1])})
+		}
+	}
+
+	return result
+}
+
+// Elements of a sequence node
+func items(n *yaml.Node) []*yaml.Node {
+	n = resolve(n)
+	if n == nil || n.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	result := make([]*yaml.Node, 0, len(n.Content))
+	for _, item := range n.Content {
+		result = append(result, resolve(item))
+	}
+
+	return result
+}
+
+// Value of a key of a mapping node, nil if there is no such key
+func get(n *yaml.Node, key string) *yaml.Node {
+	n = resolve(n)
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return resolve(n.Content[i+1])
+		}
+	}
+
+	return nil
+}
+
+// Scalar value of a key of a mapping node
+func text(n *yaml.Node, key string) string {
+	if value := get(n, key); value != nil && value.Kind == yaml.ScalarNode {
+		return value.Value
+	}
+
+	return ""
+}
+
+func resolve(n *yaml.Node) *yaml.Node {
+	if n != nil && n.Kind == yaml.AliasNode {
+		return n.Alias
+	}
+
+	return n
+}
+
+// Escape a key as a JSON pointer token
+func escape(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,167 @@
+package openapi
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/extractor"
+)
+
+const openAPISource = `openapi: 3.0.3
+info:
+  title: Pet Store
+  summary: Sample   store.
+  description: |
+    Store of **pets**.
+
+    # Usage
+    Call the api.
+tags:
+  - name: pets
+    description: Everything about pets
+paths:
+  /pets/{id}:
+    x-internal: true
+    parameters:
+      - name: id
+        in: path
+        description: Id of the pet
+    get:
+      operationId: getPet
+      summary: Find a pet
+      responses:
+        "200":
+          description: The pet
+        "404":
+          description: ""
+    delete:
+      description: Remove a pet
+components:
+  schemas:
+    Pet:
+      description: A pet of the store
+      properties:
+        name:
+          description: Name of the pet
+        tags:
+          items:
+            description: Tag of the pet
+    Empty:
+      type: object
+  securitySchemes:
+    apiKey:
+      description: Key in the header
+`
+
+const swaggerSource = `{
+  "swagger": "2.0",
+  "info": {"title": "Legacy"},
+  "paths": {"/a~b/c": {"post": {"summary": "Create"}}},
+  "definitions": {"a/b": {"properties": {"c": {"description": "Property c"}}}}
+}`
+
+func TestParse(t *testing.T) {
+	block := func(kind extractor.BlockKind, level int, text, location string) extractor.Block {
+		return extractor.Block{Kind: kind, Level: level, Text: text, Location: location}
+	}
+
+	tests := []struct {
+		name   string
+		source string
+		want   *extractor.Article
+		err    error
+	}{
+		{
+			name:   "openapi 3 yaml",
+			source: openAPISource,
+			want: &extractor.Article{Title: "Pet Store", Layout: Layout, Blocks: []extractor.Block{
+				block(extractor.BlockHeading, 1, "Pet Store", "#/info"),
+				block(extractor.BlockParagraph, 0, "Sample store.", "#/info"),
+				block(extractor.BlockParagraph, 0, "Store of pets.", "#/info"),
+				block(extractor.BlockHeading, 3, "Usage", "#/info"),
+				block(extractor.BlockParagraph, 0, "Call the api.", "#/info"),
+				block(extractor.BlockListItem, 0, "`pets`: Everything about pets", "#/tags/0"),
+				block(extractor.BlockHeading, 2, "`GET /pets/{id}`", "getPet"),
+				block(extractor.BlockParagraph, 0, "Find a pet", "getPet"),
+				block(extractor.BlockListItem, 0, "`id`: Id of the pet", "getPet"),
+				block(extractor.BlockListItem, 0, "`200`: The pet", "getPet"),
+				block(extractor.BlockHeading, 2, "`DELETE /pets/{id}`", "DELETE /pets/{id}"),
+				block(extractor.BlockParagraph, 0, "Remove a pet", "DELETE /pets/{id}"),
+				block(extractor.BlockListItem, 0, "`id`: Id of the pet", "DELETE /pets/{id}"),
+				block(extractor.BlockHeading, 2, "`Pet`", "#/components/schemas/Pet"),
+				block(extractor.BlockParagraph, 0, "A pet of the store", "#/components/schemas/Pet"),
+				block(extractor.BlockListItem, 0, "`name`: Name of the pet",
+					"#/components/schemas/Pet/properties/name"),
+				block(extractor.BlockParagraph, 0, "Tag of the pet",
+					"#/components/schemas/Pet/properties/tags/items"),
+				block(extractor.BlockListItem, 0, "`apiKey`: Key in the header",
+					"#/components/securitySchemes/apiKey"),
+			}},
+		},
+		{
+			name:   "swagger 2 json",
+			source: swaggerSource,
+			want: &extractor.Article{Title: "Legacy", Layout: Layout, Blocks: []extractor.Block{
+				block(extractor.BlockHeading, 1, "Legacy", "#/info"),
+				block(extractor.BlockHeading, 2, "`POST /a~b/c`", "POST /a~b/c"),
+				block(extractor.BlockParagraph, 0, "Create", "POST /a~b/c"),
+				block(extractor.BlockHeading, 2, "`a/b`", "#/definitions/a~1b"),
+				block(extractor.BlockListItem, 0, "`c`: Property c", "#/definitions/a~1b/properties/c"),
+			}},
+		},
+		{
+			name:   "not a spec",
+			source: "name: app\nversion: 1\n",
+			err:    ErrNotSpec,
+		},
+		{
+			name:   "not yaml",
+			source: "{\"openapi\": ",
+			err:    ErrNotSpec,
+		},
+		{
+			name:   "scalar document",
+			source: "openapi",
+			err:    ErrNotSpec,
+		},
+		{
+			name:   "no prose",
+			source: "openapi: 3.0.0\ninfo:\n  version: 1.0.0\npaths: {}\n",
+			err:    extractor.ErrNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.source))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCandidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		want        bool
+	}{
+		{name: "openapi.yaml", want: true},
+		{name: "https://example.com/v2/swagger.JSON?raw=1", want: true},
+		{name: "https://example.com/spec", contentType: "application/vnd.oai.openapi;version=3.0", want: true},
+		{name: "https://example.com/spec", contentType: "application/json; charset=utf-8", want: true},
+		{name: "https://example.com/docs/", contentType: "text/html", want: false},
+		{name: "README.md", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsCandidate(tt.name, tt.contentType); got != tt.want {
+			t.Errorf("IsCandidate(%q, %q) = %v, want %v", tt.name, tt.contentType, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package phrases
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/tokenizer"
+)
+
+// Function words which can not be a part of a collocation
+var stopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "and": true, "or": true, "but": true, "nor": true, "so": true,
+	"if": true, "then": true, "else": true, "than": true, "as": true, "of": true, "to": true, "in": true,
+	"on": true, "at": true, "by": true, "for": true, "with": true, "from": true, "into": true, "onto": true,
+	"about": true, "over": true, "under": true, "up": true, "down": true, "out": true, "off": true,
+	"through": true, "between": true, "after": true, "before": true, "during": true, "without": true,
+	"within": true, "via": true, "per": true, "be": true, "have": true, "do": true, "will": true,
+	"would": true, "can": true, "could": true, "shall": true, "should": true, "may": true, "might": true,
+	"must": true, "not": true, "no": true, "yes": true, "i": true, "we": true, "you": true, "he": true,
+	"she": true, "it": true, "they": true, "me": true, "us": true, "him": true, "her": true, "them": true,
+	"my": true, "our": true, "your": true, "his": true, "its": true, "their": true, "this": true,
+	"that": true, "these": true, "those": true, "there": true, "here": true, "what": true, "which": true,
+	"who": true, "whom": true, "whose": true, "when": true, "where": true, "why": true, "how": true,
+	"all": true, "any": true, "each": true, "every": true, "some": true, "such": true, "other": true,
+	"more": true, "most": true, "less": true, "least": true, "many": true, "much": true, "few": true,
+	"also": true, "only": true, "just": true, "very": true, "too": true, "now": true, "again": true,
+	"once": true, "one": true, "two": true, "like": true, "e": true, "g": true,
+	"etc": true, "ie": true, "eg": true, "let": true, "because": true, "while": true, "until": true,
+	"both": true, "either": true, "neither": true, "own": true, "same": true, "whether": true,
+}
+
+// Function words allowed in the middle of a collocation: "point of view", "drag and drop"
+var linkWords = map[string]bool{"of": true, "and": true}
+
+// Occurrence counts of word sequences
+type ngramCounts struct {
+	// Sequences of two and three words
+	bigrams  map[string]int
+	trigrams map[string]int
+	// Words and pairs in positions of the sequences: first word, last word and so on
+	bigramFirst  map[string]int
+	bigramLast   map[string]int
+	trigramHead  map[string]int
+	trigramTail  map[string]int
+	trigramFirst map[string]int
+	trigramLast  map[string]int
+	bigramTotal  int
+	trigramTotal int
+}
+
+// Collocations significant across all texts by log-likelihood ratio. Pairs used only inside a longer
+// collocation are dropped: "dead letter queue" leaves out "letter queue".
+func findCollocations(texts []Text, options Options) map[string]bool {
+	counts := &ngramCounts{
+		bigrams: make(map[string]int), trigrams: make(map[string]int),
+		bigramFirst: make(map[string]int), bigramLast: make(map[string]int),
+		trigramHead: make(map[string]int), trigramTail: make(map[string]int),
+		trigramFirst: make(map[string]int), trigramLast: make(map[string]int),
+	}
+	for _, t := range texts {
+		counts.add(t.Content, t.Tokens)
+	}
+
+	minCount := max(options.MinCount, 2)
+	result := make(map[string]bool)
+
+	for trigram, count := range counts.trigrams {
+		if count < minCount || !candidate(strings.Fields(trigram)) {
+			continue
+		}
+		words := strings.Fields(trigram)
+		head, tail := words[0]+" "+words[1], words[1]+" "+words[2]
+
+		score := min(
+			logLikelihood(count, counts.trigramHead[head], counts.trigramLast[words[2]], counts.trigramTotal),
+			logLikelihood(count, counts.trigramFirst[words[0]], counts.trigramTail[tail], counts.trigramTotal),
+		)
+		if score >= options.MinScore {
+			result[trigram] = true
+		}
+	}
+
+	// Count of every pair inside accepted trigrams
+	inTrigrams := make(map[string]int)
+	for trigram := range result {
+		words := strings.Fields(trigram)
+		inTrigrams[words[0]+" "+words[1]] += counts.trigrams[trigram]
+		inTrigrams[words[1]+" "+words[2]] += counts.trigrams[trigram]
+	}
+
+	for bigram, count := range counts.bigrams {
+		if count < minCount || count <= inTrigrams[bigram] || !candidate(strings.Fields(bigram)) {
+			continue
+		}
+		first, last, _ := strings.Cut(bigram, " ")
+
+		score := logLikelihood(count, counts.bigramFirst[first], counts.bigramLast[last], counts.bigramTotal)
+		if score >= options.MinScore {
+			result[bigram] = true
+		}
+	}
+
+	return result
+}
+
+// Count word sequences of one sentence, code tokens break sequences
+func (c *ngramCounts) add(text string, tokens []tokenizer.Token) {
+	for i := 0; i+1 < len(tokens); i++ {
+		a, b := tokens[i], tokens[i+1]
+		if a.FromCode || b.FromCode || !joined(text, a, b) {
+			continue
+		}
+
+		c.bigrams[a.Lemma+" "+b.Lemma]++
+		c.bigramFirst[a.Lemma]++
+		c.bigramLast[b.Lemma]++
+		c.bigramTotal++
+
+		if i+2 >= len(tokens) || tokens[i+2].FromCode || !joined(text, b, tokens[i+2]) {
+			continue
+		}
+		d := tokens[i+2]
+
+		c.trigrams[a.Lemma+" "+b.Lemma+" "+d.Lemma]++
+		c.trigramHead[a.Lemma+" "+b.Lemma]++
+		c.trigramTail[b.Lemma+" "+d.Lemma]++
+		c.trigramFirst[a.Lemma]++
+		c.trigramLast[d.Lemma]++
+		c.trigramTotal++
+	}
+}
+
+// Words can form a collocation: content words with a link word in the middle of three words
+func candidate(words []string) bool {
+	for i, word := range words {
+		if len(word) < 2 || strings.ContainsAny(word, "'’") {
+			return false
+		}
+		if stopWords[word] && (i == 0 || i == len(words)-1 || !linkWords[word]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Dunning log-likelihood ratio of two parts used together k times, a and b times in their positions
+// out of n sequences. Parts used together less often than by chance score zero.
+func logLikelihood(k, a, b, n int) float64 {
+	k11 := float64(k)
+	k12 := float64(a - k)
+	k21 := float64(b - k)
+	k22 := float64(n - a - b + k)
+	if k12 < 0 || k21 < 0 || k22 < 0 || k11*float64(n) <= float64(a)*float64(b) {
+		return 0
+	}
+
+	rows := entropy(k11+k12, k21+k22)
+	columns := entropy(k11+k21, k12+k22)
+	matrix := entropy(k11, k12, k21, k22)
+	if rows+columns < matrix {
+		return 0
+	}
+
+	return 2 * (rows + columns - matrix)
+}
+
+func entropy(values ...float64) float64 {
+	sum, result := 0.0, 0.0
+	for _, v := range values {
+		result += xLogX(v)
+		sum += v
+	}
+
+	return xLogX(sum) - result
+}
+
+func xLogX(x float64) float64 {
+	if x == 0 {
+		return 0
+	}
+
+	return x * math.Log(x)
+}
+
+// Occurrences of collocations, longer collocations win over the pairs they contain. Words of phrasal verbs
+// are not a part of collocations.
+func collocationOccurrences(
+	text string, tokens []tokenizer.Token, collocations map[string]bool, verbs []Phrase,
+) []Phrase {
+	found := make([]Phrase, 0)
+	if len(collocations) == 0 {
+		return found
+	}
+
+	inVerb := make([]bool, len(tokens))
+	for _, verb := range verbs {
+		first := sort.Search(len(tokens), func(i int) bool { return tokens[i].Start >= verb.Start })
+		for i := first; i < len(tokens) && tokens[i].End <= verb.End; i++ {
+			inVerb[i] = true
+		}
+	}
+
+	for i := 0; i+1 < len(tokens); i++ {
+		for length := 3; length >= 2; length-- {
+			if i+length > len(tokens) {
+				continue
+			}
+
+			words := make([]string, 0, length)
+			ok := true
+			for j := i; j < i+length && ok; j++ {
+				ok = !tokens[j].FromCode && !inVerb[j] && (j == i || joined(text, tokens[j-1], tokens[j]))
+				words = append(words, tokens[j].Lemma)
+			}
+			if !ok || !collocations[strings.Join(words, " ")] {
+				continue
+			}
+
+			found = append(found, newPhrase(text, tokens[i], tokens[i+length-1], KindCollocation, words[0], words[1:]))
+			// Words of a found collocation do not start another one
+			i += length - 1
+			break
+		}
+	}
+
+	return found
+}
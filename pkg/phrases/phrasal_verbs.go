@@ -0,0 +1,115 @@
+package phrases
+
+import (
+	"bufio"
+	_ "embed"
+	"sort"
+	"strings"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/tokenizer"
+)
+
+//go:embed phrasal_verbs.txt
+var phrasalVerbsFile string
+
+// Verb lemma to particle sequences of its phrasal verbs, longest first
+var phrasalVerbs = loadPhrasalVerbs(phrasalVerbsFile)
+
+// Short objects which may separate a verb from its particle: pronouns alone, determiners before one word
+var (
+	objectPronouns = map[string]bool{
+		"it": true, "them": true, "him": true, "her": true, "me": true, "us": true, "you": true, "this": true,
+		"that": true, "these": true, "those": true, "everything": true, "something": true, "anything": true,
+	}
+	determiners = map[string]bool{
+		"the": true, "a": true, "an": true, "your": true, "my": true, "our": true, "their": true, "its": true,
+		"this": true, "that": true, "these": true, "those": true, "his": true, "her": true, "some": true,
+		"all": true, "any": true, "each": true, "every": true,
+	}
+)
+
+func loadPhrasalVerbs(data string) map[string][][]string {
+	result := make(map[string][][]string)
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(strings.ToLower(line))
+		if len(fields) < 2 {
+			continue
+		}
+		result[fields[0]] = append(result[fields[0]], fields[1:])
+	}
+
+	for _, particles := range result {
+		sort.SliceStable(particles, func(i, j int) bool { return len(particles[i]) > len(particles[j]) })
+	}
+
+	return result
+}
+
+// Find phrasal verbs, the longest verb matching at a token wins. Particles of two word verbs may follow
+// a pronoun or a determiner with one word: "set it up", "roll the change out".
+func findPhrasalVerbs(text string, tokens []tokenizer.Token) []Phrase {
+	found := make([]Phrase, 0)
+
+	for i, token := range tokens {
+		if token.FromCode {
+			continue
+		}
+
+		for _, particles := range phrasalVerbs[token.Lemma] {
+			last := matchParticles(text, tokens, i, particles, 0)
+			if last < 0 && len(particles) == 1 {
+				for gap := 1; gap <= 2 && last < 0; gap++ {
+					if isObject(tokens[i+1 : min(i+1+gap, len(tokens))]) {
+						last = matchParticles(text, tokens, i, particles, gap)
+					}
+				}
+			}
+			if last < 0 {
+				continue
+			}
+
+			found = append(found, newPhrase(text, tokens[i], tokens[last], KindPhrasalVerb, token.Lemma, particles))
+			break
+		}
+	}
+
+	return found
+}
+
+// Index of the last particle when particles follow the verb at i after gap tokens, otherwise -1
+func matchParticles(text string, tokens []tokenizer.Token, i int, particles []string, gap int) int {
+	last := i + gap + len(particles)
+	if last >= len(tokens) {
+		return -1
+	}
+
+	for j := i + 1; j <= last; j++ {
+		if tokens[j].FromCode || !joined(text, tokens[j-1], tokens[j]) {
+			return -1
+		}
+		if j > i+gap && tokens[j].Lemma != particles[j-i-gap-1] {
+			return -1
+		}
+	}
+
+	return last
+}
+
+// Tokens form a short object of a separable phrasal verb
+func isObject(tokens []tokenizer.Token) bool {
+	switch len(tokens) {
+	case 1:
+		return objectPronouns[tokens[0].Lemma]
+	case 2:
+		return determiners[tokens[0].Lemma] && !stopWords[tokens[1].Lemma]
+	}
+
+	return false
+}
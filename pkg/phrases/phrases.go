@@ -0,0 +1,87 @@
+package phrases
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/tokenizer"
+)
+
+const (
+	KindPhrasalVerb = "phrasal_verb"
+	KindCollocation = "collocation"
+)
+
+// Phrase occurrence in a text
+type Phrase struct {
+	// Lemmas of the words joined by spaces, objects inside phrasal verbs are left out
+	Lemma string
+	Kind  string
+	// Surface form as written in the text
+	Text string
+	// Byte offsets of the phrase in the text
+	Start int
+	End   int
+}
+
+// Text with its tokens in text order
+type Text struct {
+	Content string
+	Tokens  []tokenizer.Token
+}
+
+type Options struct {
+	// Collocations used less often in all texts together are skipped
+	MinCount int
+	// Lowest log-likelihood ratio of a collocation, 10.83 is the 0.001 significance level
+	MinScore float64
+}
+
+// Find phrasal verbs and collocations significant across all texts, returns occurrences in every text
+// in text order. Code tokens are never a part of a phrase.
+func Find(texts []Text, options Options) [][]Phrase {
+	collocations := findCollocations(texts, options)
+
+	result := make([][]Phrase, len(texts))
+	for i, t := range texts {
+		found := findPhrasalVerbs(t.Content, t.Tokens)
+		found = append(found, collocationOccurrences(t.Content, t.Tokens, collocations, found)...)
+
+		sort.SliceStable(found, func(i, j int) bool { return found[i].Start < found[j].Start })
+		result[i] = found
+	}
+
+	return result
+}
+
+// Phrase from the first to the last token with lemma made of the head and the following words
+func newPhrase(text string, first, last tokenizer.Token, kind string, head string, rest []string) Phrase {
+	return Phrase{
+		Lemma: head + " " + strings.Join(rest, " "),
+		Kind:  kind,
+		Text:  strings.Join(strings.Fields(text[first.Start:last.End]), " "),
+		Start: first.Start,
+		End:   last.End,
+	}
+}
+
+// Tokens follow each other in one sentence: only spaces and at most one line break are between them
+func joined(text string, a, b tokenizer.Token) bool {
+	if b.Start < a.End {
+		return false
+	}
+
+	breaks := 0
+	for _, r := range text[a.End:b.Start] {
+		switch r {
+		case ' ', '\t':
+		case '\n':
+			breaks++
+		case '\r':
+		default:
+			return false
+		}
+	}
+
+	return breaks <= 1
+}
@@ -0,0 +1,101 @@
+package srs
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	fsrsDecay = -0.5
+	// Makes retrievability equal to 0.9 when elapsed time equals stability
+	fsrsFactor = 19.0 / 81.0
+	// Difficulty of a card converted from another algorithm
+	fsrsDefaultDifficulty = 5
+)
+
+// Default FSRS-4.5 parameters optimized on a large collection of Anki reviews
+var fsrsWeights = [17]float64{
+	0.4872, 1.4003, 3.7145, 13.8206, 5.1618, 1.2298, 0.8975, 0.031, 1.6474, 0.1367, 1.0461, 2.1072, 0.0793,
+	0.3246, 1.587, 0.2272, 2.8755,
+}
+
+// Free Spaced Repetition Scheduler: memory is described by stability, the interval in days at which
+// recall probability falls to 90%, and difficulty from 1 to 10. Intervals are chosen to keep recall
+// probability at the requested retention.
+type fsrs struct {
+	retention float64
+}
+
+func NewFSRS(retention float64) Scheduler {
+	if retention <= 0 || retention >= 1 {
+		retention = 0.9
+	}
+
+	return &fsrs{retention: retention}
+}
+
+func (f *fsrs) Schedule(card Card, grade Grade, now time.Time) (Card, error) {
+	if !grade.Valid() {
+		return card, ErrInvalidGrade
+	}
+	w := fsrsWeights
+
+	switch {
+	case card.Repetitions == 0 && card.Stability == 0:
+		card.Stability = w[grade-1]
+		card.Difficulty = initialDifficulty(grade)
+	default:
+		if card.Stability == 0 {
+			// Card reviewed by another algorithm, its interval is the best guess of stability
+			card.Stability = max(card.Interval, w[Good-1])
+			card.Difficulty = fsrsDefaultDifficulty
+		}
+
+		elapsed := 0.0
+		if card.LastReview != nil {
+			elapsed = max(now.Sub(*card.LastReview).Hours()/24, 0)
+		}
+		retrievability := math.Pow(1+fsrsFactor*elapsed/card.Stability, fsrsDecay)
+
+		if grade == Again {
+			card.Stability = w[11] * math.Pow(card.Difficulty, -w[12]) * (math.Pow(card.Stability+1, w[13]) - 1) *
+				math.Exp(w[14]*(1-retrievability))
+		} else {
+			bonus := 1.0
+			switch grade {
+			case Hard:
+				bonus = w[15]
+			case Easy:
+				bonus = w[16]
+			}
+			card.Stability *= 1 + math.Exp(w[8])*(11-card.Difficulty)*math.Pow(card.Stability, -w[9])*
+				(math.Exp(w[10]*(1-retrievability))-1)*bonus
+		}
+
+		// Difficulty moves with the grade and reverts to the mean difficulty of a good answer
+		difficulty := card.Difficulty - w[6]*float64(grade-Good)
+		card.Difficulty = clamp(w[7]*initialDifficulty(Good)+(1-w[7])*difficulty, 1, 10)
+	}
+
+	if grade == Again {
+		if card.Repetitions > 0 {
+			card.Lapses++
+		}
+		card.Repetitions = 0
+	} else {
+		card.Repetitions++
+		card.Interval = math.Round(card.Stability / fsrsFactor * (math.Pow(f.retention, 1/fsrsDecay) - 1))
+	}
+
+	schedule(&card, grade, now)
+
+	return card, nil
+}
+
+func initialDifficulty(grade Grade) float64 {
+	return clamp(fsrsWeights[4]-float64(grade-Good)*fsrsWeights[5], 1, 10)
+}
+
+func clamp(value, low, high float64) float64 {
+	return min(max(value, low), high)
+}
@@ -0,0 +1,150 @@
+package srs
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFSRSScheduleNewCard(t *testing.T) {
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		retention   float64
+		grade       Grade
+		stability   float64
+		difficulty  float64
+		interval    float64
+		repetitions int
+		due         time.Time
+	}{
+		{
+			name: "again", retention: 0.9, grade: Again,
+			stability: fsrsWeights[0], difficulty: 7.6214, interval: 0,
+			due: now.Add(relearnDelay),
+		},
+		{
+			name: "hard", retention: 0.9, grade: Hard,
+			stability: fsrsWeights[1], difficulty: 6.3916, interval: 1, repetitions: 1,
+			due: now.Add(day),
+		},
+		{
+			name: "good", retention: 0.9, grade: Good,
+			stability: fsrsWeights[2], difficulty: 5.1618, interval: 4, repetitions: 1,
+			due: now.Add(4 * day),
+		},
+		{
+			name: "easy", retention: 0.9, grade: Easy,
+			stability: fsrsWeights[3], difficulty: 3.932, interval: 14, repetitions: 1,
+			due: now.Add(14 * day),
+		},
+		{
+			name: "lower retention gives longer interval", retention: 0.8, grade: Good,
+			stability: fsrsWeights[2], difficulty: 5.1618, interval: 9, repetitions: 1,
+			due: now.Add(9 * day),
+		},
+		{
+			name: "invalid retention falls back to default", retention: 1.5, grade: Good,
+			stability: fsrsWeights[2], difficulty: 5.1618, interval: 4, repetitions: 1,
+			due: now.Add(4 * day),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card, err := NewFSRS(tt.retention).Schedule(Card{}, tt.grade, now)
+			if err != nil {
+				t.Fatalf("Schedule() error = %v", err)
+			}
+			if math.Abs(card.Stability-tt.stability) > 1e-9 {
+				t.Errorf("Stability = %v, want %v", card.Stability, tt.stability)
+			}
+			if math.Abs(card.Difficulty-tt.difficulty) > 1e-9 {
+				t.Errorf("Difficulty = %v, want %v", card.Difficulty, tt.difficulty)
+			}
+			if card.Interval != tt.interval {
+				t.Errorf("Interval = %v, want %v", card.Interval, tt.interval)
+			}
+			if card.Repetitions != tt.repetitions {
+				t.Errorf("Repetitions = %v, want %v", card.Repetitions, tt.repetitions)
+			}
+			if !card.Due.Equal(tt.due) {
+				t.Errorf("Due = %v, want %v", card.Due, tt.due)
+			}
+		})
+	}
+}
+
+func TestFSRSScheduleReview(t *testing.T) {
+	lastReview := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	now := lastReview.Add(4 * day)
+	reviewed := Card{
+		Stability: 4, Difficulty: 5, Interval: 4, Repetitions: 1, Due: now, LastReview: &lastReview,
+	}
+
+	tests := []struct {
+		name  string
+		card  Card
+		grade Grade
+		// Stability grows on recall and falls on a lapse
+		grows      bool
+		lapses     int
+		difficulty func(float64) bool
+	}{
+		{
+			name: "again", card: reviewed, grade: Again, grows: false, lapses: 1,
+			difficulty: func(d float64) bool { return d > 5 },
+		},
+		{
+			name: "hard", card: reviewed, grade: Hard, grows: true,
+			difficulty: func(d float64) bool { return d > 5 },
+		},
+		{
+			name: "good", card: reviewed, grade: Good, grows: true,
+			difficulty: func(d float64) bool { return d > 5 && d < 5.1 },
+		},
+		{
+			name: "easy", card: reviewed, grade: Easy, grows: true,
+			difficulty: func(d float64) bool { return d < 5 },
+		},
+		{
+			name: "card of another algorithm", grade: Good, grows: true,
+			card:       Card{Ease: 2.5, Interval: 15, Repetitions: 3, Due: now, LastReview: &lastReview},
+			difficulty: func(d float64) bool { return d > 5 && d < 5.1 },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card, err := NewFSRS(0.9).Schedule(tt.card, tt.grade, now)
+			if err != nil {
+				t.Fatalf("Schedule() error = %v", err)
+			}
+
+			stability := max(tt.card.Stability, tt.card.Interval)
+			if grows := card.Stability > stability; grows != tt.grows {
+				t.Errorf("Stability = %v after %v, want grows = %v", card.Stability, stability, tt.grows)
+			}
+			if !tt.difficulty(card.Difficulty) {
+				t.Errorf("Difficulty = %v is unexpected", card.Difficulty)
+			}
+			if card.Difficulty < 1 || card.Difficulty > 10 {
+				t.Errorf("Difficulty = %v is out of range", card.Difficulty)
+			}
+			if card.Lapses != tt.lapses {
+				t.Errorf("Lapses = %v, want %v", card.Lapses, tt.lapses)
+			}
+			if tt.grade != Again && card.Interval != math.Round(card.Stability) {
+				t.Errorf("Interval = %v, want stability %v at 90%% retention", card.Interval, card.Stability)
+			}
+		})
+	}
+}
+
+func TestFSRSScheduleInvalidGrade(t *testing.T) {
+	if _, err := NewFSRS(0.9).Schedule(Card{}, 0, time.Now()); !errors.Is(err, ErrInvalidGrade) {
+		t.Errorf("Schedule() error = %v, want %v", err, ErrInvalidGrade)
+	}
+}
@@ -0,0 +1,53 @@
+package srs
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	sm2InitialEase = 2.5
+	sm2MinEase     = 1.3
+)
+
+// SuperMemo 2 algorithm: intervals of 1 and 6 days, then the previous interval multiplied by the ease
+// factor which changes with every answer
+type sm2 struct{}
+
+func NewSM2() Scheduler {
+	return sm2{}
+}
+
+func (sm2) Schedule(card Card, grade Grade, now time.Time) (Card, error) {
+	if !grade.Valid() {
+		return card, ErrInvalidGrade
+	}
+	if card.Ease == 0 {
+		card.Ease = sm2InitialEase
+	}
+
+	// Grades map to SM-2 answer quality: again is a failed answer, hard, good and easy are correct ones
+	quality := float64(grade) + 1
+	card.Ease = max(card.Ease+0.1-(5-quality)*(0.08+(5-quality)*0.02), sm2MinEase)
+
+	switch {
+	case grade == Again:
+		if card.Repetitions > 0 {
+			card.Lapses++
+		}
+		card.Repetitions = 0
+	case card.Repetitions == 0:
+		card.Repetitions = 1
+		card.Interval = 1
+	case card.Repetitions == 1:
+		card.Repetitions = 2
+		card.Interval = 6
+	default:
+		card.Repetitions++
+		card.Interval = math.Round(card.Interval * card.Ease)
+	}
+
+	schedule(&card, grade, now)
+
+	return card, nil
+}
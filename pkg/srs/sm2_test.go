@@ -0,0 +1,118 @@
+package srs
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSM2Schedule(t *testing.T) {
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		card        Card
+		grade       Grade
+		ease        float64
+		interval    float64
+		repetitions int
+		lapses      int
+		due         time.Time
+	}{
+		{
+			name:  "new card answered good",
+			card:  Card{},
+			grade: Good, ease: 2.5, interval: 1, repetitions: 1,
+			due: now.Add(day),
+		},
+		{
+			name:  "second answer",
+			card:  Card{Ease: 2.5, Interval: 1, Repetitions: 1},
+			grade: Good, ease: 2.5, interval: 6, repetitions: 2,
+			due: now.Add(6 * day),
+		},
+		{
+			name:  "interval multiplied by ease",
+			card:  Card{Ease: 2.5, Interval: 6, Repetitions: 2},
+			grade: Good, ease: 2.5, interval: 15, repetitions: 3,
+			due: now.Add(15 * day),
+		},
+		{
+			name:  "easy answer raises ease",
+			card:  Card{Ease: 2.5, Interval: 6, Repetitions: 2},
+			grade: Easy, ease: 2.6, interval: 16, repetitions: 3,
+			due: now.Add(16 * day),
+		},
+		{
+			name:  "hard answer lowers ease",
+			card:  Card{Ease: 2.5, Interval: 6, Repetitions: 2},
+			grade: Hard, ease: 2.36, interval: 14, repetitions: 3,
+			due: now.Add(14 * day),
+		},
+		{
+			name:  "forgotten card is relearned",
+			card:  Card{Ease: 2.5, Interval: 15, Repetitions: 3},
+			grade: Again, ease: 2.18, interval: 0, lapses: 1,
+			due: now.Add(relearnDelay),
+		},
+		{
+			name:  "forgotten new card is not a lapse",
+			card:  Card{},
+			grade: Again, ease: 2.18, interval: 0,
+			due: now.Add(relearnDelay),
+		},
+		{
+			name:  "ease does not fall below minimum",
+			card:  Card{Ease: sm2MinEase, Interval: 3, Repetitions: 4, Lapses: 2},
+			grade: Again, ease: sm2MinEase, interval: 0, lapses: 3,
+			due: now.Add(relearnDelay),
+		},
+		{
+			name:  "interval is capped",
+			card:  Card{Ease: 2.5, Interval: 30000, Repetitions: 10},
+			grade: Good, ease: 2.5, interval: maxInterval, repetitions: 11,
+			due: now.Add(maxInterval * day),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card, err := NewSM2().Schedule(tt.card, tt.grade, now)
+			if err != nil {
+				t.Fatalf("Schedule() error = %v", err)
+			}
+			if math.Abs(card.Ease-tt.ease) > 1e-9 {
+				t.Errorf("Ease = %v, want %v", card.Ease, tt.ease)
+			}
+			if card.Interval != tt.interval {
+				t.Errorf("Interval = %v, want %v", card.Interval, tt.interval)
+			}
+			if card.Repetitions != tt.repetitions {
+				t.Errorf("Repetitions = %v, want %v", card.Repetitions, tt.repetitions)
+			}
+			if card.Lapses != tt.lapses {
+				t.Errorf("Lapses = %v, want %v", card.Lapses, tt.lapses)
+			}
+			if !card.Due.Equal(tt.due) {
+				t.Errorf("Due = %v, want %v", card.Due, tt.due)
+			}
+			if card.LastReview == nil || !card.LastReview.Equal(now) {
+				t.Errorf("LastReview = %v, want %v", card.LastReview, now)
+			}
+		})
+	}
+}
+
+func TestSM2ScheduleInvalidGrade(t *testing.T) {
+	for _, grade := range []Grade{0, 5, -1} {
+		card := Card{Ease: 2.5, Interval: 6, Repetitions: 2}
+		got, err := NewSM2().Schedule(card, grade, time.Now())
+		if !errors.Is(err, ErrInvalidGrade) {
+			t.Errorf("Schedule(%d) error = %v, want %v", grade, err, ErrInvalidGrade)
+		}
+		if got != card {
+			t.Errorf("Schedule(%d) changed card to %+v", grade, got)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package srs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Answer quality given by the learner
+type Grade int
+
+const (
+	// Answer was forgotten, the card is shown again the same day
+	Again Grade = iota + 1
+	// Answer was recalled with serious difficulty
+	Hard
+	// Answer was recalled after some hesitation
+	Good
+	// Answer was recalled without effort
+	Easy
+)
+
+const (
+	AlgorithmSM2  = "sm2"
+	AlgorithmFSRS = "fsrs"
+)
+
+const (
+	// Delay before a forgotten card is shown again
+	relearnDelay = 10 * time.Minute
+	// Longest interval in days, about a hundred years
+	maxInterval = 36500
+	day         = 24 * time.Hour
+)
+
+var (
+	ErrUnknownAlgorithm = errors.New("unknown scheduling algorithm")
+	ErrInvalidGrade     = errors.New("grade must be from 1 to 4")
+)
+
+// Card state kept between reviews. SM-2 uses Ease, FSRS uses Stability and Difficulty, both keep
+// the rest, so a card can be moved from one algorithm to another.
+type Card struct {
+	Ease       float64
+	Stability  float64
+	Difficulty float64
+	// Days until the next review
+	Interval    float64
+	Repetitions int
+	Lapses      int
+	Due         time.Time
+	LastReview  *time.Time
+}
+
+// Scheduler computes card state after a review
+type Scheduler interface {
+	Schedule(card Card, grade Grade, now time.Time) (Card, error)
+}
+
+// Scheduler by algorithm name, retention is the probability of recall at the due date used by FSRS
+func New(algorithm string, retention float64) (Scheduler, error) {
+	switch algorithm {
+	case AlgorithmSM2:
+		return NewSM2(), nil
+	case AlgorithmFSRS:
+		return NewFSRS(retention), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAlgorithm, algorithm)
+	}
+}
+
+func (g Grade) Valid() bool {
+	return g >= Again && g <= Easy
+}
+
+// Set due date of the card after a review
+func schedule(card *Card, grade Grade, now time.Time) {
+	card.LastReview = &now
+	if grade == Again {
+		card.Interval = 0
+		card.Due = now.Add(relearnDelay)
+		return
+	}
+
+	card.Interval = min(max(card.Interval, 1), maxInterval)
+	card.Due = now.Add(time.Duration(card.Interval * float64(day)))
+}
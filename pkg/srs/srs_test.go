@@ -0,0 +1,34 @@
+package srs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		want      Scheduler
+		err       error
+	}{
+		{algorithm: AlgorithmSM2, want: sm2{}},
+		{algorithm: AlgorithmFSRS, want: &fsrs{retention: 0.85}},
+		{algorithm: "leitner", err: ErrUnknownAlgorithm},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			got, err := New(tt.algorithm, 0.85)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("New() error = %v, want %v", err, tt.err)
+			}
+			if f, ok := got.(*fsrs); ok {
+				if *f != *tt.want.(*fsrs) {
+					t.Errorf("New() = %+v, want %+v", f, tt.want)
+				}
+			} else if got != tt.want {
+				t.Errorf("New() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package subtitles
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"mime"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/extractor"
+)
+
+const (
+	FormatSRT    = "srt"
+	FormatWebVTT = "vtt"
+)
+
+const (
+	// Silence between cues which ends a paragraph after a finished sentence
+	paragraphPause = 2 * time.Second
+	// Length of a paragraph in bytes after which the next finished sentence ends it
+	maxParagraphLength = 1000
+	// Length of a paragraph without sentence ends after which the next cue starts a new one, automatic
+	// captions have no punctuation and their paragraphs would be too long for examples
+	maxUnpunctuatedLength = 250
+)
+
+var ErrUnknownFormat = errors.New("unknown subtitle format")
+
+// File extensions of supported formats
+var extensions = map[string]string{
+	".srt": FormatSRT,
+	".vtt": FormatWebVTT,
+}
+
+// Media types of supported formats
+var mediaTypes = map[string]string{
+	"application/x-subrip": FormatSRT,
+	"text/srt":             FormatSRT,
+	"text/vtt":             FormatWebVTT,
+}
+
+var (
+	blankLineRe = regexp.MustCompile(`\n[ \t]*\n`)
+	timingRe    = regexp.MustCompile(`^\s*(\S+)\s+-->\s+(\S+)`)
+	timestampRe = regexp.MustCompile(`^(?:(\d+):)?(\d{1,2}):(\d{2})[,.](\d{1,3})$`)
+	// Markup of cue text: <i>, <v Speaker>, <c.class>, karaoke timestamps and {\an8} positions of SRT
+	tagRe = regexp.MustCompile(`<[^>]*>|\{\\[^}]*\}`)
+	// Descriptions of sounds for the hard of hearing: [Music], (applause)
+	soundRe = regexp.MustCompile(`\[[^\]]*\]|^\([^)]*\)$|♪[^♪]*♪?`)
+	// Speaker labels of captions: "JOHN:", ">> ANNA:"
+	speakerRe = regexp.MustCompile(`^(?:>>\s*)?(?:[A-Z][A-Z0-9 .'-]*:\s+|>>\s*)`)
+	// Dialogue dashes at line starts
+	dashRe = regexp.MustCompile(`^[-–—]\s*`)
+	// Speaker of a WebVTT voice span
+	voiceRe = regexp.MustCompile(`<v(?:\.[\w.-]+)?\s+([^>]+)>`)
+)
+
+// Timed text of one subtitle
+type cue struct {
+	start time.Duration
+	end   time.Duration
+	// Speaker of a WebVTT voice span
+	voice string
+	text  string
+}
+
+// Format of a file by its media type or by its name or url, empty for unsupported files
+func FormatOf(name, contentType string) string {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil && mediaTypes[mediaType] != "" {
+		return mediaTypes[mediaType]
+	}
+
+	if i := strings.IndexAny(name, "?#"); i >= 0 {
+		name = name[:i]
+	}
+
+	return extensions[strings.ToLower(path.Ext(name))]
+}
+
+// Parse SRT or WebVTT subtitles into an article of paragraphs. Cues are joined into paragraphs which end
+// at a pause after a finished sentence or at a change of speaker, so sentences split across cues are whole,
+// captions without punctuation are cut into short paragraphs.
+// Every cue start is a mark of its paragraph labeled with the timestamp, "00:01:23.456", so examples keep
+// the moment of the video they are said at. Markup, sound descriptions and speaker labels are dropped and
+// lines repeated by rolling captions are taken once. The title is the one of the WebVTT header.
+func Parse(format string, source []byte) (*extractor.Article, error) {
+	const op = "pkg.subtitles.parse"
+
+	text := strings.ReplaceAll(string(source), "\r\n", "\n")
+	text = strings.ReplaceAll(strings.TrimPrefix(text, "\ufeff"), "\r", "\n")
+
+	article := &extractor.Article{Layout: format}
+	switch format {
+	case FormatSRT:
+	case FormatWebVTT:
+		header, _, _ := strings.Cut(text, "\n")
+		if !strings.HasPrefix(header, "WEBVTT") {
+			return nil, fmt.Errorf("%s: %w: missing WEBVTT header", op, ErrUnknownFormat)
+		}
+		article.Title = strings.TrimLeft(strings.TrimPrefix(header, "WEBVTT"), " \t-")
+	default:
+		return nil, fmt.Errorf("%s: %w: %s", op, ErrUnknownFormat, format)
+	}
+
+	if article.Blocks = paragraphs(parseCues(text)); len(article.Blocks) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, extractor.ErrNoContent)
+	}
+
+	return article, nil
+}
+
+// Cues of SRT and WebVTT files: blocks separated by blank lines with a timing line, SRT numbers and
+// WebVTT identifiers before the timing line are skipped as well as WebVTT header, notes and styles
+func parseCues(text string) []cue {
+	cues := make([]cue, 0)
+	last, voice := "", ""
+	for _, block := range blankLineRe.Split(text, -1) {
+		lines := strings.Split(strings.Trim(block, "\n"), "\n")
+
+		timing := -1
+		for i, line := range lines[:min(len(lines), 3)] {
+			if timingRe.MatchString(line) {
+				timing = i
+				break
+			}
+		}
+		if timing < 0 {
+			continue
+		}
+
+		match := timingRe.FindStringSubmatch(lines[timing])
+		start, ok := parseTimestamp(match[1])
+		if !ok {
+			continue
+		}
+		end, _ := parseTimestamp(match[2])
+
+		// Cues without a voice span continue the speech of the last speaker
+		c := cue{start: start, end: end, voice: voice}
+		parts := make([]string, 0, len(lines)-timing-1)
+		for _, line := range lines[timing+1:] {
+			if match := voiceRe.FindStringSubmatch(line); match != nil {
+				c.voice = strings.TrimSpace(match[1])
+				voice = c.voice
+			}
+			line = cleanLine(line)
+			// Rolling captions repeat the previous line above the new one
+			if line == "" || line == last {
+				continue
+			}
+			parts = append(parts, line)
+			last = line
+		}
+		if c.text = strings.Join(parts, " "); c.text != "" {
+			cues = append(cues, c)
+		}
+	}
+
+	return cues
+}
+
+// Text of a cue line without markup, sound descriptions, speaker labels and dialogue dashes
+func cleanLine(line string) string {
+	line = html.UnescapeString(tagRe.ReplaceAllString(line, ""))
+	line = strings.Join(strings.Fields(line), " ")
+	line = soundRe.ReplaceAllString(line, "")
+	line = dashRe.ReplaceAllString(strings.TrimSpace(line), "")
+	line = speakerRe.ReplaceAllString(line, "")
+
+	return strings.TrimSpace(line)
+}
+
+// Join cues into paragraphs with a mark at the start of every cue
+func paragraphs(cues []cue) []extractor.Block {
+	blocks := make([]extractor.Block, 0)
+	var current *extractor.Block
+	for i, c := range cues {
+		if current != nil && breaksParagraph(current.Text, cues[i-1], c) {
+			blocks = append(blocks, *current)
+			current = nil
+		}
+		if current == nil {
+			current = &extractor.Block{Kind: extractor.BlockParagraph}
+		} else {
+			current.Text += " "
+		}
+
+		current.Marks = append(current.Marks, extractor.Location{
+			Start: utf8.RuneCountInString(current.Text), Label: formatTimestamp(c.start),
+		})
+		current.Text += c.text
+	}
+	if current != nil {
+		blocks = append(blocks, *current)
+	}
+
+	return blocks
+}
+
+// Another speaker of voice spans, a pause or a long paragraph after a finished sentence,
+// or a long paragraph without punctuation
+func breaksParagraph(paragraph string, previous, next cue) bool {
+	if previous.voice != next.voice {
+		return true
+	}
+	if !strings.ContainsAny(paragraph, ".!?") {
+		return len(paragraph) >= maxUnpunctuatedLength
+	}
+
+	finished := strings.ContainsAny(previous.text[len(previous.text)-1:], ".!?")
+
+	return finished && (next.start-previous.end >= paragraphPause || len(paragraph) >= maxParagraphLength)
+}
+
+// Timestamp of SRT, "01:02:03,456", or WebVTT, "01:02:03.456" or "02:03.456"
+func parseTimestamp(s string) (time.Duration, bool) {
+	match := timestampRe.FindStringSubmatch(s)
+	if match == nil {
+		return 0, false
+	}
+
+	hours, _ := strconv.Atoi(match[1])
+	minutes, _ := strconv.Atoi(match[2])
+	seconds, _ := strconv.Atoi(match[3])
+	millis, _ := strconv.Atoi((match[4] + "00")[:3])
+
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second + time.Duration(millis)*time.Millisecond, true
+}
+
+// Timestamp label of a cue: "00:01:23.456"
+func formatTimestamp(d time.Duration) string {
+	return fmt.Sprintf(
+		"%02d:%02d:%02d.%03d",
+		int(d/time.Hour), int(d/time.Minute)%60, int(d/time.Second)%60, int(d/time.Millisecond)%1000,
+	)
+}
@@ -0,0 +1,132 @@
+package subtitles
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shlembo598/text-lexicon-go/pkg/extractor"
+)
+
+const srtSource = "\ufeff1\r\n00:00:01,000 --> 00:00:02,500\r\n<i>Hello there,</i> my\r\n\r\n" +
+	"2\r\n00:00:02,600 --> 00:00:04,000\r\n{\\an8}friend. [Music]\r\n\r\n" +
+	"3\r\n00:00:07,000 --> 00:00:08,000\r\nJOHN: How &amp; why?\r\n- Because.\r\n\r\n" +
+	"4\r\n00:00:08,100 --> 00:00:09,000\r\n(applause)\r\n"
+
+const vttSource = "WEBVTT - Lecture one\n\n" +
+	"NOTE written by hand\n\n" +
+	"STYLE\n::cue { color: yellow }\n\n" +
+	"intro\n00:01.000 --> 00:02.000 align:start\n<v Anna>Welcome to the lecture\n\n" +
+	"00:02.100 --> 00:03.000\nWelcome to the lecture\nabout words.\n\n" +
+	"00:03.100 --> 00:04.000\n<v.loud Bob>Thanks, Anna.\n\n" +
+	"1:00:00.000 --> 1:00:01.000\n<c.yellow>We <00:00:00.500>start</c> now.\n"
+
+func TestParse(t *testing.T) {
+	paragraph := func(text string, marks ...extractor.Location) extractor.Block {
+		return extractor.Block{Kind: extractor.BlockParagraph, Text: text, Marks: marks}
+	}
+	mark := func(start int, label string) extractor.Location {
+		return extractor.Location{Start: start, Label: label}
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		source string
+		want   *extractor.Article
+		err    error
+	}{
+		{
+			name:   "srt",
+			format: FormatSRT,
+			source: srtSource,
+			want: &extractor.Article{Layout: FormatSRT, Blocks: []extractor.Block{
+				paragraph("Hello there, my friend.", mark(0, "00:00:01.000"), mark(16, "00:00:02.600")),
+				paragraph("How & why? Because.", mark(0, "00:00:07.000")),
+			}},
+		},
+		{
+			name:   "webvtt",
+			format: FormatWebVTT,
+			source: vttSource,
+			want: &extractor.Article{Title: "Lecture one", Layout: FormatWebVTT, Blocks: []extractor.Block{
+				paragraph("Welcome to the lecture about words.", mark(0, "00:00:01.000"), mark(23, "00:00:02.100")),
+				paragraph("Thanks, Anna.", mark(0, "00:00:03.100")),
+				paragraph("We start now.", mark(0, "01:00:00.000")),
+			}},
+		},
+		{
+			name:   "webvtt without header",
+			format: FormatWebVTT,
+			source: "00:01.000 --> 00:02.000\nText.\n",
+			err:    ErrUnknownFormat,
+		},
+		{
+			name:   "unknown format",
+			format: "ass",
+			source: srtSource,
+			err:    ErrUnknownFormat,
+		},
+		{
+			name:   "sounds only",
+			format: FormatSRT,
+			source: "1\n00:00:01,000 --> 00:00:02,000\n[Music]\n\n2\nbroken --> 00:00:03,000\nText.\n",
+			err:    extractor.ErrNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.format, []byte(tt.source))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseUnpunctuated(t *testing.T) {
+	// Automatic captions with lines of 22 bytes, 11 of them make a paragraph of 252 bytes
+	cues := make([]string, 0, 25)
+	for i := 0; i < 25; i++ {
+		cues = append(cues, fmt.Sprintf("00:00:%02d,000 --> 00:00:%02d,900\ncaption line number %02d", i, i, i))
+	}
+
+	article, err := Parse(FormatSRT, []byte(strings.Join(cues, "\n\n")))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	lengths := make([]int, 0, len(article.Blocks))
+	for _, b := range article.Blocks {
+		lengths = append(lengths, len(b.Marks))
+	}
+	if want := []int{11, 11, 3}; !reflect.DeepEqual(lengths, want) {
+		t.Errorf("Parse() cues of paragraphs = %v, want %v", lengths, want)
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		want        string
+	}{
+		{name: "movie.en.SRT", want: FormatSRT},
+		{name: "https://example.com/captions.vtt?lang=en", want: FormatWebVTT},
+		{name: "https://example.com/captions", contentType: "text/vtt; charset=utf-8", want: FormatWebVTT},
+		{name: "https://example.com/captions", contentType: "application/x-subrip", want: FormatSRT},
+		{name: "movie.ass", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := FormatOf(tt.name, tt.contentType); got != tt.want {
+			t.Errorf("FormatOf(%q, %q) = %q, want %q", tt.name, tt.contentType, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package textnorm
+
+import (
+	"bytes"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/transform"
+)
+
+const (
+	UTF8 = "utf-8"
+	// Fallback of legacy pages in western languages
+	Windows1252 = "windows-1252"
+)
+
+// Share of non-ASCII bytes next to other non-ASCII bytes above which a legacy text is taken as Cyrillic:
+// Cyrillic words are written with them only, accented letters of western languages stand among ASCII ones
+const cyrillicRunShare = 0.5
+
+// Single-byte Cyrillic charsets told apart by letter case
+var cyrillicCharsets = []struct {
+	name     string
+	encoding encoding.Encoding
+}{
+	{name: "windows-1251", encoding: charmap.Windows1251},
+	{name: "koi8-r", encoding: charmap.KOI8R},
+}
+
+// Charset of a text by its byte order mark, the charset of its media type, a <meta> or XML declaration of
+// html pages, and by its bytes: valid UTF-8 is UTF-8, other text is windows-1251 or KOI8-R when its letters
+// look Cyrillic and windows-1252 otherwise. Names are the ones of the WHATWG encoding standard.
+func Detect(body []byte, contentType string) string {
+	_, name, certain := charset.DetermineEncoding(body, contentType)
+	// Charsets of <meta> tags are not certain, but neither are the two fallbacks
+	if certain || name != UTF8 && name != Windows1252 {
+		return name
+	}
+	if utf8.Valid(body) {
+		return UTF8
+	}
+
+	return guessLegacy(body)
+}
+
+// Text transcoded to UTF-8 from its detected charset without a byte order mark and NUL characters. Bytes
+// invalid in the charset are replaced with U+FFFD.
+func ToUTF8(body []byte, contentType string) []byte {
+	name := Detect(body, contentType)
+	if name == UTF8 {
+		return clean(bytes.ToValidUTF8(body, []byte("\uFFFD")))
+	}
+
+	e, _ := charset.Lookup(name)
+	if e == nil {
+		return clean(bytes.ToValidUTF8(body, []byte("\uFFFD")))
+	}
+	decoded, _, err := transform.Bytes(e.NewDecoder(), body)
+	if err != nil {
+		return clean(bytes.ToValidUTF8(body, []byte("\uFFFD")))
+	}
+
+	return clean(decoded)
+}
+
+// UTF-8 text without a byte order mark and NUL characters, which Postgres can not store
+func clean(text []byte) []byte {
+	return bytes.ReplaceAll(bytes.TrimPrefix(text, []byte("\ufeff")), []byte{0}, nil)
+}
+
+// Single-byte charset of a text which is not UTF-8
+func guessLegacy(body []byte) string {
+	high, runs := 0, 0
+	for i, c := range body {
+		if c < 0x80 {
+			continue
+		}
+		high++
+		if i > 0 && body[i-1] >= 0x80 || i+1 < len(body) && body[i+1] >= 0x80 {
+			runs++
+		}
+	}
+	if high == 0 || float64(runs)/float64(high) <= cyrillicRunShare {
+		return Windows1252
+	}
+
+	best, bestScore := Windows1252, 0
+	for _, cs := range cyrillicCharsets {
+		if score := cyrillicScore(cs.encoding, body); score > bestScore {
+			best, bestScore = cs.name, score
+		}
+	}
+
+	return best
+}
+
+// Lower case Cyrillic letters less upper case ones inside words, the case of letters is swapped between
+// windows-1251 and KOI8-R and the wrong charset turns most letters of a text to upper case
+func cyrillicScore(e encoding.Encoding, body []byte) int {
+	decoded, err := e.NewDecoder().Bytes(body)
+	if err != nil {
+		return 0
+	}
+
+	score := 0
+	previous := ' '
+	for _, r := range string(decoded) {
+		switch {
+		case unicode.Is(unicode.Cyrillic, r) && unicode.IsLower(r):
+			score++
+		case unicode.Is(unicode.Cyrillic, r) && unicode.IsUpper(r) && unicode.IsLetter(previous):
+			score--
+		}
+		previous = r
+	}
+
+	return score
+}
@@ -0,0 +1,94 @@
+package textnorm
+
+import (
+	"bytes"
+	"testing"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+)
+
+const (
+	russian = "Съешь же ещё этих мягких французских булок, да выпей чаю."
+	french  = "Le cœur déçu mais l'âme plutôt naïve, Louÿs rêva de crapaüter."
+)
+
+func encode(t *testing.T, e encoding.Encoding, text string) []byte {
+	t.Helper()
+
+	encoded, err := e.NewEncoder().Bytes([]byte(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return encoded
+}
+
+func TestDetect(t *testing.T) {
+	utf16 := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM)
+
+	tests := []struct {
+		name        string
+		body        []byte
+		contentType string
+		want        string
+	}{
+		{name: "ascii", body: []byte("plain text"), want: UTF8},
+		{name: "utf-8", body: []byte(russian), want: UTF8},
+		{name: "utf-8 with bom", body: append([]byte("\ufeff"), french...), want: UTF8},
+		{name: "utf-16 with bom", body: encode(t, utf16, russian), want: "utf-16le"},
+		{name: "windows-1251", body: encode(t, charmap.Windows1251, russian), want: "windows-1251"},
+		{name: "koi8-r", body: encode(t, charmap.KOI8R, russian), want: "koi8-r"},
+		{name: "windows-1252", body: encode(t, charmap.Windows1252, french), want: Windows1252},
+		{
+			name: "charset of media type", body: encode(t, charmap.KOI8R, russian),
+			contentType: "text/plain; charset=windows-1251", want: "windows-1251",
+		},
+		{
+			name:        "meta tag",
+			body:        []byte(`<html><head><meta charset="koi8-r"></head><body>text</body></html>`),
+			contentType: "text/html", want: "koi8-r",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect(tt.body, tt.contentType); got != tt.want {
+				t.Errorf("Detect() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToUTF8(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        []byte
+		contentType string
+		want        string
+	}{
+		{name: "utf-8", body: []byte(russian), want: russian},
+		{name: "utf-8 with bom", body: append([]byte("\ufeff"), french...), want: french},
+		{
+			name: "utf-16 with bom",
+			body: encode(t, unicode.UTF16(unicode.BigEndian, unicode.UseBOM), russian), want: russian,
+		},
+		{name: "windows-1251", body: encode(t, charmap.Windows1251, russian), want: russian},
+		{name: "koi8-r", body: encode(t, charmap.KOI8R, russian), want: russian},
+		{name: "windows-1252", body: encode(t, charmap.Windows1252, french), want: french},
+		{name: "nul characters", body: []byte("nul\x00 byte\x00"), want: "nul byte"},
+		{
+			name: "invalid bytes of utf-8", body: []byte("caf\xc3 au lait"),
+			contentType: "text/plain; charset=utf-8", want: "caf� au lait",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToUTF8(tt.body, tt.contentType); !bytes.Equal(got, []byte(tt.want)) {
+				t.Errorf("ToUTF8() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
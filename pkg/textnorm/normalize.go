@@ -0,0 +1,28 @@
+package textnorm
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Typographic characters folded to plain ones: spaces to a space, quotes to ASCII quotes, hyphens, minus
+// and en dashes to a hyphen, other dashes to an em dash which stays a break between words, and ligatures
+// to their letters. Soft hyphens, zero width characters and NUL characters, which Postgres can not store,
+// are removed.
+var folding = strings.NewReplacer(
+	"\x00", "", "\u00ad", "", "\u200b", "", "\u2060", "", "\ufeff", "",
+	"\u00a0", " ", "\u2002", " ", "\u2003", " ", "\u2007", " ", "\u2009", " ", "\u200a", " ",
+	"\u202f", " ", "\u205f", " ", "\u3000", " ",
+	"‘", "'", "’", "'", "‚", "'", "‛", "'", "′", "'",
+	"“", `"`, "”", `"`, "„", `"`, "‟", `"`, "″", `"`, "«", `"`, "»", `"`,
+	"‐", "-", "‑", "-", "‒", "-", "–", "-", "−", "-",
+	"―", "—", "⸺", "—", "⸻", "—",
+	"ﬀ", "ff", "ﬁ", "fi", "ﬂ", "fl", "ﬃ", "ffi", "ﬄ", "ffl", "ﬅ", "st", "ﬆ", "st",
+)
+
+// Text in Unicode normalization form C with typographic characters folded, so words and sentences
+// are found the same way whatever typography the source has
+func Normalize(text string) string {
+	return folding.Replace(norm.NFC.String(text))
+}
@@ -0,0 +1,31 @@
+package textnorm
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "plain text", text: "plain text", want: "plain text"},
+		{name: "composed characters", text: "Cafe\u0301 nai\u0308ve", want: "Café naïve"},
+		{name: "spaces", text: "a\u00a0b\u2009c\u202fd\u3000e", want: "a b c d e"},
+		{name: "invisible characters", text: "in\u00adter\u200bna\u2060tio\ufeffnal", want: "international"},
+		{name: "nul characters", text: "nul\x00 byte\x00", want: "nul byte"},
+		{name: "single quotes", text: "‘quoted’ don’t", want: "'quoted' don't"},
+		{name: "double quotes", text: "“quoted” «guillemets»", want: `"quoted" "guillemets"`},
+		{name: "hyphens and dashes", text: "well‐known 1–2 −1", want: "well-known 1-2 -1"},
+		{name: "long dashes", text: "word―word⸺word", want: "word—word—word"},
+		{name: "em dash is kept", text: "word—word", want: "word—word"},
+		{name: "ligatures", text: "ﬁle ﬂow eﬃcient", want: "file flow efficient"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.text); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package tokenizer
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Part of a text which is either prose or code
+type segment struct {
+	start int
+	end   int
+	code  bool
+}
+
+// Split markdown-like text into prose and code, code is a fenced block or an inline backtick span
+func segments(text string) []segment {
+	result := make([]segment, 0)
+	proseStart := 0
+
+	addProse := func(end int) {
+		if end > proseStart {
+			result = append(result, proseSegments(text, proseStart, end)...)
+		}
+	}
+
+	for lineStart := 0; lineStart < len(text); {
+		lineEnd := lineEndAt(text, lineStart)
+
+		fence := fenceMarker(text[lineStart:lineEnd])
+		if fence == "" {
+			lineStart = nextLine(text, lineEnd)
+			continue
+		}
+
+		addProse(lineStart)
+
+		// Code runs until the closing fence line or the end of text
+		codeStart := nextLine(text, lineEnd)
+		codeEnd, blockEnd := len(text), len(text)
+		for l := codeStart; l < len(text); {
+			e := lineEndAt(text, l)
+			if closing := strings.TrimSpace(text[l:e]); strings.HasPrefix(closing, fence) &&
+				strings.Trim(closing, fence[:1]) == "" {
+				codeEnd, blockEnd = l, nextLine(text, e)
+				break
+			}
+			l = nextLine(text, e)
+		}
+
+		if codeEnd > codeStart {
+			result = append(result, segment{start: codeStart, end: codeEnd, code: true})
+		}
+		lineStart, proseStart = blockEnd, blockEnd
+	}
+	addProse(len(text))
+
+	return result
+}
+
+// Split prose into text and inline code spans, unmatched backticks are left in the text
+func proseSegments(text string, start, end int) []segment {
+	result := make([]segment, 0)
+	proseStart := start
+
+	for i := start; i < end; {
+		if text[i] != '`' {
+			i++
+			continue
+		}
+
+		ticks := i
+		for ticks < end && text[ticks] == '`' {
+			ticks++
+		}
+		marker := text[i:ticks]
+
+		closing := strings.Index(text[ticks:end], marker)
+		if closing < 0 {
+			i = ticks
+			continue
+		}
+
+		if i > proseStart {
+			result = append(result, segment{start: proseStart, end: i})
+		}
+		result = append(result, segment{start: ticks, end: ticks + closing, code: true})
+
+		i = ticks + closing + len(marker)
+		proseStart = i
+	}
+
+	if end > proseStart {
+		result = append(result, segment{start: proseStart, end: end})
+	}
+
+	return result
+}
+
+// Opening fence of a code block, three or more "`" or "~" indented by up to three spaces.
+// The block is closed by a line of the same characters at least as long as the opening fence.
+func fenceMarker(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 || trimmed == "" || (trimmed[0] != '`' && trimmed[0] != '~') {
+		return ""
+	}
+
+	fence := trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, trimmed[:1]))]
+	if len(fence) < 3 {
+		return ""
+	}
+
+	return fence
+}
+
+func lineEndAt(text string, start int) int {
+	if i := strings.IndexByte(text[start:], '\n'); i >= 0 {
+		return start + i
+	}
+
+	return len(text)
+}
+
+func nextLine(text string, lineEnd int) int {
+	if lineEnd < len(text) {
+		return lineEnd + 1
+	}
+
+	return lineEnd
+}
+
+// Check if word is a programming identifier rather than an english word:
+// snake_case, camelCase, PascalCase with inner capitals as in "HTTPServer" or words mixed with digits
+func isIdentifier(word string) bool {
+	if strings.ContainsRune(word, '_') {
+		return true
+	}
+
+	runes := []rune(word)
+	hasLetter, hasDigit := false, false
+	for i, r := range runes {
+		switch {
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsLetter(r):
+			hasLetter = true
+			if i > 0 && unicode.IsUpper(r) && unicode.IsLower(runes[i-1]) {
+				return true
+			}
+			// Plural abbreviations like "URLs" and "APIs" are still words
+			if i > 0 && i+1 < len(runes) && unicode.IsUpper(r) && unicode.IsUpper(runes[i-1]) &&
+				unicode.IsLower(runes[i+1]) && !(i+2 == len(runes) && runes[i+1] == 's') {
+				return true
+			}
+		}
+	}
+
+	return hasLetter && hasDigit
+}
+
+// Split identifier into words: "SetConnMaxLifetime" gives "Set", "Conn", "Max", "Lifetime",
+// "HTTPServer" gives "HTTP", "Server", "snake_case" and "kebab-case" are split on separators.
+// Returned offsets are relative to the identifier.
+func splitIdentifier(identifier string) []Token {
+	parts := make([]Token, 0)
+	start := -1
+
+	emit := func(end int) {
+		if start >= 0 && end > start {
+			parts = append(parts, Token{Text: identifier[start:end], Start: start, End: end})
+		}
+		start = -1
+	}
+
+	for i := 0; i < len(identifier); {
+		r, size := utf8.DecodeRuneInString(identifier[i:])
+
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			emit(i)
+			i += size
+			continue
+		}
+
+		if start >= 0 {
+			prev, _ := utf8.DecodeLastRuneInString(identifier[:i])
+			next, _ := utf8.DecodeRuneInString(identifier[i+size:])
+
+			switch {
+			// "maxOpen": lower to upper starts a new word
+			case unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+				emit(i)
+			// "HTTPServer": the last capital of an abbreviation starts the next word
+			case unicode.IsUpper(r) && unicode.IsUpper(prev) && unicode.IsLower(next):
+				emit(i)
+			// "utf8Decode", "v2": digits and letters are separate parts
+			case unicode.IsDigit(r) != unicode.IsDigit(prev):
+				emit(i)
+			}
+		}
+
+		if start < 0 {
+			start = i
+		}
+		i += size
+	}
+	emit(len(identifier))
+
+	return parts
+}
@@ -0,0 +1,213 @@
+package tokenizer
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+)
+
+//go:embed exceptions.txt
+var exceptionsFile string
+
+// Inflected form to lemma, lemmas map to themselves so suffix rules are not applied to them
+var exceptions = loadExceptions(exceptionsFile)
+
+func loadExceptions(data string) map[string]string {
+	result := make(map[string]string)
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		for _, form := range fields {
+			// The first mention wins, so ambiguous forms are resolved by the file order
+			if _, ok := result[form]; !ok {
+				result[form] = fields[0]
+			}
+		}
+	}
+
+	return result
+}
+
+// Lemma returns dictionary form of a lowercase english word
+func Lemma(word string) string {
+	if lemma, ok := exceptions[word]; ok {
+		return lemma
+	}
+
+	// Compounds only lose the plural ending, "plug-ins" becomes "plug-in" but "well-known" stays
+	if i := strings.LastIndexByte(word, '-'); i > 0 {
+		return word[:i+1] + nounStem(word[i+1:], 3)
+	}
+
+	if len(word) <= 3 {
+		return word
+	}
+
+	switch {
+	case strings.HasSuffix(word, "ies"):
+		if len(word) > 4 {
+			return word[:len(word)-3] + "y"
+		}
+		return word[:len(word)-1]
+	case strings.HasSuffix(word, "ied"):
+		if len(word) > 4 {
+			return word[:len(word)-3] + "y"
+		}
+		return word[:len(word)-1]
+	case strings.HasSuffix(word, "ings"):
+		// Plural of a verbal noun: "settings", "bindings", "warnings"
+		return word[:len(word)-1]
+	case strings.HasSuffix(word, "ing"):
+		stem := word[:len(word)-3]
+		if len(stem) < 2 || !hasVowel(stem) {
+			return word
+		}
+		return verbStem(stem)
+	case strings.HasSuffix(word, "eed"), strings.HasSuffix(word, "ued"):
+		return word[:len(word)-1]
+	case strings.HasSuffix(word, "ed"):
+		stem := word[:len(word)-2]
+		if len(stem) < 2 || !hasVowel(stem) {
+			return word
+		}
+		return verbStem(stem)
+	}
+
+	return nounStem(word, 4)
+}
+
+// Strip plural or third person ending from a word of at least minLength letters
+func nounStem(word string, minLength int) string {
+	if len(word) < minLength || !strings.HasSuffix(word, "s") {
+		return word
+	}
+
+	switch {
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"), strings.HasSuffix(word, "is"):
+		return word
+	case strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "ches"),
+		strings.HasSuffix(word, "shes"), strings.HasSuffix(word, "zzes"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		return word[:len(word)-3] + "y"
+	}
+
+	return word[:len(word)-1]
+}
+
+// Restore verb base from a stem left after removing -ed or -ing
+func verbStem(stem string) string {
+	n := len(stem)
+
+	// Doubled final consonant: "stopped", "logging", "committed"
+	if last := stem[n-1]; last == stem[n-2] && !isVowel(last) {
+		switch last {
+		case 'l', 's', 'z', 'f':
+			return stem
+		}
+		return stem[:n-1]
+	}
+
+	if needsE(stem) {
+		return stem + "e"
+	}
+
+	return stem
+}
+
+// Guess whether the base form ends with a silent "e" which was dropped by the suffix
+func needsE(stem string) bool {
+	n := len(stem)
+	last, prev := stem[n-1], stem[n-2]
+
+	// Letter before the last two, a vowel there means a long vowel digraph as in "treat" or "avoid",
+	// except for "u" after "q" which is read as a consonant in "require" or "acquire"
+	digraph := n >= 3 && isVowel(stem[n-3]) && !(stem[n-3] == 'u' && n >= 4 && stem[n-4] == 'q')
+
+	switch last {
+	case 'v', 'u':
+		return true
+	case 'z':
+		return prev != 'z'
+	case 'c':
+		return true
+	case 'g':
+		switch prev {
+		case 'r', 'd', 'l', 'a':
+			return true
+		case 'n':
+			return n >= 3 && (stem[n-3] == 'a' || stem[n-3] == 'e')
+		}
+	case 's':
+		switch prev {
+		case 'a', 'e', 'i', 'o', 'r', 'n', 'p', 'l':
+			return true
+		case 'u':
+			return n <= 3
+		}
+	case 't':
+		switch prev {
+		case 'a', 'u', 'o':
+			return !digraph
+		case 'i':
+			return n >= 3 && strings.ContainsRune("cvn", rune(stem[n-3]))
+		case 'e':
+			return strings.HasSuffix(stem, "let") || strings.HasSuffix(stem, "cret")
+		}
+	case 'd':
+		switch prev {
+		case 'a', 'i', 'o', 'u':
+			return !digraph
+		}
+	case 'r':
+		switch prev {
+		case 'a', 'i', 'o', 'u':
+			return !digraph
+		}
+	case 'n':
+		switch prev {
+		case 'i', 'u':
+			return !digraph
+		}
+	case 'm':
+		switch prev {
+		case 'a', 'i', 'u':
+			return !digraph
+		}
+	case 'l':
+		switch prev {
+		case 'b', 'p', 't', 'd', 'g', 'k', 'c', 'f', 'z':
+			return true
+		case 'i', 'u':
+			return !digraph
+		case 'a':
+			return n >= 3 && stem[n-3] == 'c'
+		}
+	case 'k', 'p', 'b':
+		switch prev {
+		case 'a', 'i', 'o', 'u', 'y':
+			return !digraph
+		}
+	}
+
+	return false
+}
+
+func isVowel(c byte) bool {
+	switch c {
+	case 'a', 'e', 'i', 'o', 'u':
+		return true
+	}
+
+	return false
+}
+
+func hasVowel(s string) bool {
+	return strings.ContainsAny(s, "aeiouy")
+}
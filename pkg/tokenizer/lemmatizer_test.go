@@ -0,0 +1,83 @@
+package tokenizer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLemma(t *testing.T) {
+	tests := []struct {
+		name string
+		word string
+		want string
+	}{
+		{name: "auxiliary verb", word: "was", want: "be"},
+		{name: "irregular verb", word: "went", want: "go"},
+		{name: "irregular plural", word: "children", want: "child"},
+		{name: "irregular plural", word: "mice", want: "mouse"},
+		{name: "short word", word: "its", want: "its"},
+		{name: "plural", word: "cats", want: "cat"},
+		{name: "plural after silent e", word: "values", want: "value"},
+		{name: "plural of -y", word: "studies", want: "study"},
+		{name: "plural of short -ie", word: "dies", want: "die"},
+		{name: "plural after sibilant", word: "classes", want: "class"},
+		{name: "plural after sibilant", word: "boxes", want: "box"},
+		{name: "plural after sibilant", word: "watches", want: "watch"},
+		{name: "plural after sibilant", word: "wishes", want: "wish"},
+		{name: "plural after sibilant", word: "buzzes", want: "buzz"},
+		{name: "singular ending with -us", word: "status", want: "status"},
+		{name: "singular ending with -is", word: "analysis", want: "analysis"},
+		{name: "plural of verbal noun", word: "settings", want: "setting"},
+		{name: "past of -y", word: "tried", want: "try"},
+		{name: "past of short -ie", word: "died", want: "die"},
+		{name: "past of -ee", word: "agreed", want: "agree"},
+		{name: "past of -ue", word: "queued", want: "queue"},
+		{name: "past", word: "deleted", want: "delete"},
+		{name: "past", word: "filled", want: "fill"},
+		{name: "past", word: "passed", want: "pass"},
+		{name: "past with doubled consonant", word: "stopped", want: "stop"},
+		{name: "past with doubled consonant", word: "committed", want: "commit"},
+		{name: "past with silent e", word: "hoped", want: "hope"},
+		{name: "past with silent e", word: "used", want: "use"},
+		{name: "past with silent e", word: "cached", want: "cache"},
+		{name: "past with silent e", word: "changed", want: "change"},
+		{name: "past with silent e", word: "parsed", want: "parse"},
+		{name: "past with silent e", word: "compiled", want: "compile"},
+		{name: "past with silent e", word: "configured", want: "configure"},
+		{name: "past with vowel digraph", word: "treated", want: "treat"},
+		{name: "past with vowel digraph", word: "avoided", want: "avoid"},
+		{name: "past with qu", word: "required", want: "require"},
+		{name: "gerund with doubled consonant", word: "running", want: "run"},
+		{name: "gerund with doubled consonant", word: "logging", want: "log"},
+		{name: "gerund with silent e", word: "making", want: "make"},
+		{name: "word ending with -ing", word: "ring", want: "ring"},
+		{name: "word ending with -ed", word: "bed", want: "bed"},
+		{name: "plural of compound", word: "plug-ins", want: "plug-in"},
+		{name: "compound adjective", word: "well-known", want: "well-known"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"/"+tt.word, func(t *testing.T) {
+			if got := Lemma(tt.word); got != tt.want {
+				t.Errorf("Lemma(%q) = %q, want %q", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadExceptions(t *testing.T) {
+	data := `# comment
+
+be am is are
+lie lay lain
+lay laid
+`
+	want := map[string]string{
+		"be": "be", "am": "be", "is": "be", "are": "be",
+		"lie": "lie", "lay": "lie", "lain": "lie", "laid": "lay",
+	}
+
+	if got := loadExceptions(data); !reflect.DeepEqual(got, want) {
+		t.Errorf("loadExceptions() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,224 @@
+package tokenizer
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Sentence of a text, offsets are in bytes
+type Sentence struct {
+	Text  string
+	Start int
+	End   int
+	// Index of the section the sentence belongs to, -1 before the first heading
+	Section int
+	// Sentence is the heading of its section
+	Heading bool
+}
+
+// Part of a markdown-like text started by a heading
+type Section struct {
+	Heading string
+	// Anchor of the heading as generated by GitHub and most documentation generators
+	Anchor string
+	Level  int
+	Start  int
+}
+
+// Abbreviations which do not end a sentence even when followed by a capital letter
+var abbreviations = map[string]bool{
+	"e.g": true, "i.e": true, "cf": true, "vs": true, "viz": true, "approx": true, "incl": true, "resp": true,
+	"mr": true, "mrs": true, "ms": true, "dr": true, "prof": true, "sr": true, "jr": true, "st": true,
+	"fig": true, "figs": true, "no": true, "nos": true, "vol": true, "p": true, "pp": true, "ch": true,
+	"sec": true, "ref": true, "eq": true, "al": true, "ca": true, "inc": true, "ltd": true, "co": true,
+	"corp": true, "jan": true, "feb": true, "mar": true, "apr": true, "jun": true, "jul": true, "aug": true,
+	"sep": true, "sept": true, "oct": true, "nov": true, "dec": true, "u.s": true, "a.k.a": true,
+}
+
+var (
+	headingRe = regexp.MustCompile(`^ {0,3}(#{1,6})\s+(.*?)\s*#*\s*$`)
+	// Markers which start a new block on their line: list items and quotes
+	blockMarkerRe = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)]|>)\s+`)
+	anchorDropRe  = regexp.MustCompile(`[^\p{L}\p{N}\s_-]`)
+)
+
+// Sentences splits markdown-like text into sentences. Blank lines, headings, list items and table rows
+// end sentences, fenced code blocks are skipped. Inside a paragraph a sentence ends with ".", "!" or "?"
+// followed by a space and a capital letter, except after abbreviations like "e.g." and initials,
+// dots inside version numbers, urls and file names never end a sentence.
+func Sentences(text string) ([]Sentence, []Section) {
+	sentences := make([]Sentence, 0)
+	sections := make([]Section, 0)
+
+	blockStart := -1
+	addBlock := func(end int) {
+		if blockStart >= 0 {
+			sentences = append(sentences, splitSentences(text, blockStart, end, len(sections)-1)...)
+		}
+		blockStart = -1
+	}
+
+	fence := ""
+	for lineStart := 0; lineStart < len(text); {
+		lineEnd := lineEndAt(text, lineStart)
+		line := text[lineStart:lineEnd]
+		next := nextLine(text, lineEnd)
+
+		if fence != "" {
+			if closing := strings.TrimSpace(line); strings.HasPrefix(closing, fence) &&
+				strings.Trim(closing, fence[:1]) == "" {
+				fence = ""
+			}
+			lineStart = next
+			continue
+		}
+
+		switch {
+		case fenceMarker(line) != "":
+			addBlock(lineStart)
+			fence = fenceMarker(line)
+		case strings.TrimSpace(line) == "":
+			addBlock(lineStart)
+		case headingRe.MatchString(line):
+			addBlock(lineStart)
+			m := headingRe.FindStringSubmatch(line)
+			sections = append(
+				sections, Section{Heading: m[2], Anchor: Anchor(m[2]), Level: len(m[1]), Start: lineStart},
+			)
+			if start := lineStart + strings.Index(line, m[2]); m[2] != "" {
+				sentences = append(
+					sentences, Sentence{
+						Text: m[2], Start: start, End: start + len(m[2]), Section: len(sections) - 1, Heading: true,
+					},
+				)
+			}
+		case strings.Contains(line, " | ") || strings.HasPrefix(strings.TrimSpace(line), "|"):
+			// Table rows are separate sentences
+			addBlock(lineStart)
+			blockStart = lineStart
+			addBlock(lineEnd)
+		default:
+			if m := blockMarkerRe.FindString(line); m != "" {
+				addBlock(lineStart)
+				blockStart = lineStart + len(m)
+			} else if blockStart < 0 {
+				blockStart = lineStart
+			}
+		}
+
+		lineStart = next
+	}
+	addBlock(len(text))
+
+	return sentences, sections
+}
+
+// Split paragraph into sentences
+func splitSentences(text string, start, end, section int) []Sentence {
+	result := make([]Sentence, 0)
+
+	add := func(s, e int) {
+		// Trim spaces and join soft wrapped lines keeping offsets of the original text
+		for s < e {
+			r, size := utf8.DecodeRuneInString(text[s:e])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			s += size
+		}
+		for e > s {
+			r, size := utf8.DecodeLastRuneInString(text[s:e])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			e -= size
+		}
+		if e > s {
+			sentence := Sentence{Text: strings.Join(strings.Fields(text[s:e]), " "), Start: s, End: e, Section: section}
+			result = append(result, sentence)
+		}
+	}
+
+	sentenceStart := start
+	inCode := false
+	for i := start; i < end; {
+		r, size := utf8.DecodeRuneInString(text[i:end])
+
+		if r == '`' {
+			inCode = !inCode
+		}
+		if inCode || (r != '.' && r != '!' && r != '?') {
+			i += size
+			continue
+		}
+
+		// Punctuation run with closing quotes and brackets: "end.)" or "really?!"
+		punctEnd := i
+		for punctEnd < end {
+			p, pSize := utf8.DecodeRuneInString(text[punctEnd:end])
+			if !strings.ContainsRune(".!?\"')]”’", p) {
+				break
+			}
+			punctEnd += pSize
+		}
+		if punctEnd >= end {
+			break
+		}
+
+		next, _ := utf8.DecodeRuneInString(text[punctEnd:end])
+		boundary := unicode.IsSpace(next) && sentenceFollows(text[punctEnd:end])
+		if !boundary || (r == '.' && isAbbreviation(text[start:i])) {
+			i = punctEnd
+			continue
+		}
+
+		add(sentenceStart, punctEnd)
+		sentenceStart = punctEnd
+		i = punctEnd
+	}
+	add(sentenceStart, end)
+
+	return result
+}
+
+// Next sentence starts with a capital letter, a digit, a quote, a bracket or inline code
+func sentenceFollows(rest string) bool {
+	rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+	if rest == "" {
+		return false
+	}
+
+	r, _ := utf8.DecodeRuneInString(rest)
+
+	return unicode.IsUpper(r) || unicode.IsDigit(r) || strings.ContainsRune("\"'“‘([`*_", r)
+}
+
+// Check if the text before a dot ends with an abbreviation or an initial like "J."
+func isAbbreviation(before string) bool {
+	i := len(before)
+	for i > 0 {
+		r, size := utf8.DecodeLastRuneInString(before[:i])
+		if !unicode.IsLetter(r) && r != '.' {
+			break
+		}
+		i -= size
+	}
+	word := before[i:]
+
+	if utf8.RuneCountInString(word) == 1 {
+		r, _ := utf8.DecodeRuneInString(word)
+		return unicode.IsUpper(r)
+	}
+
+	return abbreviations[strings.ToLower(word)]
+}
+
+// Anchor of a heading: lowercase words joined by hyphens without punctuation
+func Anchor(heading string) string {
+	heading = strings.ReplaceAll(heading, "`", "")
+	heading = anchorDropRe.ReplaceAllString(strings.ToLower(heading), "")
+
+	return strings.Join(strings.Fields(heading), "-")
+}
@@ -0,0 +1,311 @@
+package tokenizer
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Word occurrence in a text
+type Token struct {
+	// Surface form as written in the text
+	Text string
+	// Lowercase dictionary form
+	Lemma string
+	// Byte offsets of the surface form in the text
+	Start int
+	End   int
+	// Token is a part of an identifier or was found in a code block
+	FromCode bool
+}
+
+// Lemma with all surface forms seen in a text
+type Term struct {
+	Lemma string
+	// Lowercase surface forms, most frequent first
+	Forms []string
+	Count int
+	// Occurrences found in code
+	CodeCount int
+}
+
+type Options struct {
+	// Split identifiers and code blocks into words instead of skipping them
+	SplitIdentifiers bool
+	// Check if a lowercase word of prose is kept whole although it looks like an identifier, for names
+	// like "GitHub" or "PostgreSQL"
+	KeepWord func(word string) bool
+}
+
+// Contraction endings and the words they stand for
+var contractions = []struct {
+	suffix string
+	lemma  string
+}{
+	{"n't", "not"},
+	{"'re", "be"},
+	{"'m", "be"},
+	{"'ve", "have"},
+	{"'ll", "will"},
+	{"'d", "would"},
+}
+
+// Negated forms whose base is changed by the contraction
+var negations = map[string]string{
+	"ca":  "can",
+	"wo":  "will",
+	"sha": "shall",
+	"ai":  "be",
+}
+
+// Words after which "'s" means "is" or "us" instead of a possessive
+var sContractions = map[string]string{
+	"it": "be", "that": "be", "what": "be", "there": "be", "here": "be", "he": "be", "she": "be",
+	"who": "be", "where": "be", "how": "be", "when": "be", "why": "be", "this": "be",
+	"everything": "be", "everyone": "be", "nothing": "be", "something": "be", "someone": "be",
+	"let": "we",
+}
+
+// Tokenize splits text into english words with their lemmas.
+// Contractions are split into separate tokens, possessive endings are dropped from lemmas,
+// words with digits or non latin letters are skipped.
+// Fenced code blocks, inline code spans and identifiers are skipped unless SplitIdentifiers is set,
+// in that case they are split into words marked as FromCode.
+func Tokenize(text string, opts Options) []Token {
+	tokens := make([]Token, 0)
+
+	for _, s := range segments(text) {
+		if !s.code {
+			tokens = append(tokens, tokenizeProse(text, s.start, s.end, opts)...)
+			continue
+		}
+		if opts.SplitIdentifiers {
+			tokens = append(tokens, tokenizeCode(text, s.start, s.end)...)
+		}
+	}
+
+	return tokens
+}
+
+func tokenizeProse(text string, start, end int, opts Options) []Token {
+	tokens := make([]Token, 0)
+
+	for i := start; i < end; {
+		r, size := utf8.DecodeRuneInString(text[i:end])
+		if !isWordRune(r) {
+			i += size
+			continue
+		}
+
+		wordStart := i
+		i = wordEnd(text[:end], i)
+		word := text[wordStart:i]
+
+		switch {
+		case opts.KeepWord != nil && isLatinWord(word) && opts.KeepWord(strings.ToLower(word)):
+			tokens = append(tokens, splitWord(word, wordStart)...)
+		case isIdentifier(word):
+			if opts.SplitIdentifiers {
+				tokens = append(tokens, identifierTokens(word, wordStart)...)
+			}
+		case isLatinWord(word):
+			tokens = append(tokens, splitWord(word, wordStart)...)
+		}
+	}
+
+	return tokens
+}
+
+// Every identifier in code is split into words, punctuation and literals are dropped
+func tokenizeCode(text string, start, end int) []Token {
+	tokens := make([]Token, 0)
+
+	for i := start; i < end; {
+		r, size := utf8.DecodeRuneInString(text[i:end])
+		if !isWordRune(r) && r != '_' {
+			i += size
+			continue
+		}
+
+		wordStart := i
+		for i < end {
+			r, size = utf8.DecodeRuneInString(text[i:end])
+			if !isWordRune(r) && r != '_' {
+				break
+			}
+			i += size
+		}
+
+		tokens = append(tokens, identifierTokens(text[wordStart:i], wordStart)...)
+	}
+
+	return tokens
+}
+
+func identifierTokens(identifier string, offset int) []Token {
+	tokens := make([]Token, 0)
+	for _, part := range splitIdentifier(identifier) {
+		if !isLatinWord(part.Text) {
+			continue
+		}
+		tokens = append(
+			tokens, Token{
+				Text:     part.Text,
+				Lemma:    Lemma(strings.ToLower(part.Text)),
+				Start:    offset + part.Start,
+				End:      offset + part.End,
+				FromCode: true,
+			},
+		)
+	}
+
+	return tokens
+}
+
+// Terms groups tokens by lemma, the most frequent lemmas go first
+func Terms(tokens []Token) []Term {
+	type formCount struct {
+		form  string
+		count int
+	}
+
+	counts := make(map[string]int)
+	forms := make(map[string][]*formCount)
+	codeCounts := make(map[string]int)
+	for _, t := range tokens {
+		counts[t.Lemma]++
+		if t.FromCode {
+			codeCounts[t.Lemma]++
+		}
+
+		form := strings.ToLower(normalizeApostrophes(t.Text))
+		found := false
+		for _, fc := range forms[t.Lemma] {
+			if fc.form == form {
+				fc.count++
+				found = true
+				break
+			}
+		}
+		if !found {
+			forms[t.Lemma] = append(forms[t.Lemma], &formCount{form: form, count: 1})
+		}
+	}
+
+	terms := make([]Term, 0, len(counts))
+	for lemma, count := range counts {
+		lemmaForms := forms[lemma]
+		sort.SliceStable(
+			lemmaForms, func(i, j int) bool {
+				if lemmaForms[i].count != lemmaForms[j].count {
+					return lemmaForms[i].count > lemmaForms[j].count
+				}
+				return lemmaForms[i].form < lemmaForms[j].form
+			},
+		)
+
+		term := Term{
+			Lemma: lemma, Count: count, CodeCount: codeCounts[lemma], Forms: make([]string, 0, len(lemmaForms)),
+		}
+		for _, fc := range lemmaForms {
+			term.Forms = append(term.Forms, fc.form)
+		}
+		terms = append(terms, term)
+	}
+
+	sort.Slice(
+		terms, func(i, j int) bool {
+			if terms[i].Count != terms[j].Count {
+				return terms[i].Count > terms[j].Count
+			}
+			return terms[i].Lemma < terms[j].Lemma
+		},
+	)
+
+	return terms
+}
+
+// Find end of the word started at i, apostrophes, hyphens and underscores are kept between letters
+func wordEnd(text string, i int) int {
+	for i < len(text) {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if isWordRune(r) {
+			i += size
+			continue
+		}
+
+		if r == '\'' || r == '’' || r == '-' || r == '_' {
+			next, _ := utf8.DecodeRuneInString(text[i+size:])
+			if isWordRune(next) {
+				i += size
+				continue
+			}
+		}
+
+		break
+	}
+
+	return i
+}
+
+// Split word into tokens handling contractions and possessives
+func splitWord(word string, offset int) []Token {
+	lower := strings.ToLower(normalizeApostrophes(word))
+
+	// Apostrophe position in the original word, "’" is longer than "'" so lower can not be used
+	apostrophe := max(strings.LastIndex(word, "'"), strings.LastIndex(word, "’"))
+
+	for _, c := range contractions {
+		if !strings.HasSuffix(lower, c.suffix) || len(lower) == len(c.suffix) {
+			continue
+		}
+
+		base := lower[:len(lower)-len(c.suffix)]
+		if c.suffix == "n't" {
+			if lemma, ok := negations[base]; ok {
+				return pair(word, offset, apostrophe-1, lemma, c.lemma)
+			}
+			return pair(word, offset, apostrophe-1, Lemma(base), c.lemma)
+		}
+
+		return pair(word, offset, apostrophe, Lemma(base), c.lemma)
+	}
+
+	if strings.HasSuffix(lower, "'s") {
+		base := lower[:len(lower)-2]
+		if lemma, ok := sContractions[base]; ok {
+			return pair(word, offset, apostrophe, Lemma(base), lemma)
+		}
+
+		return []Token{{Text: word, Lemma: Lemma(base), Start: offset, End: offset + len(word)}}
+	}
+
+	return []Token{{Text: word, Lemma: Lemma(lower), Start: offset, End: offset + len(word)}}
+}
+
+// Two tokens for a word split at a byte position
+func pair(word string, offset, split int, baseLemma, suffixLemma string) []Token {
+	return []Token{
+		{Text: word[:split], Lemma: baseLemma, Start: offset, End: offset + split},
+		{Text: word[split:], Lemma: suffixLemma, Start: offset + split, End: offset + len(word)},
+	}
+}
+
+func normalizeApostrophes(s string) string {
+	return strings.ReplaceAll(s, "’", "'")
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isLatinWord(word string) bool {
+	for _, r := range word {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && r != '\'' && r != '’' && r != '-' {
+			return false
+		}
+	}
+
+	return true
+}
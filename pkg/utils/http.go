@@ -2,11 +2,15 @@ package utils
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 
+	"github.com/shlembo598/text-lexicon-go/internal/models"
 	"github.com/shlembo598/text-lexicon-go/pkg/logger/sl"
+	"github.com/shlembo598/text-lexicon-go/pkg/utils/httpErrors"
 )
 
 // ReqIDCtxKey is a key used for the Request ID in context
@@ -30,6 +34,16 @@ func GetRequestCtx(c echo.Context) context.Context {
 	return context.WithValue(c.Request().Context(), ReqIDCtxKey{}, GetRequestID(c))
 }
 
+// Get user from context set by auth middleware
+func GetUserFromCtx(ctx context.Context) (*models.User, error) {
+	user, ok := ctx.Value(UserCtxKey{}).(*models.User)
+	if !ok {
+		return nil, httpErrors.Unauthorized
+	}
+
+	return user, nil
+}
+
 // Read request body and validate
 func ReadRequest(ctx echo.Context, request interface{}) error {
 	if err := ctx.Bind(request); err != nil {
@@ -47,3 +61,37 @@ func LogResponseError(ctx echo.Context, err error) {
 		sl.Err(err),
 	)
 }
+
+// Get dictionary filters from query parameters
+func GetDictionaryParams(c echo.Context) (*models.DictionaryParams, error) {
+	params := &models.DictionaryParams{
+		PartOfSpeech: c.QueryParam("pos"), MinLevel: c.QueryParam("min_level"), Sort: c.QueryParam("sort"),
+		Names: c.QueryParam("names"),
+	}
+
+	if hideKnown := c.QueryParam("hide_known"); hideKnown != "" {
+		value, err := strconv.ParseBool(hideKnown)
+		if err != nil {
+			return nil, httpErrors.NewBadRequestError(err)
+		}
+		params.HideKnown = &value
+	}
+
+	if minFrequency := c.QueryParam("min_frequency"); minFrequency != "" {
+		value, err := strconv.Atoi(minFrequency)
+		if err != nil || value < 0 {
+			return nil, httpErrors.NewBadRequestError(fmt.Errorf("invalid min_frequency: %s", minFrequency))
+		}
+		params.MinFrequency = value
+	}
+
+	if atMyLevel := c.QueryParam("at_my_level"); atMyLevel != "" {
+		value, err := strconv.ParseBool(atMyLevel)
+		if err != nil {
+			return nil, httpErrors.NewBadRequestError(err)
+		}
+		params.AtMyLevel = value
+	}
+
+	return params, nil
+}